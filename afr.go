@@ -0,0 +1,142 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/UNO-SOFT/giro/model"
+	"github.com/UNO-SOFT/zlog/v2"
+)
+
+// DefaultAFRPattern matches the file name of the instant payment (AFR) participant list on DefaultURL.
+const DefaultAFRPattern = `(?i)^AFR.*\.(pdf|xlsx?)$`
+
+// AFRParticipant is a participant of the instant payment (AFR) system.
+type AFRParticipant struct {
+	// Bankkod is the 3-digit GIRO code of the bank, the prefix of its Bankszerv codes.
+	Bankkod string
+	Nev     string
+	BIC     string
+}
+
+// FetchAFR searches DefaultURL for the AFR participant list, downloads and parses it.
+func FetchAFR(ctx context.Context, opts ...Option) ([]AFRParticipant, error) {
+	u, err := SearchXLSURL(ctx, DefaultURL, DefaultAFRPattern, opts...)
+	if err != nil {
+		return nil, err
+	}
+	_, rc, err := DownloadFile(ctx, u, opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return ParseAFR(ctx, rc, opts...)
+}
+
+// ParseAFR parses the AFR participant list, in PDF or XLSX (or any format read by ExtractRows).
+//
+// The columns are found by the header row: the bank code, the name and the BIC.
+func ParseAFR(ctx context.Context, r io.Reader, opts ...Option) ([]AFRParticipant, error) {
+	logger := zlog.SFromContext(ctx)
+	logger.Info("ParseAFR")
+	o := newOptions(opts)
+	sr, err := o.sectionReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var participants []AFRParticipant
+	kodCol, nevCol, bicCol := -1, -1, -1
+	fn := func(row []string) error {
+		if kodCol < 0 || bicCol < 0 {
+			kodCol, nevCol, bicCol = -1, -1, -1
+			for i, h := range row {
+				h = strings.ToLower(strings.TrimSpace(h))
+				switch {
+				case strings.Contains(h, "bic") || strings.Contains(h, "swift"):
+					bicCol = i
+				case kodCol < 0 && (strings.Contains(h, "kód") || strings.Contains(h, "code") || strings.Contains(h, "bankszerv")):
+					kodCol = i
+				case nevCol < 0 && (strings.Contains(h, "név") || strings.Contains(h, "name") || strings.Contains(h, "megnevezés")):
+					nevCol = i
+				}
+			}
+			return ctx.Err()
+		}
+		get := func(i int) string {
+			if 0 <= i && i < len(row) {
+				return cleanField(row[i])
+			}
+			return ""
+		}
+		p := AFRParticipant{Bankkod: cellToCode(get(kodCol), 3), Nev: get(nevCol), BIC: get(bicCol)}
+		if len(p.Bankkod) > 3 { // a Bankszerv
+			p.Bankkod = p.Bankkod[:3]
+		}
+		if model.IsDigits(p.Bankkod) || p.BIC != "" {
+			participants = append(participants, p)
+		}
+		return ctx.Err()
+	}
+	err = o.tableRows(ctx, sr, fn)
+	if err == nil && len(participants) == 0 {
+		err = fmt.Errorf("AFR participants: %w", ErrNotFound)
+	}
+	logger.Info("ParseAFR", "participants", len(participants), "error", err)
+	return participants, err
+}
+
+// afrIndex indexes the AFR participants by bank code and BIC.
+type afrIndex struct {
+	byBankkod, byBIC map[string]struct{}
+}
+
+// SetAFR sets the instant payment participants used by InstantReachable.
+func (d *Directory) SetAFR(participants []AFRParticipant) {
+	idx := afrIndex{
+		byBankkod: make(map[string]struct{}, len(participants)),
+		byBIC:     make(map[string]struct{}, len(participants)),
+	}
+	for _, p := range participants {
+		if p.Bankkod != "" {
+			idx.byBankkod[p.Bankkod] = struct{}{}
+		}
+		if bic := bic8(p.BIC); bic != "" {
+			idx.byBIC[bic] = struct{}{}
+		}
+	}
+	d.afr.Store(&idx)
+}
+
+// InstantReachable reports whether the branch of bankszerv exists,
+// and its bank participates in the instant payment (AFR) system (see SetAFR).
+func (d *Directory) InstantReachable(bankszerv string) bool {
+	idx := d.afr.Load()
+	if idx == nil {
+		return false
+	}
+	h, ok := d.Lookup(bankszerv)
+	if !ok {
+		return false
+	}
+	if _, ok = idx.byBankkod[h.BankCode()]; ok {
+		return true
+	}
+	_, ok = idx.byBIC[bic8(h.BIC)]
+	return ok && h.BIC != ""
+}
+
+// bic8 returns the institution part of the BIC (without the branch code).
+func bic8(bic string) string {
+	bic = strings.ToUpper(strings.TrimSpace(bic))
+	if len(bic) > 8 {
+		return bic[:8]
+	}
+	return bic
+}
@@ -0,0 +1,55 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestAFR(t *testing.T) {
+	wb := excelize.NewFile()
+	sheet := wb.GetSheetName(0)
+	for i, row := range [][]interface{}{
+		{"Azonnali fizetési rendszer résztvevői"},
+		{"Bankkód", "Résztvevő neve", "BIC"},
+		{"117", "OTP Bank Nyrt.", "OTPVHUHB"},
+		{"", "Valami Bank", "VALAHUHBXXX"},
+	} {
+		cell, _ := excelize.CoordinatesToCellName(1, i+1)
+		if err := wb.SetSheetRow(sheet, cell, &row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var buf bytes.Buffer
+	if _, err := wb.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	ps, err := ParseAFR(context.Background(), &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ps) != 2 || ps[0].Bankkod != "117" || ps[1].BIC != "VALAHUHBXXX" {
+		t.Fatalf("got %+v", ps)
+	}
+
+	d := NewDirectory([]Hitelezo{
+		{Bankszerv: "11773016", Nev: "OTP Bank"},
+		{Bankszerv: "10002003", Nev: "Magyar Államkincstár"},
+		{Bankszerv: "19000002", Nev: "Valami Bank", BIC: "VALAHUHB"},
+	})
+	if d.InstantReachable("11773016") {
+		t.Error("reachable without AFR list")
+	}
+	d.SetAFR(ps)
+	for bs, want := range map[string]bool{"11773016": true, "10002003": false, "19000002": true, "11700000": false} {
+		if got := d.InstantReachable(bs); got != want {
+			t.Errorf("%s: got %t, wanted %t", bs, got, want)
+		}
+	}
+}
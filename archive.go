@@ -0,0 +1,160 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Archive is a content-addressable store of the downloaded files,
+// with an index of their provenance.
+//
+// The files are stored in its directory by their SHA-256 hash,
+// the index is index.jsonl, with an ArchiveEntry on each line.
+type Archive struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// ArchiveEntry is a download stored in the Archive.
+type ArchiveEntry struct {
+	// SHA256 is the hex-encoded SHA-256 hash of the file.
+	SHA256   string
+	URL      string
+	Filename string `json:",omitempty"`
+	Size     int64
+	// Downloaded is the time of the download.
+	Downloaded   time.Time
+	LastModified time.Time `json:",omitempty"`
+	// EffectiveDate is parsed from the Filename or the URL, zero if unknown.
+	EffectiveDate time.Time `json:",omitempty"`
+}
+
+const archiveIndex = "index.jsonl"
+
+// OpenArchive opens (creates) the Archive in dir.
+func OpenArchive(dir string) (*Archive, error) {
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, err
+	}
+	return &Archive{dir: dir}, nil
+}
+
+// WithArchive makes DownloadFile store the downloaded file in the Archive,
+// and return the archived copy.
+func WithArchive(a *Archive) Option {
+	return func(o *options) { o.archive = a }
+}
+
+// Add stores the contents of r with its provenance in e (SHA256 and Size are computed),
+// and adds it to the index.
+func (a *Archive) Add(r io.Reader, e ArchiveEntry) (ArchiveEntry, error) {
+	tmp, err := os.CreateTemp(a.dir, ".giro-*")
+	if err != nil {
+		return e, err
+	}
+	defer os.Remove(tmp.Name())
+	h := sha256.New()
+	e.Size, err = io.Copy(io.MultiWriter(tmp, h), r)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return e, fmt.Errorf("archive %s: %w", e.URL, err)
+	}
+	e.SHA256 = hex.EncodeToString(h.Sum(nil))
+	if e.Downloaded.IsZero() {
+		e.Downloaded = time.Now()
+	}
+	if e.EffectiveDate.IsZero() {
+		name := e.Filename
+		if name == "" {
+			name = e.URL
+		}
+		e.EffectiveDate, _ = EffectiveDate(name)
+	}
+	line, err := json.Marshal(e)
+	if err != nil {
+		return e, err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if err = os.Rename(tmp.Name(), a.path(e.SHA256)); err != nil {
+		return e, fmt.Errorf("archive %s: %w", e.URL, err)
+	}
+	fh, err := os.OpenFile(filepath.Join(a.dir, archiveIndex), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0640)
+	if err != nil {
+		return e, err
+	}
+	if _, err = fh.Write(append(line, '\n')); err != nil {
+		fh.Close()
+		return e, err
+	}
+	return e, fh.Close()
+}
+
+// List returns the entries of the index, in the order of their addition.
+func (a *Archive) List() ([]ArchiveEntry, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	fh, err := os.Open(filepath.Join(a.dir, archiveIndex))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer fh.Close()
+	var entries []ArchiveEntry
+	scanner := bufio.NewScanner(fh)
+	for scanner.Scan() {
+		var e ArchiveEntry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return entries, fmt.Errorf("%s:%d: %w", archiveIndex, len(entries)+1, err)
+		}
+		entries = append(entries, e)
+	}
+	return entries, scanner.Err()
+}
+
+// Open returns the archived file with the given hash.
+func (a *Archive) Open(sha256 string) (*os.File, error) {
+	if _, err := hex.DecodeString(sha256); err != nil || len(sha256) != 64 {
+		return nil, fmt.Errorf("%q: %w", sha256, ErrNotFound)
+	}
+	fh, err := os.Open(a.path(sha256))
+	if errors.Is(err, os.ErrNotExist) {
+		err = fmt.Errorf("%s: %w", sha256, ErrNotFound)
+	}
+	return fh, err
+}
+
+// Parse re-parses the archived file of e, returning its Source, too
+// (overriding WithSource in opts).
+func (a *Archive) Parse(ctx context.Context, e ArchiveEntry, opts ...Option) ([]Hitelezo, Source, error) {
+	src := Source{URL: e.URL, Filename: e.Filename, LastModified: e.LastModified, EffectiveDate: e.EffectiveDate}
+	fh, err := a.Open(e.SHA256)
+	if err != nil {
+		return nil, src, err
+	}
+	defer fh.Close()
+	hs, err := Parse(ctx, fh, append(slices.Clip(opts), WithSource(&src))...)
+	return hs, src, err
+}
+
+func (a *Archive) path(sha256 string) string { return filepath.Join(a.dir, sha256) }
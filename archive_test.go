@@ -0,0 +1,71 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestArchive(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteXLSX(&buf, []Hitelezo{
+		{Bankszerv: "10002003", Nev: "Magyar Államkincstár", Irszam: "1139", Cim: "Budapest, Váci út 71."},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Disposition", `attachment; filename="EHT_20260101.xlsx"`)
+		w.Write(buf.Bytes())
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	a, err := OpenArchive(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var src Source
+	for range 2 {
+		_, rc, err := DownloadFile(ctx, srv.URL, WithArchive(a))
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = Parse(ctx, rc, WithSource(&src))
+		rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	entries, err := a.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("got %d entries, wanted 2", len(entries))
+	}
+	e := entries[1]
+	if e.SHA256 != src.SHA256 || e.URL != srv.URL || e.Filename != "EHT_20260101.xlsx" ||
+		e.Size != int64(buf.Len()) || !e.EffectiveDate.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("got %+v, source %+v", e, src)
+	}
+	hs, src2, err := a.Parse(ctx, e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hs) != 1 || src2.SHA256 != e.SHA256 || src2.Format != FormatXLSX {
+		t.Errorf("got %+v, %+v", hs, src2)
+	}
+	if _, err := a.Open(strings.Repeat("0", 64)); !errors.Is(err, ErrNotFound) {
+		t.Errorf("got %+v, wanted ErrNotFound", err)
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/UNO-SOFT/giro/model"
+	"github.com/UNO-SOFT/zlog/v2"
+)
+
+// AVT is a settlement endpoint record of the AVT_dd_mm_yyyy publication.
+type AVT struct {
+	// Vegpont is the code of the settlement endpoint.
+	Vegpont string
+	Nev     string
+	BIC     string
+	// Ervenyes is the validity as published (date or period), if given.
+	Ervenyes string
+	// Egyeb holds the other columns, by header name.
+	Egyeb map[string]string `json:",omitempty"`
+}
+
+// avtColumns maps the known (lowercase) header name fragments to the AVT fields.
+var avtColumns = []struct {
+	field     func(*AVT) *string
+	fragments []string
+}{
+	{func(a *AVT) *string { return &a.Vegpont }, []string{"végpont", "vegpont", "kód", "kod", "code", "azonosító"}},
+	{func(a *AVT) *string { return &a.BIC }, []string{"bic", "swift"}},
+	{func(a *AVT) *string { return &a.Nev }, []string{"név", "nev", "megnevezés", "name"}},
+	{func(a *AVT) *string { return &a.Ervenyes }, []string{"érvényes", "hatály", "kezdete", "valid"}},
+}
+
+// avtMapping maps the columns of the header row to the AVT fields,
+// reporting whether it looks like an AVT header at all.
+func avtMapping(header []string) ([]func(*AVT) *string, bool) {
+	mapping := make([]func(*AVT) *string, len(header))
+	var known int
+	var vegpontSeen bool
+	for i, h := range header {
+		h = strings.ToLower(strings.TrimSpace(h))
+	Columns:
+		for j, c := range avtColumns {
+			for _, f := range c.fragments {
+				if strings.Contains(h, f) {
+					if j == 0 {
+						if vegpontSeen {
+							continue
+						}
+						vegpontSeen = true
+					}
+					mapping[i] = c.field
+					known++
+					break Columns
+				}
+			}
+		}
+	}
+	return mapping, vegpontSeen && known >= 2
+}
+
+// ParseAVT parses the AVT (settlement endpoint) publication, in PDF or XLSX (or any format read by ExtractRows).
+//
+// The columns are mapped by the header row;
+// the unknown columns are returned in Egyeb.
+func ParseAVT(ctx context.Context, r io.Reader, opts ...Option) ([]AVT, error) {
+	logger := zlog.SFromContext(ctx)
+	logger.Info("ParseAVT")
+	o := newOptions(opts)
+	sr, err := o.sectionReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []AVT
+	var header []string
+	var mapping []func(*AVT) *string
+	fn := func(row []string) error {
+		if mapping == nil {
+			var ok bool
+			if mapping, ok = avtMapping(row); !ok {
+				mapping = nil
+			} else {
+				header = append(header[:0], row...)
+			}
+			return ctx.Err()
+		}
+		var rec AVT
+		for i, s := range row {
+			if s = cleanField(s); s == "" {
+				continue
+			}
+			if i < len(mapping) && mapping[i] != nil {
+				*mapping[i](&rec) = s
+			} else if i < len(header) {
+				if rec.Egyeb == nil {
+					rec.Egyeb = make(map[string]string)
+				}
+				rec.Egyeb[cleanField(header[i])] = s
+			}
+		}
+		if rec.Vegpont = cellToCode(rec.Vegpont, 0); model.IsDigits(rec.Vegpont) {
+			records = append(records, rec)
+		}
+		return ctx.Err()
+	}
+
+	err = o.tableRows(ctx, sr, fn)
+	if err == nil && mapping == nil {
+		err = fmt.Errorf("AVT header: %w", ErrNotFound)
+	}
+	logger.Info("ParseAVT", "records", len(records), "error", err)
+	return records, err
+}
@@ -0,0 +1,44 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestParseAVT(t *testing.T) {
+	wb := excelize.NewFile()
+	sheet := wb.GetSheetName(0)
+	for i, row := range [][]interface{}{
+		{"Átutalási végpontok tábla"},
+		{"Végpont kód", "Végpont neve", "BIC kód", "Érvényesség kezdete", "Megjegyzés"},
+		{"100", "Magyar Államkincstár", "HUSTHUHB", "2021.04.01", ""},
+		{float64(117), "OTP Bank Nyrt.", "OTPVHUHB", "2021.04.01", "IG2"},
+	} {
+		cell, _ := excelize.CoordinatesToCellName(1, i+1)
+		if err := wb.SetSheetRow(sheet, cell, &row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var buf bytes.Buffer
+	if _, err := wb.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	avts, err := ParseAVT(context.Background(), &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(avts) != 2 {
+		t.Fatalf("got %d records, wanted 2: %+v", len(avts), avts)
+	}
+	if got := avts[1]; got.Vegpont != "117" || got.Nev != "OTP Bank Nyrt." || got.BIC != "OTPVHUHB" ||
+		got.Ervenyes != "2021.04.01" || got.Egyeb["Megjegyzés"] != "IG2" {
+		t.Errorf("got %+v", got)
+	}
+}
@@ -0,0 +1,18 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import "github.com/UNO-SOFT/giro/model"
+
+// Bank is a credit institution owning a 3-digit GIRO bank code.
+type Bank = model.Bank
+
+// LookupBank returns the Bank of the bank code: the first 3 digits of code,
+// which may be a Bankszerv or an account number, too.
+func LookupBank(code string) (Bank, bool) { return model.LookupBank(code) }
+
+// BankName returns the legal name of the institution of the bank code (see LookupBank),
+// or "" if it is unknown.
+func BankName(code string) string { return model.BankName(code) }
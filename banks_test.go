@@ -0,0 +1,30 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import "testing"
+
+func TestBankName(t *testing.T) {
+	for _, tc := range []struct {
+		Code, Want string
+	}{
+		{"117", "OTP Bank Nyrt."},
+		{"11773016", "OTP Bank Nyrt."},
+		{"11773016-20000000", "OTP Bank Nyrt."},
+		{"103", "MBH Bank Nyrt."},
+		{"999", ""},
+		{"1", ""},
+	} {
+		if got := BankName(tc.Code); got != tc.Want {
+			t.Errorf("%q: got %q, wanted %q", tc.Code, got, tc.Want)
+		}
+	}
+	if b, ok := LookupBank("12010855"); !ok || b.BIC != "UBRTHUHB" {
+		t.Errorf("got %+v, %t", b, ok)
+	}
+	if got := (Hitelezo{Bankszerv: "10918001"}).BankName(); got != "UniCredit Bank Hungary Zrt." {
+		t.Errorf("got %q", got)
+	}
+}
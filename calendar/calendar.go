@@ -0,0 +1,195 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package calendar tells the settlement days of the GIRO clearing.
+//
+// The settlement days are the Hungarian working days:
+// Monday to Friday, except the public holidays,
+// with the exceptions (working Saturdays, bridge days) published yearly.
+package calendar
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Calendar is a clearing calendar: the default rules and the published exceptions.
+//
+// The zero value knows only the public holidays.
+type Calendar struct {
+	// exceptions maps the days to whether they are settlement days.
+	exceptions map[date]bool
+}
+
+type date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+func dateOf(t time.Time) date {
+	y, m, d := t.Date()
+	return date{Year: y, Month: m, Day: d}
+}
+
+// New returns a Calendar with the given exceptions:
+// days mapped to true are settlement days, days mapped to false are not,
+// regardless of the default rules.
+func New(exceptions map[time.Time]bool) *Calendar {
+	c := Calendar{exceptions: make(map[date]bool, len(exceptions))}
+	for t, ok := range exceptions {
+		c.exceptions[dateOf(t)] = ok
+	}
+	return &c
+}
+
+// Default is the Calendar used by IsSettlementDay and NextSettlementDay.
+var Default = new(Calendar)
+
+// IsSettlementDay reports whether t is a settlement day by Default.
+func IsSettlementDay(t time.Time) bool { return Default.IsSettlementDay(t) }
+
+// NextSettlementDay returns the first settlement day after t by Default.
+func NextSettlementDay(t time.Time) time.Time { return Default.NextSettlementDay(t) }
+
+// IsSettlementDay reports whether t is a settlement day.
+//
+// Only the date of t is considered (in its location).
+func (c *Calendar) IsSettlementDay(t time.Time) bool {
+	d := dateOf(t)
+	if ok, found := c.exceptions[d]; found {
+		return ok
+	}
+	switch t.Weekday() {
+	case time.Saturday, time.Sunday:
+		return false
+	}
+	return !isHoliday(d)
+}
+
+// NextSettlementDay returns the midnight of the first settlement day after the day of t.
+func (c *Calendar) NextSettlementDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	t = time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+	for i := 0; i < 366; i++ {
+		if t = t.AddDate(0, 0, 1); c.IsSettlementDay(t) {
+			return t
+		}
+	}
+	return t
+}
+
+// isHoliday reports whether d is a Hungarian public holiday.
+func isHoliday(d date) bool {
+	switch d.Month {
+	case time.January:
+		return d.Day == 1
+	case time.March:
+		if d.Day == 15 {
+			return true
+		}
+	case time.May:
+		if d.Day == 1 {
+			return true
+		}
+	case time.August:
+		return d.Day == 20
+	case time.October:
+		return d.Day == 23
+	case time.November:
+		return d.Day == 1
+	case time.December:
+		return d.Day == 25 || d.Day == 26
+	}
+	easter := easterSunday(d.Year)
+	for _, off := range []int{-2, 1, 50} { // Good Friday, Easter Monday, Whit Monday
+		if d == dateOf(easter.AddDate(0, 0, off)) {
+			return d.Year >= 2017 || off != -2 // Good Friday is a holiday since 2017
+		}
+	}
+	return false
+}
+
+// easterSunday returns the date of Easter Sunday in the year (Gregorian calendar).
+func easterSunday(year int) time.Time {
+	a := year % 19
+	b, c := year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+var rDate = regexp.MustCompile(`\b(20[0-9]{2})[-. ]+([01]?[0-9])[-. ]+([0-3]?[0-9])\b`)
+
+// Parse parses the published exceptions of the clearing calendar, as text or HTML.
+//
+// Each date (2024.12.07, 2024. 12. 24. or 2024-12-24) is followed by its kind,
+// before the next date on the same line:
+// "munkanap" (working day) or "elszámolási nap" makes it a settlement day,
+// "pihenőnap", "munkaszüneti nap" or "szünnap" makes it a non-settlement day.
+// Dates without kind are ignored.
+func Parse(r io.Reader) (*Calendar, error) {
+	c := Calendar{exceptions: make(map[date]bool)}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64<<10), 1<<20)
+	for scanner.Scan() {
+		line := strings.ToLower(scanner.Text())
+		locs := rDate.FindAllStringSubmatchIndex(line, -1)
+		for i, loc := range locs {
+			end := len(line)
+			if i+1 < len(locs) {
+				end = locs[i+1][0]
+			}
+			var ok bool
+			switch kind := line[loc[1]:end]; {
+			case strings.Contains(kind, "szünnap") || strings.Contains(kind, "pihenőnap") ||
+				strings.Contains(kind, "munkaszüneti"):
+				ok = false
+			case strings.Contains(kind, "munkanap") || strings.Contains(kind, "elszámolási nap"):
+				ok = true
+			default:
+				continue
+			}
+			y, _ := strconv.Atoi(line[loc[2]:loc[3]])
+			m, _ := strconv.Atoi(line[loc[4]:loc[5]])
+			d, _ := strconv.Atoi(line[loc[6]:loc[7]])
+			if m < 1 || m > 12 || d < 1 || d > 31 {
+				return nil, fmt.Errorf("bad date %q", line[loc[0]:loc[1]])
+			}
+			c.exceptions[date{Year: y, Month: time.Month(m), Day: d}] = ok
+		}
+	}
+	return &c, scanner.Err()
+}
+
+// Fetch downloads and parses the published exceptions from the URL (see Parse).
+func Fetch(ctx context.Context, URL string) (*Calendar, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", URL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("%s: %s", URL, resp.Status)
+	}
+	return Parse(resp.Body)
+}
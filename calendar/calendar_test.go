@@ -0,0 +1,68 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package calendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func day(s string) time.Time {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestDefault(t *testing.T) {
+	for s, want := range map[string]bool{
+		"2024-03-14": true,  // Thursday
+		"2024-03-15": false, // national holiday
+		"2024-03-16": false, // Saturday
+		"2024-03-29": false, // Good Friday
+		"2024-04-01": false, // Easter Monday
+		"2024-05-20": false, // Whit Monday
+		"2024-05-21": true,
+		"2016-03-25": true, // Good Friday was a working day before 2017
+		"2025-12-25": false,
+	} {
+		if got := IsSettlementDay(day(s)); got != want {
+			t.Errorf("%s: got %t, wanted %t", s, got, want)
+		}
+	}
+	if got := NextSettlementDay(day("2024-03-28").Add(15 * time.Hour)); !got.Equal(day("2024-04-02")) {
+		t.Errorf("next after Maundy Thursday: got %s", got)
+	}
+}
+
+func TestParse(t *testing.T) {
+	c, err := Parse(strings.NewReader(`<p>Munkanap-áthelyezések 2024-ben</p>
+<p>2024. 12. 07. (szombat) munkanap, 2024. 12. 24. (kedd) pihenőnap</p>
+<p>2024-12-27 pihenőnap</p>
+<p>Kiadva: 2023.09.01</p>
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for s, want := range map[string]bool{
+		"2024-12-07": true,
+		"2024-12-24": false,
+		"2024-12-27": false,
+		"2024-12-23": true,
+		"2023-09-01": true, // no kind, default rules
+	} {
+		if got := c.IsSettlementDay(day(s)); got != want {
+			t.Errorf("%s: got %t, wanted %t", s, got, want)
+		}
+	}
+	if got := c.NextSettlementDay(day("2024-12-20")); !got.Equal(day("2024-12-23")) {
+		t.Errorf("next after 2024-12-20: got %s", got)
+	}
+	if got := c.NextSettlementDay(day("2024-12-23")); !got.Equal(day("2024-12-30")) {
+		t.Errorf("next after 2024-12-23: got %s", got)
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"cmp"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Candidate is a file found by SearchCandidates.
+type Candidate struct {
+	URL, Filename string
+	// Date is the effective date parsed from Filename (see EffectiveDate), zero if unknown.
+	Date time.Time
+	// Kind is the kind of the list: "EHT", "AVT", "SHT", or empty if unknown.
+	Kind string
+}
+
+func newCandidate(URL string) Candidate {
+	c := Candidate{URL: URL, Filename: path.Base(URL)}
+	if u, err := url.Parse(URL); err == nil {
+		c.Filename = path.Base(u.Path)
+	}
+	c.Date, _ = EffectiveDate(c.Filename)
+	for _, kind := range []string{"EHT", "AVT"} {
+		if strings.HasPrefix(c.Filename, kind+"_") {
+			c.Kind = kind
+			break
+		}
+	}
+	if c.Filename == path.Base(DefaultXLSXURL) {
+		c.Kind = "SHT"
+	}
+	return c
+}
+
+// sortCandidates sorts cs by Date, then Filename.
+func sortCandidates(cs []Candidate) {
+	slices.SortFunc(cs, func(a, b Candidate) int {
+		if c := a.Date.Compare(b.Date); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Filename, b.Filename)
+	})
+}
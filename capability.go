@@ -0,0 +1,103 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import "fmt"
+
+// Publication is a published list of the bank branches.
+type Publication string
+
+const (
+	// PublicationSHT is the MNB list (sht.xlsx, DefaultXLSXURL), with BIC and VIBER data.
+	PublicationSHT = Publication("SHT")
+	// PublicationEHT is the GIRO list of the branches (EHT_*.pdf/xls), with the best addresses.
+	PublicationEHT = Publication("EHT")
+)
+
+// Reliability tells how a Publication provides a field.
+type Reliability uint8
+
+const (
+	// Missing fields are not provided.
+	Missing = Reliability(iota)
+	// Partial fields are provided for some of the records, or in a lesser quality.
+	Partial
+	// Complete fields are provided for all the records.
+	Complete
+	// Authoritative fields are provided by the owner of the data, they take precedence.
+	Authoritative
+)
+
+var reliabilityNames = [...]string{"missing", "partial", "complete", "authoritative"}
+
+func (r Reliability) String() string {
+	if int(r) < len(reliabilityNames) {
+		return reliabilityNames[r]
+	}
+	return fmt.Sprintf("Reliability(%d)", uint8(r))
+}
+
+// MarshalText returns the name of r.
+func (r Reliability) MarshalText() ([]byte, error) { return []byte(r.String()), nil }
+
+// The fields of the capability matrix: the fields of Hitelezo, and VIBER membership.
+const (
+	FieldBankszerv = "Bankszerv"
+	FieldBIC       = "BIC"
+	FieldNev       = "Nev"
+	FieldIrszam    = "Irszam"
+	FieldCim       = "Cim"
+	FieldVIBER     = "VIBER"
+)
+
+// capabilities is the capability matrix.
+var capabilities = map[Publication]map[string]Reliability{
+	PublicationSHT: {
+		FieldBankszerv: Complete,
+		FieldBIC:       Authoritative,
+		FieldNev:       Complete,
+		FieldIrszam:    Partial, // part of the address
+		FieldCim:       Partial,
+		FieldVIBER:     Authoritative,
+	},
+	PublicationEHT: {
+		FieldBankszerv: Authoritative,
+		FieldBIC:       Missing,
+		FieldNev:       Authoritative,
+		FieldIrszam:    Authoritative,
+		FieldCim:       Authoritative,
+		FieldVIBER:     Missing,
+	},
+}
+
+// Capabilities returns the capability matrix: the Reliability of each field, by Publication.
+//
+// The returned maps are copies.
+func Capabilities() map[Publication]map[string]Reliability {
+	m := make(map[Publication]map[string]Reliability, len(capabilities))
+	for p, fields := range capabilities {
+		m[p] = make(map[string]Reliability, len(fields))
+		for f, r := range fields {
+			m[p][f] = r
+		}
+	}
+	return m
+}
+
+// Capability returns the Reliability of the field in the Publication.
+func Capability(p Publication, field string) Reliability { return capabilities[p][field] }
+
+// Preferred returns the Publication with the most reliable field from the given ones,
+// the first one on ties.
+func Preferred(field string, publications ...Publication) Publication {
+	var best Publication
+	var bestR Reliability
+	for i, p := range publications {
+		if r := Capability(p, field); i == 0 || r > bestR {
+			best, bestR = p, r
+		}
+	}
+	return best
+}
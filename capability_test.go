@@ -0,0 +1,42 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCapabilities(t *testing.T) {
+	for _, tc := range []struct {
+		Field string
+		Want  Publication
+	}{
+		{FieldBIC, PublicationSHT},
+		{FieldVIBER, PublicationSHT},
+		{FieldCim, PublicationEHT},
+		{FieldBankszerv, PublicationEHT},
+	} {
+		if got := Preferred(tc.Field, PublicationSHT, PublicationEHT); got != tc.Want {
+			t.Errorf("%s: got %s, wanted %s", tc.Field, got, tc.Want)
+		}
+		if got := Preferred(tc.Field, PublicationEHT, PublicationSHT); got != tc.Want {
+			t.Errorf("%s reversed: got %s, wanted %s", tc.Field, got, tc.Want)
+		}
+	}
+
+	m := Capabilities()
+	m[PublicationEHT][FieldBIC] = Authoritative
+	if Capability(PublicationEHT, FieldBIC) != Missing {
+		t.Error("Capabilities is not a copy")
+	}
+	b, err := json.Marshal(Capabilities()[PublicationSHT])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(b), `{"BIC":"authoritative","Bankszerv":"complete","Cim":"partial","Irszam":"partial","Nev":"complete","VIBER":"authoritative"}`; got != want {
+		t.Errorf("got %s, wanted %s", got, want)
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"strings"
+
+	"github.com/UNO-SOFT/giro/model"
+)
+
+var (
+	// ErrCheckDigit is the error reported for a check digit (CDV) mismatch.
+	ErrCheckDigit = model.ErrCheckDigit
+	// ErrAccountNumber is returned by NormalizeAccountNumber for an impossible account number.
+	ErrAccountNumber = model.ErrAccountNumber
+)
+
+// CheckDigitError is reported when the check digit column of a record
+// contradicts the check digit computed from its Bankszerv.
+type CheckDigitError = model.CheckDigitError
+
+// checkDigit returns the check digit completing the digits of s.
+func checkDigit(s string) (byte, bool) { return model.ComputeCheckDigit(s) }
+
+// NormalizeAccountNumber returns the canonical form of the Hungarian account number
+// (see model.NormalizeAccountNumber).
+func NormalizeAccountNumber(s string) (string, error) { return model.NormalizeAccountNumber(s) }
+
+// ValidateAccountNumber returns the canonical form of the account number, verifying its check digits
+// (see model.ValidateAccountNumber).
+func ValidateAccountNumber(s string) (string, error) { return model.ValidateAccountNumber(s) }
+
+// IBAN returns the Hungarian IBAN of the account number, in groups of 4 characters.
+func IBAN(s string) (string, error) { return model.IBAN(s) }
+
+// FormatAccountNumber returns the account number in the usual 8-8 or 8-8-8 digit groups,
+// or s unchanged if it is not a valid account number (see NormalizeAccountNumber).
+func FormatAccountNumber(s string) string { return model.FormatAccountNumber(s) }
+
+// cdvColumn returns the index of the check digit column in the header row, or -1.
+func cdvColumn(header []string) int {
+	for i, s := range header {
+		s = strings.ToLower(strings.TrimSpace(s))
+		if s == "cdv" || s == "check digit" || strings.HasPrefix(s, "ellenőrző") {
+			return i
+		}
+	}
+	return -1
+}
+
+// splitCDV removes the k-th (check digit) column from row, returning its value.
+func splitCDV(row []string, k int) ([]string, string) {
+	if k < 0 || k >= len(row) {
+		return row, ""
+	}
+	cdv := strings.TrimSpace(row[k])
+	return append(row[:k], row[k+1:]...), cdv
+}
+
+// applyCDV completes a 7-digit Bankszerv with the check digit from the CDV column,
+// or verifies the 8-digit one against it, reporting the mismatches.
+func (o *options) applyCDV(rec *Hitelezo, cdv string) {
+	bs := strings.TrimSpace(rec.Bankszerv)
+	switch len(bs) {
+	case 7:
+		if len(cdv) == 1 {
+			rec.Bankszerv = bs + cdv
+		}
+	case 8:
+		bs = bs[:7]
+	default:
+		return
+	}
+	want, ok := checkDigit(bs)
+	if !ok {
+		return
+	}
+	if cdv != string(want) || !strings.HasSuffix(rec.Bankszerv, string(want)) {
+		o.warn(&CheckDigitError{Bankszerv: strings.TrimSpace(rec.Bankszerv), CDV: cdv, Want: want})
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCheckDigit(t *testing.T) {
+	for _, s := range []string{"10002003", "10023002", "11773016", "10100716"} {
+		if got, ok := checkDigit(s[:7]); !ok || got != s[7] {
+			t.Errorf("checkDigit(%q)=%q,%t, wanted %q", s[:7], got, ok, s[7])
+		}
+	}
+}
+
+func TestBankszervParts(t *testing.T) {
+	h := Hitelezo{Bankszerv: "11773016"}
+	if got := h.BankCode(); got != "117" {
+		t.Errorf("BankCode=%q", got)
+	}
+	if got := h.BranchCode(); got != "7301" {
+		t.Errorf("BranchCode=%q", got)
+	}
+	if got := h.CheckDigit(); got != '6' {
+		t.Errorf("CheckDigit=%q", got)
+	}
+	for s, want := range map[string]bool{
+		"11773016": true, "11773017": false, "1177301": false, "1177301x": false, "": false,
+	} {
+		if got := (Hitelezo{Bankszerv: s}).Valid(); got != want {
+			t.Errorf("%q: Valid=%t, wanted %t", s, got, want)
+		}
+	}
+	if (Hitelezo{Bankszerv: "11"}).BankCode() != "" || (Hitelezo{Bankszerv: "117"}).BranchCode() != "" {
+		t.Error("short Bankszerv")
+	}
+}
+
+func TestAccountNumber(t *testing.T) {
+	for s, want := range map[string]string{
+		"11773016-11111018":                  "11773016-11111018",
+		"1177301611111018":                   "11773016-11111018",
+		"11773016 11111018 00000000":         "11773016-11111018",
+		"11773016-11111018-12345678":         "11773016-11111018-12345678",
+		"HU42 1177 3016 1111 1018 0000 0000": "11773016-11111018",
+		"hu42117730161111101812345678":       "11773016-11111018-12345678",
+	} {
+		if got := FormatAccountNumber(s); got != want {
+			t.Errorf("%q: got %q, wanted %q", s, got, want)
+		}
+	}
+	for _, s := range []string{"", "11773016", "11773016-1111101", "11773016-1111101x", "DE42117730161111101800000000"} {
+		if got, err := NormalizeAccountNumber(s); !errors.Is(err, ErrAccountNumber) {
+			t.Errorf("%q: got %q, %+v, wanted ErrAccountNumber", s, got, err)
+		}
+	}
+}
+
+func TestValidateAccountNumber(t *testing.T) {
+	for s, want := range map[string]error{
+		"11773016-11111018":                  nil,
+		"HU42 1177 3016 1111 1018 0000 0000": nil,
+		"HU43 1177 3016 1111 1018 0000 0000": ErrCheckDigit,
+		"11773017-11111018":                  ErrCheckDigit,
+		"11773016-11111019":                  ErrCheckDigit,
+		"11773016-1111101":                   ErrAccountNumber,
+	} {
+		if _, err := ValidateAccountNumber(s); !errors.Is(err, want) || want == nil && err != nil {
+			t.Errorf("%q: got %+v, wanted %v", s, err, want)
+		}
+	}
+	if got, err := IBAN("11773016-11111018"); err != nil || got != "HU42 1177 3016 1111 1018 0000 0000" {
+		t.Errorf("IBAN: got %q, %+v", got, err)
+	}
+}
+
+func TestParseHTMLCDV(t *testing.T) {
+	const doc = `<html><body><table>
+<tr><th>Bankszerv</th><th>CDV</th><th>Név</th><th>Irsz.</th><th>Cím</th></tr>
+<tr><td>1000200</td><td>3</td><td>Magyar Államkincstár</td><td>1139</td><td>Budapest, Váci út 71.</td></tr>
+<tr><td>10023002</td><td>2</td><td>Magyar Államkincstár</td><td>1117</td><td>Budapest, Hengermalom út 1.</td></tr>
+<tr><td>10024003</td><td>9</td><td>Magyar Államkincstár</td><td>1138</td><td>Budapest, Dunavirág u. 2.</td></tr>
+</table></body></html>`
+	var rep Report
+	hs, err := ParseHTML(context.Background(), strings.NewReader(doc), WithReport(&rep))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hs) != 3 {
+		t.Fatalf("got %d records, wanted 3: %+v", len(hs), hs)
+	}
+	checkHs(t, hs)
+	if len(rep.Warnings) != 1 {
+		t.Fatalf("got %d warnings, wanted 1: %v", len(rep.Warnings), rep.Warnings)
+	}
+	var cdvErr *CheckDigitError
+	if err := rep.Warnings[0]; !errors.Is(err, ErrCheckDigit) || !errors.As(err, &cdvErr) || cdvErr.Bankszerv != "10024003" {
+		t.Errorf("got %v, wanted check digit error for 10024003", err)
+	}
+}
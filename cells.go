@@ -0,0 +1,81 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/UNO-SOFT/giro/model"
+)
+
+// cellToCode converts a spreadsheet cell's text to a digit code:
+// numeric cells may come in scientific notation (1.0002003E+07),
+// with a fraction (10002003.0) or with thousand separators (10 002 003, 10,002,003).
+//
+// Codes shorter than width get their lost leading zeros back.
+// Anything that does not look like a number is returned trimmed, but otherwise intact.
+func cellToCode(s string, width int) string {
+	s = strings.TrimSpace(s)
+	if s == "" || model.IsDigits(s) && len(s) >= width {
+		return s
+	}
+	t := strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '\u00a0', '\u202f', '\'': // (non-breaking) spaces, apostrophe
+			return -1
+		}
+		return r
+	}, s)
+	if t == "" {
+		return s
+	}
+	if !model.IsDigits(t) {
+		if u, ok := stripThousands(t); ok {
+			t = u
+		} else if f, err := strconv.ParseFloat(t, 64); err == nil &&
+			f >= 0 && f < 1e15 && f == math.Trunc(f) {
+			t = strconv.FormatFloat(f, 'f', 0, 64)
+		} else {
+			return s
+		}
+	}
+	if len(t) < width {
+		t = strings.Repeat("0", width-len(t)) + t
+	}
+	return t
+}
+
+// stripThousands removes the thousand separators (',' or '.') from s,
+// if s is a properly grouped integer, such as 10,002,003.
+func stripThousands(s string) (string, bool) {
+	if len(s) < 5 {
+		return s, false
+	}
+	sep := s[len(s)-4]
+	if sep != ',' && sep != '.' {
+		return s, false
+	}
+	groups := strings.Split(s, string(sep))
+	if len(groups[0]) == 0 || len(groups[0]) > 3 || !model.IsDigits(groups[0]) {
+		return s, false
+	}
+	for _, g := range groups[1:] {
+		if len(g) != 3 || !model.IsDigits(g) {
+			return s, false
+		}
+	}
+	return strings.Join(groups, ""), true
+}
+
+// CleanCell returns the text of the cell without the NUL bytes and the surrounding whitespace,
+// as the parsers clean the cells (see package table).
+func CleanCell(s string) string { return cleanField(s) }
+
+// CellToCode returns the digit code of the cell, as formatted in the spreadsheets
+// (in scientific notation, with a fraction, or with thousand separators),
+// padded with the lost leading zeros to width.
+func CellToCode(s string, width int) string { return cellToCode(s, width) }
@@ -0,0 +1,77 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestCellToCode(t *testing.T) {
+	for _, tc := range []struct {
+		In    string
+		Width int
+		Want  string
+	}{
+		{"10002003", 8, "10002003"},
+		{" 10002003 ", 8, "10002003"},
+		{"1.0002003E+07", 8, "10002003"},
+		{"1.0002003e7", 8, "10002003"},
+		{"10002003.0", 8, "10002003"},
+		{"10 002 003", 8, "10002003"},
+		{"10\u00a0002\u00a0003", 8, "10002003"},
+		{"10,002,003", 8, "10002003"},
+		{"10.002.003", 8, "10002003"},
+		{"3002003", 8, "03002003"},
+		{"3.002003E+06", 8, "03002003"},
+		{"1139", 0, "1139"},
+		{"1139.0", 0, "1139"},
+		{"1,139", 0, "1139"},
+		{"Bankszerv", 8, "Bankszerv"},
+		{"1000-2003", 8, "1000-2003"},
+		{"10.5", 8, "10.5"},
+		{"", 8, ""},
+	} {
+		if got := cellToCode(tc.In, tc.Width); got != tc.Want {
+			t.Errorf("cellToCode(%q, %d)=%q, wanted %q", tc.In, tc.Width, got, tc.Want)
+		}
+	}
+}
+
+func TestParseXLSXNumeric(t *testing.T) {
+	wb := excelize.NewFile()
+	sheet := wb.GetSheetName(0)
+	for i, row := range [][]interface{}{
+		{"Bankszerv", "Név", "Irányítószám", "Cím"},
+		{float64(10002003), "Magyar Államkincstár", float64(1139), "Budapest, Váci út 71."},
+		{"1.0023002E+07", "Magyar Államkincstár", "1117", "Budapest, Hengermalom út 1."},
+		{"10 024 003", "Magyar Államkincstár", "1138.0", "Budapest, Dunavirág u. 2."},
+	} {
+		cell, _ := excelize.CoordinatesToCellName(1, i+1)
+		if err := wb.SetSheetRow(sheet, cell, &row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var buf bytes.Buffer
+	if _, err := wb.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	hs, err := ParseXLSX(context.Background(), &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hs) != 3 {
+		t.Fatalf("got %d records, wanted 3: %+v", len(hs), hs)
+	}
+	checkHs(t, hs)
+	for i, want := range []string{"10002003", "10023002", "10024003"} {
+		if hs[i].Bankszerv != want {
+			t.Errorf("%d. got %q, wanted %q", i, hs[i].Bankszerv, want)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/UNO-SOFT/filecache"
+)
+
+// Changes is the difference between two versions of the list, by Bankszerv.
+type Changes struct {
+	Added, Removed []Hitelezo
+	Modified       []Modification
+}
+
+// Modification is a record changed between two versions.
+type Modification struct {
+	Old, New Hitelezo
+}
+
+// Diff returns the Changes from old to new, sorted by Bankszerv.
+//
+// Only the first record of each Bankszerv is compared (see WithDuplicates).
+func Diff(old, new []Hitelezo) Changes {
+	oldM := make(map[string]Hitelezo, len(old))
+	for _, h := range old {
+		if _, ok := oldM[h.Bankszerv]; !ok {
+			oldM[h.Bankszerv] = h
+		}
+	}
+	var c Changes
+	seen := make(map[string]struct{}, len(new))
+	for _, h := range new {
+		if _, ok := seen[h.Bankszerv]; ok {
+			continue
+		}
+		seen[h.Bankszerv] = struct{}{}
+		o, ok := oldM[h.Bankszerv]
+		if !ok {
+			c.Added = append(c.Added, h)
+			continue
+		}
+		delete(oldM, h.Bankszerv)
+		if o != h {
+			c.Modified = append(c.Modified, Modification{Old: o, New: h})
+		}
+	}
+	for _, h := range oldM {
+		c.Removed = append(c.Removed, h)
+	}
+	byBankszerv := func(a, b Hitelezo) int { return cmp.Compare(a.Bankszerv, b.Bankszerv) }
+	slices.SortFunc(c.Added, byBankszerv)
+	slices.SortFunc(c.Removed, byBankszerv)
+	slices.SortFunc(c.Modified, func(a, b Modification) int { return byBankszerv(a.New, b.New) })
+	return c
+}
+
+// Empty reports whether there are no changes.
+func (c Changes) Empty() bool {
+	return len(c.Added) == 0 && len(c.Removed) == 0 && len(c.Modified) == 0
+}
+
+// snapshotKey is the prefix of the cache keys of the records returned by the last Parse WithChanges.
+const snapshotKey = "giro.snapshot.v1"
+
+// snapshotActionID returns the cache key of the snapshot of the list:
+// by the name of the Upstream, or by the URL of the Source, the same for all the others.
+func (o *options) snapshotActionID() filecache.ActionID {
+	key := snapshotKey
+	if o.upstream != "" {
+		key += "\x00upstream\x00" + o.upstream
+	} else if o.source != nil && o.source.URL != "" {
+		key += "\x00url\x00" + o.source.URL
+	}
+	return filecache.NewActionID([]byte(key))
+}
+
+// WithChanges makes Parse (and Directory.Refresh) compute the Changes
+// relative to the records returned by the previous Parse WithChanges of the same list,
+// which are kept in the persistent cache (see WithCacheDir).
+//
+// The lists are told apart by the name of the Upstream (for Parse(ctx, nil)),
+// or by the URL of the Source (see WithSource); the other lists share one snapshot.
+// If there is no previous snapshot, all the records are Added.
+// Cache errors are reported as warnings (see WithReport).
+func WithChanges(changes *Changes) Option {
+	return func(o *options) { o.changes = changes }
+}
+
+// diffSnapshot sets o.changes to the Changes from the cached snapshot to hs,
+// and replaces the snapshot with hs.
+func (o *options) diffSnapshot(hs []Hitelezo) {
+	cache, err := o.openCache()
+	if err != nil {
+		o.warn(fmt.Errorf("open cache: %w", err))
+		*o.changes = Diff(nil, hs)
+		return
+	}
+	id := o.snapshotActionID()
+	var old []Hitelezo
+	if b, _, err := cache.GetBytes(id); err == nil {
+		if old, err = decodeRecords(b); err != nil {
+			o.warn(fmt.Errorf("decode cached snapshot: %w", err))
+		}
+	}
+	*o.changes = Diff(old, hs)
+	b, err := encodeRecords(hs)
+	if err == nil {
+		_, _, err = cache.Put(id, bytes.NewReader(b))
+	}
+	if err != nil {
+		o.warn(fmt.Errorf("cache snapshot: %w", err))
+	}
+}
+
+// encodeRecords encodes hs as zstd-compressed JSON (see Compress).
+func encodeRecords(hs []Hitelezo) ([]byte, error) {
+	var buf bytes.Buffer
+	zw, err := Compress(&buf, "snapshot.json.zst")
+	if err != nil {
+		return nil, err
+	}
+	if err := json.NewEncoder(zw).Encode(hs); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeRecords decodes the output of encodeRecords, or the gzipped JSON cached by the earlier versions.
+func decodeRecords(b []byte) ([]Hitelezo, error) {
+	zr, err := Decompress(bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	var hs []Hitelezo
+	err = json.NewDecoder(zr).Decode(&hs)
+	return hs, err
+}
@@ -0,0 +1,147 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	old := []Hitelezo{
+		{Bankszerv: "10002003", Nev: "MÁK", Cim: "Budapest"},
+		{Bankszerv: "11773016", Nev: "OTP Bank", Cim: "Budapest"},
+		{Bankszerv: "12001008", Nev: "Raiffeisen Bank", Cim: "Budapest"},
+	}
+	new := []Hitelezo{
+		{Bankszerv: "11773023", Nev: "OTP Bank Fiók", Cim: "Debrecen"},
+		{Bankszerv: "11773016", Nev: "OTP Bank", Cim: "Budapest"},
+		{Bankszerv: "10002003", Nev: "MÁK", Cim: "Budapest, Hold u. 4."},
+	}
+	c := Diff(old, new)
+	if len(c.Added) != 1 || c.Added[0].Bankszerv != "11773023" ||
+		len(c.Removed) != 1 || c.Removed[0].Bankszerv != "12001008" ||
+		len(c.Modified) != 1 || c.Modified[0].Old.Cim != "Budapest" || c.Modified[0].New.Cim != "Budapest, Hold u. 4." {
+		t.Errorf("got %+v", c)
+	}
+	if c.Empty() || !Diff(old, old).Empty() {
+		t.Errorf("Empty is wrong")
+	}
+}
+
+func TestWithChanges(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	hs := []Hitelezo{
+		{Bankszerv: "10002003", Nev: "Magyar Államkincstár", Irszam: "1139", Cim: "Budapest, Váci út 71."},
+		{Bankszerv: "11773016", Nev: "OTP Bank", Irszam: "1051", Cim: "Budapest"},
+	}
+	parse := func(hs []Hitelezo) Changes {
+		t.Helper()
+		var buf bytes.Buffer
+		if err := WriteXLSX(&buf, hs); err != nil {
+			t.Fatal(err)
+		}
+		var c Changes
+		var rep Report
+		if _, err := Parse(ctx, &buf, WithCacheDir(dir), WithChanges(&c), WithReport(&rep)); err != nil {
+			t.Fatal(err)
+		}
+		if len(rep.Warnings) != 0 {
+			t.Errorf("got warnings %+v", rep.Warnings)
+		}
+		return c
+	}
+	if c := parse(hs); len(c.Added) != 2 || len(c.Removed)+len(c.Modified) != 0 {
+		t.Errorf("first: got %+v", c)
+	}
+	hs[1].Cim = "Budapest, Nádor u. 16."
+	if c := parse(hs[1:]); len(c.Added) != 0 || len(c.Removed) != 1 || len(c.Modified) != 1 {
+		t.Errorf("second: got %+v", c)
+	}
+	if c := parse(hs[1:]); !c.Empty() {
+		t.Errorf("third: got %+v", c)
+	}
+}
+
+func TestEncodeRecords(t *testing.T) {
+	hs := []Hitelezo{{Bankszerv: "10002003", Nev: "Magyar Államkincstár", Irszam: "1139", Cim: "Budapest, Váci út 71."}}
+	b, err := encodeRecords(hs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(b, []byte{0x28, 0xb5, 0x2f, 0xfd}) {
+		t.Errorf("got %x..., wanted zstd", b[:4])
+	}
+	// the snapshots cached by the earlier versions are gzipped
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if err = json.NewEncoder(zw).Encode(hs); err != nil {
+		t.Fatal(err)
+	}
+	zw.Close()
+	for name, b := range map[string][]byte{"zstd": b, "gzip": buf.Bytes()} {
+		if got, err := decodeRecords(b); err != nil || !slices.Equal(got, hs) {
+			t.Errorf("%s: got %+v, %+v", name, got, err)
+		}
+	}
+}
+
+func TestChangesByList(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	lists := map[string][]Hitelezo{
+		"/a.xlsx": {{Bankszerv: "10002003", Nev: "Magyar Államkincstár", Irszam: "1139", Cim: "Budapest, Váci út 71."}},
+		"/b.xlsx": {{Bankszerv: "10002003", Nev: "MÁK", Irszam: "1139", Cim: "Budapest"}},
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := WriteXLSX(w, lists[r.URL.Path]); err != nil {
+			t.Error(err)
+		}
+	}))
+	defer srv.Close()
+	a := Upstream{Name: "a", URL: srv.URL + "/a.xlsx"}
+	b := Upstream{Name: "b", URL: srv.URL + "/b.xlsx"}
+	var buf bytes.Buffer
+	if err := WriteXLSX(&buf, lists["/b.xlsx"]); err != nil {
+		t.Fatal(err)
+	}
+	parse := func(r io.Reader, opts ...Option) Changes {
+		t.Helper()
+		var c Changes
+		if hs, err := Parse(ctx, r, append(opts, WithCacheDir(dir), WithChanges(&c))...); err != nil || len(hs) != 1 {
+			t.Fatalf("got %+v, %+v", hs, err)
+		}
+		return c
+	}
+	for i, tc := range []struct {
+		File  bool
+		Opt   Option
+		Added int
+	}{
+		{Opt: WithUpstreams(a), Added: 1},
+		// not Modified, as another list
+		{Opt: WithUpstreams(b), Added: 1},
+		{File: true, Opt: WithSource(&Source{URL: "file:///b.xlsx"}), Added: 1},
+		{Opt: WithUpstreams(a)},
+		{Opt: WithUpstreams(b)},
+		{File: true, Opt: WithSource(&Source{URL: "file:///b.xlsx"})},
+	} {
+		var r io.Reader
+		if tc.File {
+			r = bytes.NewReader(buf.Bytes())
+		}
+		if c := parse(r, tc.Opt); len(c.Added) != tc.Added || len(c.Removed)+len(c.Modified) != 0 {
+			t.Errorf("%d. got %+v", i, c)
+		}
+	}
+}
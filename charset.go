@@ -0,0 +1,74 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+// detectCharset returns the legacy Hungarian code page b is encoded in,
+// or nil if b is valid UTF-8.
+//
+// Windows-1250 is chosen when b contains bytes from the 0x80-0x9F range
+// (C1 controls in ISO-8859-2), ISO-8859-2 otherwise.
+func detectCharset(b []byte) *charmap.Charmap {
+	// a rune cut in half at the end of the sample is not an error
+	for i := len(b) - 1; i >= 0 && i >= len(b)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(b[i]) {
+			if !utf8.FullRune(b[i:]) {
+				b = b[:i]
+			}
+			break
+		}
+	}
+	if utf8.Valid(b) {
+		return nil
+	}
+	for _, c := range b {
+		if 0x80 <= c && c < 0xa0 {
+			return charmap.Windows1250
+		}
+	}
+	return charmap.ISO8859_2
+}
+
+// newUTF8Reader returns a reader that converts the contents of r to UTF-8,
+// if the first 64KiB of it is not UTF-8 already.
+func newUTF8Reader(r io.Reader) io.Reader {
+	br := bufio.NewReaderSize(r, 64<<10)
+	b, _ := br.Peek(64 << 10)
+	if cm := detectCharset(b); cm != nil {
+		return cm.NewDecoder().Reader(br)
+	}
+	return br
+}
+
+// fixLatin2 repairs s if it is a legacy 8-bit string decoded as Latin-1,
+// which turns the Hungarian ő, ű into õ, û (and their capitals).
+func fixLatin2(s string) string {
+	if !strings.ContainsAny(s, "õÕûÛ") && !strings.ContainsFunc(s, func(r rune) bool { return 0x80 <= r && r < 0xa0 }) {
+		return s
+	}
+	b := make([]byte, 0, len(s))
+	for _, r := range s {
+		if r > 0xff {
+			return s
+		}
+		b = append(b, byte(r))
+	}
+	cm := detectCharset(b)
+	if cm == nil {
+		return s
+	}
+	if t, err := cm.NewDecoder().Bytes(b); err == nil {
+		return string(t)
+	}
+	return s
+}
@@ -0,0 +1,47 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+func TestCharset(t *testing.T) {
+	const want = "Győr, Fő út 1. – Űrhajós"
+	for _, cm := range []*charmap.Charmap{charmap.ISO8859_2, charmap.Windows1250} {
+		w := want
+		if cm == charmap.ISO8859_2 { // no en dash in ISO-8859-2
+			w = strings.ReplaceAll(w, "–", "-")
+		}
+		enc, err := cm.NewEncoder().String(w)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := io.ReadAll(newUTF8Reader(strings.NewReader(enc)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := string(b); got != w {
+			t.Errorf("%s: got %q, wanted %q", cm, got, w)
+		}
+
+		// what an 8-bit XLS string looks like when decoded as Latin-1
+		var latin1 strings.Builder
+		for _, c := range []byte(enc) {
+			latin1.WriteRune(rune(c))
+		}
+		if got := fixLatin2(latin1.String()); got != w {
+			t.Errorf("%s: fixLatin2(%q)=%q, wanted %q", cm, latin1.String(), got, w)
+		}
+	}
+
+	if got := fixLatin2(want); got != want {
+		t.Errorf("fixLatin2(%q)=%q", want, got)
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package client is a typed client of the HTTP API of github.com/UNO-SOFT/giro/server
+// (see its OpenAPI document at /openapi.json).
+//
+// It depends on the standard library only, not linking the parsers of the giro package.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// ErrNotFound is returned by Lookup for an unknown branch.
+var ErrNotFound = errors.New("not found")
+
+// Hitelezo is a record of the directory, as served by the API (see giro.Hitelezo).
+type Hitelezo struct {
+	Bankszerv, BIC, Nev, Irszam, Cim string
+	// NonStdIrszam is set when Irszam is not of an accepted form.
+	NonStdIrszam bool `json:",omitempty"`
+	// SearchKey is the folded Nev and Cim, if provided.
+	SearchKey string `json:",omitempty"`
+}
+
+// AccountValidation is the result of validating an account number (see giro.AccountValidation).
+type AccountValidation struct {
+	Input string `json:"input"`
+	// Account is the account number in the usual digit groups.
+	Account string `json:"account,omitempty"`
+	// IBAN is set for the valid account numbers.
+	IBAN string `json:"iban,omitempty"`
+	// Valid reports whether the check digits are right.
+	Valid bool `json:"valid"`
+	// Error is the reason of the invalidity, or of the branch not found.
+	Error string `json:"error,omitempty"`
+	// Branch is the branch of the account number, if found.
+	Branch *Hitelezo `json:"branch,omitempty"`
+}
+
+// StatusError is returned for the unexpected HTTP responses.
+type StatusError struct {
+	StatusCode int
+	Status     string
+	// Body is the beginning of the response body.
+	Body string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Status, e.Body)
+}
+
+// Client talks to the API at BaseURL.
+//
+// It is safe for concurrent use.
+type Client struct {
+	// BaseURL is the URL the API is served at, such as "http://localhost:8080".
+	BaseURL string
+	// HTTPClient is used for the requests, http.DefaultClient if nil.
+	HTTPClient *http.Client
+	// APIKey is sent in the X-API-Key header, if not empty.
+	APIKey string
+
+	mu sync.Mutex
+	// etag and records are the last response of All, for the conditional requests.
+	etag    string
+	records []Hitelezo
+}
+
+// New returns a Client of the API at baseURL.
+func New(baseURL string) *Client {
+	return &Client{BaseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+// All returns all the records.
+//
+// The records are cached, and revalidated with their ETag,
+// thus the unchanged list is not transferred again.
+// The returned slice must not be modified.
+func (c *Client) All(ctx context.Context) ([]Hitelezo, error) {
+	c.mu.Lock()
+	etag, cached := c.etag, c.records
+	c.mu.Unlock()
+	req, err := c.newRequest(ctx, "GET", "/hitelezo", nil)
+	if err != nil {
+		return nil, err
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	resp, err := c.do(req, http.StatusOK, http.StatusNotModified)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotModified {
+		return cached, nil
+	}
+	// decoded into a new slice, as the cached one may be in use by the callers
+	var records []Hitelezo
+	if err = json.NewDecoder(resp.Body).Decode(&records); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.etag, c.records = resp.Header.Get("ETag"), records
+	c.mu.Unlock()
+	return records, nil
+}
+
+// Lookup returns the record of the 8-digit bankszerv, or ErrNotFound.
+func (c *Client) Lookup(ctx context.Context, bankszerv string) (Hitelezo, error) {
+	var h Hitelezo
+	err := c.getJSON(ctx, "/hitelezo/"+url.PathEscape(bankszerv), &h)
+	return h, err
+}
+
+// Capabilities returns the reliability (missing, partial, complete or authoritative)
+// of each field, by publication (SHT, EHT).
+func (c *Client) Capabilities(ctx context.Context) (map[string]map[string]string, error) {
+	var m map[string]map[string]string
+	err := c.getJSON(ctx, "/capabilities", &m)
+	return m, err
+}
+
+// Validate validates the account numbers (or IBANs), and looks up their branches.
+func (c *Client) Validate(ctx context.Context, accounts []string) ([]AccountValidation, error) {
+	b, err := json.Marshal(accounts)
+	if err != nil {
+		return nil, err
+	}
+	req, err := c.newRequest(ctx, "POST", "/validate", bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.do(req, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var vs []AccountValidation
+	err = json.NewDecoder(resp.Body).Decode(&vs)
+	return vs, err
+}
+
+// Refresh forces an immediate refresh of the server with the Bearer token,
+// returning the number of records after it.
+func (c *Client) Refresh(ctx context.Context, token string) (int, error) {
+	req, err := c.newRequest(ctx, "POST", "/refresh", nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := c.do(req, http.StatusOK)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	var res struct{ Records int }
+	err = json.NewDecoder(resp.Body).Decode(&res)
+	return res.Records, err
+}
+
+func (c *Client) getJSON(ctx context.Context, path string, v any) error {
+	req, err := c.newRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.do(req, http.StatusOK)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+func (c *Client) newRequest(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(c.BaseURL, "/")+path, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.APIKey != "" {
+		req.Header.Set("X-API-Key", c.APIKey)
+	}
+	return req, nil
+}
+
+// do sends the request, returning a StatusError (wrapping ErrNotFound for 404)
+// if the response status is not one of the accepted ones.
+func (c *Client) do(req *http.Request, accepted ...int) (*http.Response, error) {
+	hc := c.HTTPClient
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	for _, code := range accepted {
+		if resp.StatusCode == code {
+			return resp, nil
+		}
+	}
+	defer resp.Body.Close()
+	b, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	err = &StatusError{StatusCode: resp.StatusCode, Status: resp.Status, Body: string(bytes.TrimSpace(b))}
+	if resp.StatusCode == http.StatusNotFound {
+		err = fmt.Errorf("%s: %w: %w", req.URL.Path, ErrNotFound, err)
+	}
+	return nil, err
+}
@@ -0,0 +1,107 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/UNO-SOFT/giro"
+	"github.com/UNO-SOFT/giro/client"
+	"github.com/UNO-SOFT/giro/server"
+)
+
+func TestClient(t *testing.T) {
+	d := giro.NewDirectory([]giro.Hitelezo{
+		{Bankszerv: "10002003", Nev: "Magyar Államkincstár", Irszam: "1139", Cim: "Budapest, Váci út 71."},
+	})
+	srv := &server.Server{
+		Directory: d,
+		Refresher: &giro.Refresher{Directory: d,
+			Fetch: func(context.Context, *giro.Source) ([]giro.Hitelezo, error) {
+				return append(d.All(), giro.Hitelezo{Bankszerv: "11773016", Nev: "OTP Bank", Irszam: "1051", Cim: "Budapest"}), nil
+			},
+		},
+		RefreshToken: "secret",
+	}
+	var notModified atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rw := &statusRecorder{ResponseWriter: w}
+		srv.ServeHTTP(rw, r)
+		if rw.code == http.StatusNotModified {
+			notModified.Add(1)
+		}
+	}))
+	defer ts.Close()
+	ctx := context.Background()
+	c := client.New(ts.URL + "/")
+
+	for i := 0; i < 2; i++ {
+		hs, err := c.All(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(hs) != 1 || hs[0].Cim != "Budapest, Váci út 71." {
+			t.Errorf("got %+v", hs)
+		}
+	}
+	if n := notModified.Load(); n != 1 {
+		t.Errorf("got %d Not Modified responses, wanted 1", n)
+	}
+
+	if h, err := c.Lookup(ctx, "10002003"); err != nil || h.Nev != "Magyar Államkincstár" {
+		t.Errorf("got %+v, %+v", h, err)
+	}
+	_, err := c.Lookup(ctx, "99999999")
+	var se *client.StatusError
+	if !errors.Is(err, client.ErrNotFound) || !errors.As(err, &se) || se.StatusCode != http.StatusNotFound {
+		t.Errorf("got %+v, wanted ErrNotFound", err)
+	}
+
+	if caps, err := c.Capabilities(ctx); err != nil || caps["SHT"]["BIC"] != "authoritative" {
+		t.Errorf("got %+v, %+v", caps, err)
+	}
+
+	if _, err = c.Refresh(ctx, "wrong"); !errors.As(err, &se) || se.StatusCode != http.StatusUnauthorized {
+		t.Errorf("got %+v, wanted 401", err)
+	}
+	if n, err := c.Refresh(ctx, "secret"); err != nil || n != 2 {
+		t.Errorf("got %d, %+v", n, err)
+	}
+	if hs, err := c.All(ctx); err != nil || len(hs) != 2 {
+		t.Errorf("after refresh: got %+v, %+v", hs, err)
+	}
+
+	vs, err := c.Validate(ctx, []string{"10002003-00000000", "11773016-11111019"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(vs) != 2 || !vs[0].Valid || vs[0].Branch == nil || vs[1].Valid || vs[1].Error == "" {
+		t.Errorf("got %+v", vs)
+	}
+
+	srv.APIKeys = []string{"key"}
+	if _, err = c.Lookup(ctx, "10002003"); !errors.As(err, &se) || se.StatusCode != http.StatusUnauthorized {
+		t.Errorf("without key: got %+v, wanted 401", err)
+	}
+	c.APIKey = "key"
+	if _, err = c.Lookup(ctx, "10002003"); err != nil {
+		t.Errorf("with key: %+v", err)
+	}
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	code int
+}
+
+func (w *statusRecorder) WriteHeader(code int) {
+	w.code = code
+	w.ResponseWriter.WriteHeader(code)
+}
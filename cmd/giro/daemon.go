@@ -0,0 +1,200 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/UNO-SOFT/giro"
+	"github.com/UNO-SOFT/giro/server"
+)
+
+// snapshotName is the name of the snapshot in the state directory of the daemon.
+const snapshotName = "snapshot.json.gz"
+
+// daemonMain keeps a fresh snapshot of the list in the state directory, and serves it over HTTP.
+func daemonMain(ctx context.Context, args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("daemon", flag.ContinueOnError)
+	flagAddr := fs.String("addr", ":8080", "address to listen on")
+	flagInterval := fs.Duration("interval", 24*time.Hour, "refresh interval")
+	flagStateDir := fs.String("state-dir", "", "directory to keep the snapshot in")
+	flagWebhook := fs.String("webhook", "", "URL to POST the change summaries to (signed with $GIRO_WEBHOOK_SECRET)")
+	flagRateLimit := fs.Float64("rate-limit", 0, "requests per second allowed per API key (or client address), 0 for unlimited")
+	flagRateBurst := fs.Int("rate-burst", 10, "requests allowed at once above the rate limit")
+	flagMaxAge := fs.Duration("max-age", 0, "maximum age of the records for /readyz (default twice the interval)")
+	flagPDF := fs.String("pdf-strategies", "", "comma-separated PDF strategies to try in order (default tabula,pdftotext)")
+	flagTabulaHints := fs.String("tabula-hints", "", `tabula extraction hints as JSON, such as {"area":[72,0,800,600],"pages":"2-40"} (see giro.TabulaHints)`)
+	flagMaxBatch := fs.Int("max-batch", server.DefaultMaxBatch, "maximum number of account numbers of a POST /validate")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: giro daemon --state-dir DIR [--addr :8080] [--interval 24h] [--webhook URL]\n\n"+
+			"Serves the list over HTTP (see package server), refreshing it every interval.\n"+
+			"POST /refresh is enabled with $GIRO_REFRESH_TOKEN as its Bearer token.\n"+
+			"With $GIRO_API_KEYS (comma-separated), the requests require one of them in the X-API-Key header.")
+		fs.PrintDefaults()
+	}
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *flagStateDir == "" || *flagInterval <= 0 {
+		fs.Usage()
+		return errUsage
+	}
+	d, err := newDaemon(*flagStateDir, *flagInterval)
+	if err != nil {
+		return err
+	}
+	var parseOpts []giro.Option
+	if *flagPDF != "" {
+		strategies, err := giro.ParsePDFStrategies(*flagPDF)
+		if err != nil {
+			return err
+		}
+		parseOpts = append(parseOpts, giro.WithPDFStrategies(strategies...))
+	}
+	if *flagTabulaHints != "" {
+		var hints giro.TabulaHints
+		if err := json.Unmarshal([]byte(*flagTabulaHints), &hints); err != nil {
+			return fmt.Errorf("--tabula-hints: %w", err)
+		}
+		if err := hints.Validate(); err != nil {
+			return fmt.Errorf("--tabula-hints: %w", err)
+		}
+		parseOpts = append(parseOpts, giro.WithTabulaHints(hints))
+	}
+	if len(parseOpts) != 0 {
+		d.parse = func(ctx context.Context, src *giro.Source) ([]giro.Hitelezo, error) {
+			return giro.Parse(ctx, nil, append(slices.Clip(parseOpts), giro.WithSource(src))...)
+		}
+	}
+	if *flagWebhook != "" {
+		d.refresher.Webhook = &giro.Webhook{URL: *flagWebhook, Secret: []byte(os.Getenv("GIRO_WEBHOOK_SECRET"))}
+	}
+	if d.stale {
+		if err := d.refresher.TriggerRefresh(ctx); err != nil {
+			slog.Warn("initial refresh failed, serving the last snapshot", "error", err)
+		}
+	}
+	go d.refresher.Run(ctx)
+
+	srv := &server.Server{Directory: d.dir, Refresher: d.refresher, RefreshToken: os.Getenv("GIRO_REFRESH_TOKEN"),
+		RateLimit: *flagRateLimit, RateBurst: *flagRateBurst, MaxAge: *flagMaxAge, MaxBatch: *flagMaxBatch}
+	if srv.MaxAge == 0 {
+		srv.MaxAge = 2 * *flagInterval
+	}
+	for _, k := range strings.Split(os.Getenv("GIRO_API_KEYS"), ",") {
+		if k = strings.TrimSpace(k); k != "" {
+			srv.APIKeys = append(srv.APIKeys, k)
+		}
+	}
+	httpSrv := http.Server{Addr: *flagAddr, Handler: srv}
+	go func() {
+		<-ctx.Done()
+		shutCtx, shutCancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer shutCancel()
+		_ = httpSrv.Shutdown(shutCtx)
+		if err := srv.Close(shutCtx); err != nil {
+			slog.Warn("close refresher", "error", err)
+		}
+	}()
+	slog.Info("listening", "addr", *flagAddr, "records", d.dir.Len())
+	if err := httpSrv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// daemon is the state of the daemon subcommand.
+type daemon struct {
+	stateDir  string
+	dir       *giro.Directory
+	refresher *giro.Refresher
+	// stale is set if the snapshot is missing or older than the refresh interval.
+	stale bool
+	// parse returns the fresh records filling their Source, giro.Parse(ctx, nil) by default.
+	parse func(context.Context, *giro.Source) ([]giro.Hitelezo, error)
+}
+
+// newDaemon returns a daemon serving the snapshot of stateDir (or the embedded snapshot if there is none).
+func newDaemon(stateDir string, interval time.Duration) (*daemon, error) {
+	if err := os.MkdirAll(stateDir, 0750); err != nil {
+		return nil, err
+	}
+	d := daemon{stateDir: stateDir, stale: true,
+		parse: func(ctx context.Context, src *giro.Source) ([]giro.Hitelezo, error) {
+			return giro.Parse(ctx, nil, giro.WithSource(src))
+		},
+	}
+	fn := filepath.Join(stateDir, snapshotName)
+	if fi, err := os.Stat(fn); err == nil {
+		hs, err := loadRecords(context.Background(), fn)
+		if err != nil {
+			return nil, err
+		}
+		// the snapshot is written after each download
+		d.dir = giro.NewDirectory(nil)
+		d.dir.Swap(hs, giro.WithFetched(fi.ModTime()))
+		d.stale = time.Since(fi.ModTime()) >= interval
+	} else if os.IsNotExist(err) {
+		d.dir = giro.LoadEmbedded()
+	} else {
+		return nil, err
+	}
+	d.refresher = &giro.Refresher{Directory: d.dir, Interval: interval, Fetch: d.fetch}
+	return &d, nil
+}
+
+// fetch returns the fresh records, logging the changes and saving them as the snapshot.
+func (d *daemon) fetch(ctx context.Context, src *giro.Source) ([]giro.Hitelezo, error) {
+	hs, err := d.parse(ctx, src)
+	if err != nil {
+		return nil, err
+	}
+	if c := giro.Diff(d.dir.All(), hs); !c.Empty() {
+		slog.Info("changed", "added", len(c.Added), "removed", len(c.Removed), "modified", len(c.Modified), "total", len(hs))
+	}
+	if err := d.writeSnapshot(hs); err != nil {
+		return nil, err
+	}
+	return hs, nil
+}
+
+// writeSnapshot replaces the snapshot in the state directory atomically.
+func (d *daemon) writeSnapshot(hs []giro.Hitelezo) error {
+	fh, err := os.CreateTemp(d.stateDir, snapshotName+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(fh.Name())
+	zw, err := giro.Compress(fh, snapshotName)
+	if err != nil {
+		fh.Close()
+		return err
+	}
+	if err = json.NewEncoder(zw).Encode(hs); err == nil {
+		err = zw.Close()
+	}
+	if err == nil {
+		err = fh.Sync()
+	}
+	if closeErr := fh.Close(); closeErr != nil && err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(fh.Name(), filepath.Join(d.stateDir, snapshotName))
+}
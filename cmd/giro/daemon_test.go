@@ -0,0 +1,48 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/UNO-SOFT/giro"
+)
+
+func TestDaemon(t *testing.T) {
+	dir := t.TempDir()
+	d, err := newDaemon(dir, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !d.stale || d.dir.Len() < 1000 {
+		t.Errorf("got stale=%t, %d records, wanted the stale embedded snapshot", d.stale, d.dir.Len())
+	}
+	hs := []giro.Hitelezo{{Bankszerv: "11773016", Nev: "OTP Bank", Irszam: "1051", Cim: "Budapest"}}
+	d.parse = func(context.Context, *giro.Source) ([]giro.Hitelezo, error) { return hs, nil }
+	if err := d.refresher.TriggerRefresh(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if d.dir.Len() != 1 {
+		t.Errorf("got %d records after refresh, wanted 1", d.dir.Len())
+	}
+	if _, err := os.Stat(filepath.Join(dir, snapshotName)); err != nil {
+		t.Fatal(err)
+	}
+
+	// restart
+	if d, err = newDaemon(dir, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if d.stale {
+		t.Error("fresh snapshot is stale")
+	}
+	if h, ok := d.dir.Lookup("11773016"); !ok || h != hs[0] {
+		t.Errorf("got %+v, wanted %+v", h, hs[0])
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/UNO-SOFT/giro"
+)
+
+// errChanged is returned by diff when there are changes, to exit with 1 without logging.
+var errChanged = errors.New("changed")
+
+// diffMain compares two versions of the list, and prints the changes.
+func diffMain(ctx context.Context, args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+	flagFormat := formatFlag(fs)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: giro diff [--format F] old new\n\n"+
+			"The files can be of any format read by giro.Parse, or JSON snapshots (gzip-compressed or not).\n"+
+			"The exit code is 1 if there are changes.")
+		fs.PrintDefaults()
+	}
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if err := checkFormat(fs, *flagFormat); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return errUsage
+	}
+	old, err := loadRecords(ctx, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	new, err := loadRecords(ctx, fs.Arg(1))
+	if err != nil {
+		return err
+	}
+	changes := giro.Diff(old, new)
+	summary := changes.Summarize(time.Time{}, len(new))
+	switch *flagFormat {
+	case formatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(struct {
+			Summary giro.ChangeSummary `json:"summary"`
+			giro.Changes
+		}{summary, changes}); err != nil {
+			return err
+		}
+	case formatJSONL, formatCSV, formatXLSX:
+		if err := writeChanges(w, *flagFormat, changeRows("", changes), false); err != nil {
+			return err
+		}
+	default:
+		printChanges(w, "", changes)
+		fmt.Fprintf(w, "%d added, %d removed, %d modified, %d total\n",
+			summary.Added, summary.Removed, summary.Modified, summary.Total)
+	}
+	if !changes.Empty() {
+		return errChanged
+	}
+	return nil
+}
+
+// printChanges prints the changes in the table format, each line after the indent.
+func printChanges(w io.Writer, indent string, changes giro.Changes) {
+	for _, h := range changes.Removed {
+		fmt.Fprintln(w, indent+"- "+recordString(h))
+	}
+	for _, h := range changes.Added {
+		fmt.Fprintln(w, indent+"+ "+recordString(h))
+	}
+	for _, m := range changes.Modified {
+		fmt.Fprintln(w, indent+"~ "+recordString(m.Old)+"\n"+indent+"  "+recordString(m.New))
+	}
+}
+
+// loadRecords reads the JSON snapshot (a JSON array of giro.Hitelezo, JSONL or XML, possibly compressed, see giro.Decompress),
+// or parses the file with giro.Parse.
+func loadRecords(ctx context.Context, fn string) ([]giro.Hitelezo, error) {
+	fh, err := os.Open(fn)
+	if err != nil {
+		return nil, err
+	}
+	defer fh.Close()
+	r, err := giro.Decompress(fh)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+	defer r.Close()
+	br2 := bufio.NewReader(r)
+	for {
+		b, err := br2.Peek(1)
+		if err != nil || !bytes.ContainsAny(b, " \t\r\n") {
+			break
+		}
+		_, _ = br2.ReadByte()
+	}
+	if b, _ := br2.Peek(1); len(b) == 1 && b[0] == '[' {
+		var hs []giro.Hitelezo
+		if err := json.NewDecoder(br2).Decode(&hs); err != nil {
+			return nil, fmt.Errorf("%s: %w", fn, err)
+		}
+		return hs, nil
+	}
+	if b, _ := br2.Peek(1); len(b) == 1 && b[0] == '{' {
+		hs, err := giro.ReadJSONL(br2)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", fn, err)
+		}
+		return hs, nil
+	}
+	if b, _ := br2.Peek(5); string(b) == "<?xml" {
+		hs, err := giro.ParseXML(br2)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", fn, err)
+		}
+		return hs, nil
+	}
+	hs, err := giro.Parse(ctx, br2, giro.WithSource(&giro.Source{Filename: filepath.Base(fn)}))
+	if err != nil {
+		return hs, fmt.Errorf("%s: %w", fn, err)
+	}
+	return hs, nil
+}
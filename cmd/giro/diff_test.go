@@ -0,0 +1,119 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/UNO-SOFT/giro"
+)
+
+func TestDiff(t *testing.T) {
+	hs := []giro.Hitelezo{
+		{Bankszerv: "10002003", Nev: "Magyar Államkincstár", Irszam: "1139", Cim: "Budapest, Váci út 71."},
+		{Bankszerv: "11773016", Nev: "OTP Bank", Irszam: "1051", Cim: "Budapest"},
+	}
+	dir := t.TempDir()
+	var buf bytes.Buffer
+	if err := giro.WriteXLSX(&buf, hs); err != nil {
+		t.Fatal(err)
+	}
+	newFn := filepath.Join(dir, "sht.xlsx")
+	if err := os.WriteFile(newFn, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	buf.Reset()
+	zw := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(zw).Encode(hs); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	sameFn := filepath.Join(dir, "snapshot.json.gz")
+	if err := os.WriteFile(sameFn, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	old := []giro.Hitelezo{{Bankszerv: "10002003", Nev: "MÁK", Irszam: "1139", Cim: "Budapest, Váci út 71."}}
+	b, err := json.Marshal(old)
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldFn := filepath.Join(dir, "old.json")
+	if err := os.WriteFile(oldFn, b, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var out strings.Builder
+	if err := Main([]string{"diff", sameFn, newFn}, &out); err != nil {
+		t.Fatalf("%+v: %s", err, out.String())
+	}
+	if got := out.String(); got != "0 added, 0 removed, 0 modified, 2 total\n" {
+		t.Errorf("got %q", got)
+	}
+
+	for name, write := range map[string]func(io.Writer, []giro.Hitelezo, ...giro.Option) error{
+		"snapshot.jsonl": giro.WriteJSONL, "snapshot.xml": giro.WriteXML,
+	} {
+		buf.Reset()
+		if err := write(&buf, hs); err != nil {
+			t.Fatal(err)
+		}
+		fn := filepath.Join(dir, name)
+		if err := os.WriteFile(fn, buf.Bytes(), 0644); err != nil {
+			t.Fatal(err)
+		}
+		out.Reset()
+		if err := Main([]string{"diff", fn, newFn}, &out); err != nil {
+			t.Fatalf("%s: %+v: %s", name, err, out.String())
+		}
+		if got := out.String(); got != "0 added, 0 removed, 0 modified, 2 total\n" {
+			t.Errorf("%s: got %q", name, got)
+		}
+	}
+
+	out.Reset()
+	if err := Main([]string{"diff", oldFn, newFn}, &out); !errors.Is(err, errChanged) {
+		t.Errorf("got %+v, wanted errChanged", err)
+	}
+	for _, want := range []string{"+ 11773016=", "~ 10002003=\"MÁK\"", "1 added, 0 removed, 1 modified, 2 total"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("%q missing from %s", want, out.String())
+		}
+	}
+
+	out.Reset()
+	if err := Main([]string{"diff", "--format", "json", oldFn, newFn}, &out); !errors.Is(err, errChanged) {
+		t.Errorf("got %+v, wanted errChanged", err)
+	}
+	var got struct {
+		Summary giro.ChangeSummary `json:"summary"`
+		giro.Changes
+	}
+	if err := json.Unmarshal([]byte(out.String()), &got); err != nil {
+		t.Fatalf("%s: %+v", out.String(), err)
+	}
+	if got.Summary.Added != 1 || len(got.Modified) != 1 || got.Modified[0].Old.Nev != "MÁK" {
+		t.Errorf("got %+v", got)
+	}
+
+	out.Reset()
+	if err := Main([]string{"diff", "--format", "csv", oldFn, newFn}, &out); !errors.Is(err, errChanged) {
+		t.Errorf("got %+v, wanted errChanged", err)
+	}
+	if got, want := out.String(), "Op,Bankszerv,BIC,Nev,Irszam,Cim\n"+
+		"+,11773016,,OTP Bank,1051,Budapest\n"+
+		"~,10002003,,Magyar Államkincstár,1139,\"Budapest, Váci út 71.\"\n"; got != want {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+}
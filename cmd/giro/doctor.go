@@ -0,0 +1,64 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/UNO-SOFT/giro"
+)
+
+// errNoPDF is returned by doctor when none of the PDF strategies can be used, to exit with 1 without logging.
+var errNoPDF = errors.New("no PDF strategy is available")
+
+// doctorMain prints the external tools of the PDF strategies, with their versions.
+func doctorMain(ctx context.Context, args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("doctor", flag.ContinueOnError)
+	flagFormat := formatFlag(fs)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: giro doctor [--format F]\n\n"+
+			"Prints whether java, tabula, pdftotext and tesseract are available, with their versions.\n"+
+			"The exit code is 1 if none of the PDF strategies can be used.")
+		fs.PrintDefaults()
+	}
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if err := checkFormat(fs, *flagFormat); err != nil {
+		return err
+	}
+	ts := giro.DetectTools(ctx)
+	var err error
+	switch *flagFormat {
+	case formatJSON, formatJSONL:
+		err = writeJSON(w, *flagFormat, ts.List())
+	default:
+		var rows [][]string
+		for _, t := range ts.List() {
+			var msg string
+			if t.Err != nil {
+				msg = t.Err.Error()
+			}
+			rows = append(rows, []string{t.Name, strconv.FormatBool(t.Available()), t.Version, t.Path, msg})
+		}
+		err = writeTable(w, *flagFormat, []string{"Tool", "Available", "Version", "Path", "Error"}, rows)
+	}
+	if err != nil {
+		return err
+	}
+	strategies := ts.Strategies()
+	if *flagFormat == formatTable {
+		fmt.Fprintf(w, "\nPDF strategies: %q\n", strategies)
+	}
+	if len(strategies) == 0 {
+		return errNoPDF
+	}
+	return nil
+}
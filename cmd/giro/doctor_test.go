@@ -0,0 +1,31 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDoctor(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+	var out strings.Builder
+	if err := Main([]string{"doctor", "--format", "json"}, &out); !errors.Is(err, errNoPDF) {
+		t.Fatalf("got %+v, wanted errNoPDF", err)
+	}
+	var tools []struct {
+		Name      string
+		Available bool
+		Error     string
+	}
+	if err := json.Unmarshal([]byte(out.String()), &tools); err != nil {
+		t.Fatalf("%+v: %s", err, out.String())
+	}
+	if len(tools) != 5 || tools[0].Name != "java" || tools[0].Available || tools[0].Error == "" {
+		t.Errorf("got %+v", tools)
+	}
+}
@@ -0,0 +1,215 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/UNO-SOFT/giro"
+	"github.com/xuri/excelize/v2"
+)
+
+// The output formats of the subcommands.
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatJSONL = "jsonl"
+	formatCSV   = "csv"
+	formatXLSX  = "xlsx"
+)
+
+// tableWidth is the maximal width of a column in the table format.
+const tableWidth = 40
+
+// formatFlag defines the --format flag on fs.
+func formatFlag(fs *flag.FlagSet) *string {
+	return fs.String("format", formatTable, "output format: table, json, jsonl, csv or xlsx")
+}
+
+// checkFormat returns errUsage for an unknown format.
+func checkFormat(fs *flag.FlagSet, format string) error {
+	switch format {
+	case formatTable, formatJSON, formatJSONL, formatCSV, formatXLSX:
+		return nil
+	}
+	fmt.Fprintf(fs.Output(), "unknown format %q\n", format)
+	fs.Usage()
+	return errUsage
+}
+
+// recordHeader is the header of the records in the table format.
+var recordHeader = []string{"Bankszerv", "BIC", "Nev", "Irszam", "Cim"}
+
+func recordRow(h giro.Hitelezo) []string {
+	return []string{h.Bankszerv, h.BIC, h.Nev, h.Irszam, h.Cim}
+}
+
+// writeRecords writes the records in the format,
+// by giro.WriteCSV, giro.WriteXLSX and giro.WriteJSONL for csv, xlsx and jsonl.
+func writeRecords(w io.Writer, format string, hs []giro.Hitelezo) error {
+	switch format {
+	case formatCSV:
+		return giro.WriteCSV(w, hs)
+	case formatXLSX:
+		return giro.WriteXLSX(w, hs)
+	case formatJSONL:
+		return giro.WriteJSONL(w, hs)
+	case formatJSON:
+		return writeJSON(w, format, hs)
+	}
+	rows := make([][]string, len(hs))
+	for i, h := range hs {
+		rows[i] = recordRow(h)
+	}
+	return writeTable(w, format, recordHeader, rows)
+}
+
+// writeJSON writes the values as an indented JSON array (json),
+// or one per line (jsonl).
+func writeJSON[T any](w io.Writer, format string, values []T) error {
+	enc := json.NewEncoder(w)
+	if format == formatJSONL {
+		for _, v := range values {
+			if err := enc.Encode(v); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	enc.SetIndent("", "  ")
+	if values == nil {
+		values = []T{}
+	}
+	return enc.Encode(values)
+}
+
+// writeTable writes the rows with the header as an aligned table with the long cells truncated,
+// or as csv or xlsx.
+func writeTable(w io.Writer, format string, header []string, rows [][]string) error {
+	switch format {
+	case formatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(header); err != nil {
+			return err
+		}
+		if err := cw.WriteAll(rows); err != nil {
+			return err
+		}
+		return cw.Error()
+
+	case formatXLSX:
+		wb := excelize.NewFile()
+		defer wb.Close()
+		sheet := wb.GetSheetName(0)
+		for i, row := range append([][]string{header}, rows...) {
+			cell, err := excelize.CoordinatesToCellName(1, i+1)
+			if err != nil {
+				return err
+			}
+			if err = wb.SetSheetRow(sheet, cell, &row); err != nil {
+				return err
+			}
+		}
+		_, err := wb.WriteTo(w)
+		return err
+	}
+
+	// text/tabwriter counts bytes, not runes
+	table := append([][]string{header}, rows...)
+	widths := make([]int, len(header))
+	for _, row := range table {
+		for i, s := range row {
+			row[i] = truncateCell(s)
+			if i < len(widths) {
+				widths[i] = max(widths[i], utf8.RuneCountInString(row[i]))
+			}
+		}
+	}
+	bw := bufio.NewWriter(w)
+	for _, row := range table {
+		for i, s := range row {
+			bw.WriteString(s)
+			if i < len(row)-1 && i < len(widths) {
+				bw.WriteString(strings.Repeat(" ", widths[i]-utf8.RuneCountInString(s)+2))
+			}
+		}
+		bw.WriteByte('\n')
+	}
+	return bw.Flush()
+}
+
+// truncateCell returns s truncated to tableWidth runes, ending with an ellipsis if truncated.
+func truncateCell(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	var n int
+	for i := range s {
+		if n == tableWidth-1 {
+			return s[:i] + "…"
+		}
+		n++
+	}
+	return s
+}
+
+// changeRow is a change of a record, in the jsonl format.
+type changeRow struct {
+	File string         `json:"file,omitempty"`
+	Op   string         `json:"op"`
+	Old  *giro.Hitelezo `json:"old,omitempty"`
+	New  *giro.Hitelezo `json:"new,omitempty"`
+	// Error is the error of the file, with "!" as Op.
+	Error string `json:"error,omitempty"`
+}
+
+// changeRows returns the changes, as "-" (removed), "+" (added) and "~" (modified).
+func changeRows(file string, changes giro.Changes) []changeRow {
+	rows := make([]changeRow, 0, len(changes.Removed)+len(changes.Added)+len(changes.Modified))
+	for _, h := range changes.Removed {
+		rows = append(rows, changeRow{File: file, Op: "-", Old: &h})
+	}
+	for _, h := range changes.Added {
+		rows = append(rows, changeRow{File: file, Op: "+", New: &h})
+	}
+	for _, m := range changes.Modified {
+		rows = append(rows, changeRow{File: file, Op: "~", Old: &m.Old, New: &m.New})
+	}
+	return rows
+}
+
+// writeChanges writes the changes in the csv, xlsx or jsonl format:
+// the new version of the added and modified, and the old of the removed records, after their op
+// (and their file, if withFile).
+func writeChanges(w io.Writer, format string, rows []changeRow, withFile bool) error {
+	if format == formatJSONL {
+		return writeJSON(w, format, rows)
+	}
+	header := append([]string{"Op"}, recordHeader...)
+	if withFile {
+		header = append([]string{"File"}, header...)
+	}
+	table := make([][]string, len(rows))
+	for i, r := range rows {
+		row := []string{r.Op}
+		if h := r.New; h != nil {
+			row = append(row, recordRow(*h)...)
+		} else if h = r.Old; h != nil {
+			row = append(row, recordRow(*h)...)
+		} else {
+			row = append(row, r.Error)
+		}
+		if withFile {
+			row = append([]string{r.File}, row...)
+		}
+		table[i] = row
+	}
+	return writeTable(w, format, header, table)
+}
@@ -0,0 +1,32 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/UNO-SOFT/giro"
+)
+
+func TestWriteRecords(t *testing.T) {
+	hs := []giro.Hitelezo{{Bankszerv: "10002003", BIC: "HUSTHUHB",
+		Nev: "Magyar Államkincstár Budapesti Központi Fiókja és Ügyfélszolgálata", Irszam: "1139", Cim: "Budapest, Váci út 71."}}
+	for format, want := range map[string]string{
+		formatTable: "Bankszerv  BIC       Nev" + strings.Repeat(" ", 39) + "Irszam  Cim\n" +
+			"10002003   HUSTHUHB  Magyar Államkincstár Budapesti Központi…  1139    Budapest, Váci út 71.\n",
+		formatJSONL: `{"Bankszerv":"10002003","BIC":"HUSTHUHB","Nev":"Magyar Államkincstár Budapesti Központi Fiókja és Ügyfélszolgálata","Irszam":"1139","Cim":"Budapest, Váci út 71."}` + "\n",
+		formatCSV: "\"Bankszerv\",\"BIC\",\"Nev\",\"Irszam\",\"Cim\"\r\n" +
+			"\"10002003\",\"HUSTHUHB\",\"Magyar Államkincstár Budapesti Központi Fiókja és Ügyfélszolgálata\",\"1139\",\"Budapest, Váci út 71.\"\r\n",
+	} {
+		var buf strings.Builder
+		if err := writeRecords(&buf, format, hs); err != nil {
+			t.Fatal(err)
+		}
+		if got := buf.String(); got != want {
+			t.Errorf("%s: got\n%s\nwanted\n%s", format, got, want)
+		}
+	}
+}
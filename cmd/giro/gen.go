@@ -0,0 +1,113 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"go/format"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/UNO-SOFT/giro"
+)
+
+// genMain writes a Go source file with the records as a package-level variable,
+// to embed the list into the offline binaries, such as with
+//
+//	//go:generate go run github.com/UNO-SOFT/giro/cmd/giro gen -package banks -o giro_data.go
+func genMain(ctx context.Context, args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("gen", flag.ContinueOnError)
+	flagPackage := fs.String("package", "main", "package name of the generated file")
+	flagVar := fs.String("var", "Hitelezok", "name of the variable")
+	flagModel := fs.Bool("model", false, "use github.com/UNO-SOFT/giro/model.Hitelezo as the type, instead of a local Hitelezo struct")
+	flagFile := fs.String("file", "", "the list to read (any format read by diff), instead of downloading it")
+	flagOut := fs.String("o", "", "output file (default stdout)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: giro gen [--package P] [--var V] [--model] [--file F] [-o out.go]\n\n"+
+			"Downloads and parses the list (or reads --file), and writes it as Go source,\n"+
+			"without any dependency by default.")
+		fs.PrintDefaults()
+	}
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if !token.IsIdentifier(*flagPackage) || !token.IsIdentifier(*flagVar) {
+		fs.Usage()
+		return errUsage
+	}
+	var hs []giro.Hitelezo
+	var err error
+	if *flagFile != "" {
+		hs, err = loadRecords(ctx, *flagFile)
+	} else {
+		hs, err = giro.Parse(ctx, nil)
+	}
+	if err != nil {
+		return err
+	}
+	src, err := genSource(*flagPackage, *flagVar, *flagModel, hs)
+	if err != nil {
+		return err
+	}
+	if *flagOut == "" {
+		_, err = w.Write(src)
+		return err
+	}
+	fh, err := os.CreateTemp(filepath.Dir(*flagOut), ".giro-gen-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(fh.Name())
+	if _, err = fh.Write(src); err != nil {
+		fh.Close()
+		return err
+	}
+	if err = fh.Close(); err != nil {
+		return err
+	}
+	return os.Rename(fh.Name(), *flagOut)
+}
+
+// genSource returns the gofmt'd Go source of the records as the variable.
+func genSource(pkg, name string, useModel bool, hs []giro.Hitelezo) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by giro gen; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", pkg)
+	typ := "Hitelezo"
+	if useModel {
+		buf.WriteString("import \"github.com/UNO-SOFT/giro/model\"\n\n")
+		typ = "model.Hitelezo"
+	} else {
+		buf.WriteString("// Hitelezo is the record of a branch.\n" +
+			"type Hitelezo struct {\n\tBankszerv, BIC, Nev, Irszam, Cim string\n}\n\n")
+	}
+	fmt.Fprintf(&buf, "// %s are the %d branches of the list.\n", name, len(hs))
+	fmt.Fprintf(&buf, "var %s = []%s{\n", name, typ)
+	for _, h := range hs {
+		buf.WriteByte('{')
+		var n int
+		for _, f := range []struct{ name, value string }{
+			{"Bankszerv", h.Bankszerv}, {"BIC", h.BIC}, {"Nev", h.Nev}, {"Irszam", h.Irszam}, {"Cim", h.Cim},
+		} {
+			if f.value == "" {
+				continue
+			}
+			if n != 0 {
+				buf.WriteString(", ")
+			}
+			n++
+			buf.WriteString(f.name + ": " + strconv.Quote(f.value))
+		}
+		buf.WriteString("},\n")
+	}
+	buf.WriteString("}\n")
+	return format.Source(buf.Bytes())
+}
@@ -0,0 +1,66 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/json"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/UNO-SOFT/giro"
+)
+
+func TestGen(t *testing.T) {
+	dir := t.TempDir()
+	hs := []giro.Hitelezo{
+		{Bankszerv: "10002003", BIC: "HUSTHUHB", Nev: `Magyar "Állam" kincstár`, Irszam: "1139", Cim: "Budapest, Váci út 71."},
+		{Bankszerv: "11773016", Nev: "OTP Bank", Irszam: "1051"},
+	}
+	b, err := json.Marshal(hs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inp := filepath.Join(dir, "list.json")
+	if err := os.WriteFile(inp, b, 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, model := range []bool{false, true} {
+		out := filepath.Join(dir, "data.go")
+		args := []string{"gen", "-package", "banks", "-var", "Branches", "-file", inp, "-o", out}
+		if model {
+			args = append(args, "-model")
+		}
+		if err := Main(args, os.Stdout); err != nil {
+			t.Fatal(err)
+		}
+		src, err := os.ReadFile(out)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f, err := parser.ParseFile(token.NewFileSet(), out, src, 0)
+		if err != nil {
+			t.Fatalf("%v:\n%s", err, src)
+		}
+		if f.Name.Name != "banks" {
+			t.Errorf("got package %q", f.Name.Name)
+		}
+		for _, want := range []string{
+			"// Code generated by giro gen; DO NOT EDIT.",
+			`{Bankszerv: "10002003", BIC: "HUSTHUHB", Nev: "Magyar \"Állam\" kincstár", Irszam: "1139", Cim: "Budapest, Váci út 71."},`,
+			`{Bankszerv: "11773016", Nev: "OTP Bank", Irszam: "1051"},`,
+		} {
+			if !strings.Contains(string(src), want) {
+				t.Errorf("%q missing from\n%s", want, src)
+			}
+		}
+		if hasModel := strings.Contains(string(src), "[]model.Hitelezo{"); hasModel != model {
+			t.Errorf("model=%t:\n%s", model, src)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Command giro is the command-line tool of the giro package.
+//
+// Usage:
+//
+//	giro reparse --store DIR    re-parse the stored original files, and report the differences
+//	giro reparse --archive DIR --snapshots DIR
+//	                            re-parse the archived downloads, and report the differences from the snapshots
+//	giro diff old new           compare two versions of the list, exiting with 1 if they differ
+//	giro validate [account...]  validate the account numbers or IBANs (or those on stdin), and print their branches
+//	giro daemon --state-dir DIR keep a fresh snapshot in DIR, and serve it over HTTP
+//	giro doctor                 print the external tools of the PDF strategies, and their versions
+//	giro gen -o data.go         write the list as Go source, to embed it (see go:generate)
+//
+// The reparse, diff, validate and doctor commands accept --format table (default, aligned and truncated),
+// json, jsonl, csv or xlsx.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"os"
+	"os/signal"
+	"slices"
+	"syscall"
+)
+
+func main() {
+	if err := Main(os.Args[1:], os.Stdout); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return
+		}
+		if errors.Is(err, errChanged) || errors.Is(err, errInvalid) || errors.Is(err, errNoPDF) {
+			os.Exit(1)
+		}
+		slog.Error("main", "error", err)
+		os.Exit(1)
+	}
+}
+
+// errUsage is returned for bad command lines, after the usage has been printed.
+var errUsage = errors.New("usage error")
+
+// commands are the subcommands, by name.
+var commands = map[string]func(ctx context.Context, args []string, w io.Writer) error{
+	"reparse":  reparseMain,
+	"diff":     diffMain,
+	"validate": validateMain,
+	"daemon":   daemonMain,
+	"doctor":   doctorMain,
+	"gen":      genMain,
+}
+
+// Main runs the command of args[0], returning flag.ErrHelp if only the help has been asked for.
+func Main(args []string, w io.Writer) error {
+	if len(args) == 0 || commands[args[0]] == nil {
+		fmt.Fprintln(os.Stderr, "Usage: giro <command> [flags]\n\nCommands:")
+		for _, name := range slices.Sorted(maps.Keys(commands)) {
+			fmt.Fprintln(os.Stderr, "  "+name)
+		}
+		if len(args) != 0 && (args[0] == "-h" || args[0] == "-help" || args[0] == "--help") {
+			return flag.ErrHelp
+		}
+		return errUsage
+	}
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	return commands[args[0]](ctx, args[1:], w)
+}
+
+// parseFlags parses the args with fs, returning errUsage on error,
+// and flag.ErrHelp if the help has been asked for (the command must not run then).
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errUsage
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/UNO-SOFT/giro"
+)
+
+// reparseMain re-parses the original files of the store (or the downloads of the archive),
+// and reports the differences from their stored parsed versions.
+//
+// The store is a directory of original files, each with its parsed version
+// (a JSON array of giro.Hitelezo) next to it, named as the original plus ".json".
+//
+// The archive is a giro.Archive, with the parsed versions in the giro.FileStore of the snapshots directory,
+// by their effective date (or the day of the download, as stored by giro.Refresher).
+func reparseMain(ctx context.Context, args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("reparse", flag.ContinueOnError)
+	flagStore := fs.String("store", "", "directory of the original files and their parsed versions")
+	flagArchive := fs.String("archive", "", "directory of the giro.Archive of the downloads")
+	flagSnapshots := fs.String("snapshots", "", "directory of the giro.FileStore of the parsed versions of the --archive")
+	flagFormat := formatFlag(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if err := checkFormat(fs, *flagFormat); err != nil {
+		return err
+	}
+	if (*flagStore == "") == (*flagArchive == "") || (*flagArchive == "") != (*flagSnapshots == "") {
+		fs.Usage()
+		return errUsage
+	}
+	rep := reparser{w: w, table: *flagFormat == formatTable}
+	var err error
+	if *flagArchive != "" {
+		err = rep.reparseArchive(ctx, *flagArchive, *flagSnapshots)
+	} else {
+		err = rep.reparseStore(ctx, *flagStore)
+	}
+	if err != nil {
+		return err
+	}
+	if rep.table {
+		fmt.Fprintf(w, "%d files, %d changed\n", rep.files, rep.changed)
+	} else if *flagFormat == formatJSON {
+		err = writeJSON(w, *flagFormat, rep.rows)
+	} else {
+		err = writeChanges(w, *flagFormat, rep.rows, true)
+	}
+	if err != nil {
+		return err
+	}
+	if rep.changed != 0 {
+		return fmt.Errorf("%d of %d files parse differently", rep.changed, rep.files)
+	}
+	return nil
+}
+
+// reparser collects the differences of the re-parsed files.
+type reparser struct {
+	w              io.Writer
+	table          bool
+	files, changed int
+	rows           []changeRow
+}
+
+// reparseStore re-parses the original files of the directory having a parsed version.
+func (rep *reparser) reparseStore(ctx context.Context, dir string) error {
+	des, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, de := range des {
+		if de.IsDir() || strings.HasSuffix(de.Name(), ".json") {
+			continue
+		}
+		fn := filepath.Join(dir, de.Name())
+		stored, err := readJSON(fn + ".json")
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return err
+		}
+		fh, err := os.Open(fn)
+		if err != nil {
+			return err
+		}
+		parsed, err := giro.Parse(ctx, fh, giro.WithSource(&giro.Source{Filename: de.Name()}))
+		fh.Close()
+		rep.compare(de.Name(), stored, parsed, err)
+	}
+	return nil
+}
+
+// reparseArchive re-parses the downloads of the archive having a snapshot in the store, each file once.
+func (rep *reparser) reparseArchive(ctx context.Context, archiveDir, storeDir string) error {
+	archive, err := giro.OpenArchive(archiveDir)
+	if err != nil {
+		return err
+	}
+	entries, err := archive.List()
+	if err != nil {
+		return err
+	}
+	store, err := giro.NewFileStore(storeDir)
+	if err != nil {
+		return err
+	}
+	seen := make(map[string]bool, len(entries))
+	for _, e := range entries {
+		if seen[e.SHA256] {
+			continue
+		}
+		seen[e.SHA256] = true
+		parsed, src, parseErr := archive.Parse(ctx, e)
+		date := src.EffectiveDate
+		if date.IsZero() {
+			y, m, d := e.Downloaded.UTC().Date()
+			date = time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+		}
+		stored, storedDate, err := store.GetByDate(ctx, date)
+		if errors.Is(err, giro.ErrNotFound) || err == nil && !storedDate.Equal(date) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		name := e.Filename
+		if name == "" {
+			name = path.Base(e.URL)
+		}
+		rep.compare(name+"@"+date.Format(time.DateOnly), stored, parsed, parseErr)
+	}
+	return nil
+}
+
+// compare records the differences of the parsed records (or the parse error) of the file from the stored ones.
+func (rep *reparser) compare(name string, stored, parsed []giro.Hitelezo, err error) {
+	rep.files++
+	if err != nil {
+		rep.changed++
+		if rep.table {
+			fmt.Fprintf(rep.w, "%s: %+v\n", name, err)
+		} else {
+			rep.rows = append(rep.rows, changeRow{File: name, Op: "!", Error: err.Error()})
+		}
+		return
+	}
+	changes := giro.Diff(stored, parsed)
+	if changes.Empty() {
+		return
+	}
+	rep.changed++
+	if !rep.table {
+		rep.rows = append(rep.rows, changeRows(name, changes)...)
+		return
+	}
+	fmt.Fprintf(rep.w, "%s: %d differences\n", name, len(changes.Added)+len(changes.Removed)+len(changes.Modified))
+	printChanges(rep.w, "\t", changes)
+}
+
+func readJSON(fn string) ([]giro.Hitelezo, error) {
+	b, err := os.ReadFile(fn)
+	if err != nil {
+		return nil, err
+	}
+	var hs []giro.Hitelezo
+	if err := json.Unmarshal(b, &hs); err != nil {
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+	return hs, nil
+}
+
+// recordString returns all the fields of h.
+func recordString(h giro.Hitelezo) string {
+	if h.BIC == "" {
+		return h.String()
+	}
+	return h.String() + " BIC=" + h.BIC
+}
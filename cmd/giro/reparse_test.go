@@ -0,0 +1,168 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/UNO-SOFT/giro"
+)
+
+func TestReparse(t *testing.T) {
+	hs := []giro.Hitelezo{
+		{Bankszerv: "03000007", Nev: "Próba Bank", Irszam: "1139", Cim: "Budapest, Váci út 71."},
+		{Bankszerv: "11773016", Nev: "OTP Bank", Irszam: "1051", Cim: "Budapest"},
+	}
+	dir := t.TempDir()
+	var buf bytes.Buffer
+	if err := giro.WriteXLSX(&buf, hs); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "sht.xlsx"), buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	writeStored := func(hs []giro.Hitelezo) {
+		b, err := json.Marshal(hs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "sht.xlsx.json"), b, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writeStored(hs)
+	var out strings.Builder
+	if err := Main([]string{"reparse", "--store", dir}, &out); err != nil {
+		t.Fatalf("%+v: %s", err, out.String())
+	}
+	if got := out.String(); got != "1 files, 0 changed\n" {
+		t.Errorf("got %q", got)
+	}
+
+	old := []giro.Hitelezo{hs[0], {Bankszerv: "10002003", Nev: "MÁK"}}
+	old[0].Nev = "Régi Bank"
+	writeStored(old)
+	out.Reset()
+	if err := Main([]string{"reparse", "--store", dir}, &out); err == nil {
+		t.Error("wanted error for the differences")
+	}
+	for _, want := range []string{"sht.xlsx: 3 differences", "Régi Bank", "+ 11773016=", "- 10002003="} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("%q missing from %s", want, out.String())
+		}
+	}
+}
+
+func TestReparseFormat(t *testing.T) {
+	hs := []giro.Hitelezo{{Bankszerv: "11773016", Nev: "OTP Bank", Irszam: "1051", Cim: "Budapest"}}
+	dir := t.TempDir()
+	var buf bytes.Buffer
+	if err := giro.WriteXLSX(&buf, hs); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "sht.xlsx"), buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "sht.xlsx.json"), []byte("[]"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	var out strings.Builder
+	if err := Main([]string{"reparse", "--store", dir, "--format", "jsonl"}, &out); err == nil {
+		t.Error("wanted error for the differences")
+	}
+	var row changeRow
+	if err := json.Unmarshal([]byte(out.String()), &row); err != nil {
+		t.Fatalf("%s: %+v", out.String(), err)
+	}
+	if row.File != "sht.xlsx" || row.Op != "+" || row.New == nil || row.New.Bankszerv != "11773016" {
+		t.Errorf("got %+v", row)
+	}
+
+	if err := Main([]string{"reparse", "--store", dir, "--format", "yaml"}, &out); !errors.Is(err, errUsage) {
+		t.Errorf("got %+v, wanted errUsage", err)
+	}
+	out.Reset()
+	for _, args := range [][]string{{"-h"}, {"reparse", "-h"}, {"diff", "-h", "old", "new"}} {
+		if err := Main(args, &out); !errors.Is(err, flag.ErrHelp) || out.Len() != 0 {
+			t.Errorf("%q: got %+v and %q, wanted flag.ErrHelp only", args, err, out.String())
+		}
+	}
+}
+
+func TestReparseArchive(t *testing.T) {
+	ctx := context.Background()
+	hs := []giro.Hitelezo{{Bankszerv: "11773016", Nev: "OTP Bank", Irszam: "1051", Cim: "Budapest"}}
+	archiveDir, snapshotsDir := t.TempDir(), t.TempDir()
+	archive, err := giro.OpenArchive(archiveDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store, err := giro.NewFileStore(snapshotsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	day := time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)
+	for i, h := range []giro.Hitelezo{hs[0], {Bankszerv: "10002003", Nev: "MÁK"}} {
+		var buf bytes.Buffer
+		if err := giro.WriteXLSX(&buf, []giro.Hitelezo{h}); err != nil {
+			t.Fatal(err)
+		}
+		// the same file downloaded twice
+		for range 2 {
+			if _, err := archive.Add(bytes.NewReader(buf.Bytes()), giro.ArchiveEntry{
+				URL: "https://www.mnb.hu/letoltes/sht.xlsx", Downloaded: day.AddDate(0, 0, i).Add(time.Hour),
+			}); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	// the second download has no snapshot of its day
+	if err := store.PutSnapshot(ctx, day, hs); err != nil {
+		t.Fatal(err)
+	}
+
+	var out strings.Builder
+	args := []string{"reparse", "--archive", archiveDir, "--snapshots", snapshotsDir}
+	if err := Main(args, &out); err != nil {
+		t.Fatalf("%+v: %s", err, out.String())
+	}
+	if got := out.String(); got != "1 files, 0 changed\n" {
+		t.Errorf("got %q", got)
+	}
+
+	hs[0].Cim = "Budapest, Nádor u. 16."
+	if err := store.PutSnapshot(ctx, day, hs); err != nil {
+		t.Fatal(err)
+	}
+	out.Reset()
+	if err := Main(args, &out); err == nil {
+		t.Error("wanted error for the differences")
+	}
+	for _, want := range []string{"sht.xlsx@2026-03-04: 1 differences", "~ 11773016=", "Nádor u. 16."} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("%q missing from %s", want, out.String())
+		}
+	}
+
+	for _, args := range [][]string{
+		{"reparse", "--archive", archiveDir},
+		{"reparse", "--store", archiveDir, "--archive", archiveDir, "--snapshots", snapshotsDir},
+	} {
+		if err := Main(args, io.Discard); !errors.Is(err, errUsage) {
+			t.Errorf("%q: got %+v, wanted errUsage", args, err)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/UNO-SOFT/giro"
+)
+
+// errInvalid is returned by validate when an account number is invalid, to exit with 1 without logging.
+var errInvalid = errors.New("invalid")
+
+// validateMain validates the account numbers (or IBANs) of the args, or of the lines of stdin,
+// and prints their branches.
+func validateMain(ctx context.Context, args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("validate", flag.ContinueOnError)
+	flagFormat := formatFlag(fs)
+	flagFile := fs.String("file", "", "the list to resolve the branches from (any format read by diff), instead of the embedded snapshot")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: giro validate [--format F] [--file F] [account number or IBAN...]\n\n"+
+			"Without arguments, the account numbers are read from stdin, one per line.\n"+
+			"The exit code is 1 if any of them is invalid.")
+		fs.PrintDefaults()
+	}
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if err := checkFormat(fs, *flagFormat); err != nil {
+		return err
+	}
+	dir := giro.LoadEmbedded()
+	if *flagFile != "" {
+		hs, err := loadRecords(ctx, *flagFile)
+		if err != nil {
+			return err
+		}
+		dir = giro.NewDirectory(hs)
+	}
+
+	inputs := fs.Args()
+	if len(inputs) == 0 {
+		scanner := bufio.NewScanner(os.Stdin)
+		for scanner.Scan() {
+			if s := strings.TrimSpace(scanner.Text()); s != "" {
+				inputs = append(inputs, s)
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return err
+		}
+	}
+	vs := giro.ValidateBatch(dir, inputs)
+	var invalid int
+	for _, v := range vs {
+		if v.Error != "" {
+			invalid++
+		}
+	}
+
+	var err error
+	switch *flagFormat {
+	case formatJSON, formatJSONL:
+		err = writeJSON(w, *flagFormat, vs)
+	default:
+		header := append([]string{"Input", "Account", "IBAN", "Error"}, recordHeader...)
+		rows := make([][]string, len(vs))
+		for i, v := range vs {
+			rows[i] = []string{v.Input, v.Account, v.IBAN, v.Error}
+			if v.Branch != nil {
+				rows[i] = append(rows[i], recordRow(*v.Branch)...)
+			}
+		}
+		err = writeTable(w, *flagFormat, header, rows)
+	}
+	if err != nil {
+		return err
+	}
+	if invalid != 0 {
+		return fmt.Errorf("%d of %d: %w", invalid, len(vs), errInvalid)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/UNO-SOFT/giro"
+)
+
+func TestValidate(t *testing.T) {
+	var out strings.Builder
+	if err := Main([]string{"validate", "--format", "jsonl", "1000200300000000", "HU42 1177 3016 1111 1018 0000 0000"}, &out); err != nil {
+		t.Fatalf("%+v: %s", err, out.String())
+	}
+	dec := json.NewDecoder(strings.NewReader(out.String()))
+	var v giro.AccountValidation
+	if err := dec.Decode(&v); err != nil {
+		t.Fatal(err)
+	}
+	if v.Error != "" || v.Account != "10002003-00000000" || v.Branch == nil || v.Branch.Irszam != "1139" {
+		t.Errorf("got %+v", v)
+	}
+	if err := dec.Decode(&v); err != nil {
+		t.Fatal(err)
+	}
+	if v.Account != "11773016-11111018" || !strings.HasPrefix(v.IBAN, "HU42 ") {
+		t.Errorf("got %+v", v)
+	}
+
+	out.Reset()
+	if err := Main([]string{"validate", "11773016-11111019"}, &out); !errors.Is(err, errInvalid) {
+		t.Errorf("got %+v, wanted errInvalid", err)
+	}
+	if !strings.Contains(out.String(), "11111019, wanted '8'") {
+		t.Errorf("got %s", out.String())
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Compression is a compression of the snapshot files (such as snapshot.json.gz),
+// recognized by its magic bytes when reading, and by the file name extension when writing.
+//
+// Only gzip and zstd are built in, the others (such as xz) can be added with RegisterCompression.
+type Compression struct {
+	// Name is the name of the compression, such as "gzip".
+	Name string
+	// Ext is the file name extension, such as ".gz".
+	Ext string
+	// Magic is the start of the compressed data.
+	Magic     []byte
+	NewReader func(io.Reader) (io.ReadCloser, error)
+	NewWriter func(io.Writer) (io.WriteCloser, error)
+}
+
+var (
+	compressionsMu sync.RWMutex
+	compressions   = []Compression{{
+		Name: "gzip", Ext: ".gz", Magic: []byte{0x1f, 0x8b},
+		NewReader: func(r io.Reader) (io.ReadCloser, error) { return gzip.NewReader(r) },
+		NewWriter: func(w io.Writer) (io.WriteCloser, error) { return gzip.NewWriter(w), nil },
+	}, {
+		Name: "zstd", Ext: ".zst", Magic: []byte{0x28, 0xb5, 0x2f, 0xfd},
+		NewReader: func(r io.Reader) (io.ReadCloser, error) {
+			zr, err := zstd.NewReader(r)
+			if err != nil {
+				return nil, err
+			}
+			return zr.IOReadCloser(), nil
+		},
+		NewWriter: func(w io.Writer) (io.WriteCloser, error) { return zstd.NewWriter(w) },
+	}}
+)
+
+// RegisterCompression adds the compression, replacing the one with the same Name.
+//
+// For example, xz with github.com/ulikunitz/xz:
+//
+//	giro.RegisterCompression(giro.Compression{
+//		Name: "xz", Ext: ".xz", Magic: []byte{0xfd, '7', 'z', 'X', 'Z', 0},
+//		NewReader: func(r io.Reader) (io.ReadCloser, error) {
+//			xr, err := xz.NewReader(r)
+//			if err != nil {
+//				return nil, err
+//			}
+//			return io.NopCloser(xr), nil
+//		},
+//		NewWriter: func(w io.Writer) (io.WriteCloser, error) { return xz.NewWriter(w) },
+//	})
+func RegisterCompression(c Compression) {
+	compressionsMu.Lock()
+	defer compressionsMu.Unlock()
+	if i := slices.IndexFunc(compressions, func(old Compression) bool { return old.Name == c.Name }); i >= 0 {
+		compressions[i] = c
+	} else {
+		compressions = append(compressions, c)
+	}
+}
+
+// compressionBy returns the first registered compression matching.
+func compressionBy(match func(Compression) bool) (Compression, bool) {
+	compressionsMu.RLock()
+	defer compressionsMu.RUnlock()
+	if i := slices.IndexFunc(compressions, match); i >= 0 {
+		return compressions[i], true
+	}
+	return Compression{}, false
+}
+
+// Decompress returns the decompressed contents of r, by the magic bytes of the registered compressions,
+// or r as is, if it is not compressed.
+func Decompress(r io.Reader) (io.ReadCloser, error) {
+	br := bufio.NewReader(r)
+	b, _ := br.Peek(8)
+	c, ok := compressionBy(func(c Compression) bool { return len(c.Magic) != 0 && bytes.HasPrefix(b, c.Magic) })
+	if !ok {
+		return io.NopCloser(br), nil
+	}
+	return c.NewReader(br)
+}
+
+// Compress returns a writer compressing into w by the extension of the file name,
+// or w as is (with a no-op Close) for the other names.
+//
+// The returned writer must be closed to flush the compressed data.
+func Compress(w io.Writer, name string) (io.WriteCloser, error) {
+	ext := strings.ToLower(filepath.Ext(name))
+	c, ok := compressionBy(func(c Compression) bool { return c.Ext == ext })
+	if !ok {
+		return nopWriteCloser{w}, nil
+	}
+	return c.NewWriter(w)
+}
+
+// TrimCompressionExt returns the file name without the extension of a registered compression,
+// to tell the format of the contents: "snapshot.csv.zst" is "snapshot.csv".
+func TrimCompressionExt(name string) string {
+	ext := filepath.Ext(name)
+	if _, ok := compressionBy(func(c Compression) bool { return c.Ext == strings.ToLower(ext) }); ok {
+		return strings.TrimSuffix(name, ext)
+	}
+	return name
+}
+
+type nopWriteCloser struct{ io.Writer }
+
+func (nopWriteCloser) Close() error { return nil }
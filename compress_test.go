@@ -0,0 +1,64 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+// prefixWriter is a fake compression: the magic, then the data as is.
+type prefixWriter struct{ io.Writer }
+
+func (prefixWriter) Close() error { return nil }
+
+func TestCompression(t *testing.T) {
+	RegisterCompression(Compression{
+		Name: "test", Ext: ".tst", Magic: []byte("TST\x00"),
+		NewReader: func(r io.Reader) (io.ReadCloser, error) {
+			var magic [4]byte
+			_, err := io.ReadFull(r, magic[:])
+			return io.NopCloser(r), err
+		},
+		NewWriter: func(w io.Writer) (io.WriteCloser, error) {
+			_, err := io.WriteString(w, "TST\x00")
+			return prefixWriter{w}, err
+		},
+	})
+	const data = `[{"Bankszerv":"10002003"}]`
+	for _, name := range []string{"snapshot.json.gz", "snapshot.json.zst", "snapshot.JSON.TST", "snapshot.json"} {
+		var buf bytes.Buffer
+		w, err := Compress(&buf, name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = io.WriteString(w, data); err != nil {
+			t.Fatal(err)
+		}
+		if err = w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if compressed := buf.String() != data; compressed == strings.HasSuffix(name, ".json") {
+			t.Errorf("%s: got %q", name, buf.String())
+		}
+		r, err := Decompress(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := io.ReadAll(r)
+		r.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != data {
+			t.Errorf("%s: got %q, wanted %q", name, b, data)
+		}
+		if got := TrimCompressionExt(name); !strings.EqualFold(got, "snapshot.json") {
+			t.Errorf("%s: got %q", name, got)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+// TabulaContainer runs tabula in a container, for the hosts without Java.
+type TabulaContainer struct {
+	// Runtime is the container engine (default is docker or podman, the first found in PATH).
+	Runtime string
+	// Image is the container image with java in its PATH, such as "docker.io/eclipse-temurin:21-jre".
+	// It is required: there is no default image.
+	Image string
+}
+
+// WithTabulaContainer makes the tabula strategy of ParsePDF run tabula in a container
+// when no local Java is found (see WithJava).
+//
+// The jar and the PDF are bind-mounted read-only, and the container has no network.
+func WithTabulaContainer(c TabulaContainer) Option {
+	return func(o *options) { o.container = &c }
+}
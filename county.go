@@ -0,0 +1,48 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"strings"
+
+	"github.com/UNO-SOFT/giro/model"
+)
+
+// Counties are the names of the counties (megye) returned by Hitelezo.County, and Budapest.
+var Counties = model.Counties
+
+// countyKey returns the key of the county name in the index,
+// without the " megye" or " vármegye" suffix.
+func countyKey(megye string) string {
+	k := Fold(megye)
+	k = strings.TrimSuffix(k, " varmegye")
+	return strings.TrimSuffix(k, " megye")
+}
+
+// ByCity returns the records of the branches in the city (see Hitelezo.City),
+// ignoring case and accents.
+func (d *Directory) ByCity(name string) []Hitelezo {
+	snap := d.snap.Load()
+	return snap.collect(snap.byCity[Fold(name)])
+}
+
+// ByCounty returns the records of the branches in the county (see Hitelezo.County),
+// ignoring case, accents and the " megye" suffix ("Pest megye" is "pest").
+func (d *Directory) ByCounty(megye string) []Hitelezo {
+	snap := d.snap.Load()
+	return snap.collect(snap.byCounty[countyKey(megye)])
+}
+
+// collect returns the records at the indexes.
+func (snap *dirSnapshot) collect(idx []int) []Hitelezo {
+	if len(idx) == 0 {
+		return nil
+	}
+	hs := make([]Hitelezo, len(idx))
+	for i, j := range idx {
+		hs[i] = snap.records[j]
+	}
+	return hs
+}
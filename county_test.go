@@ -0,0 +1,44 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import "testing"
+
+func TestCounty(t *testing.T) {
+	for irszam, want := range map[string]string{
+		"1139": "Budapest", "2000": "Pest", "2660": "Nógrád", "3300": "Heves", "3525": "Borsod-Abaúj-Zemplén",
+		"4025": "Hajdú-Bihar", "4400": "Szabolcs-Szatmár-Bereg", "6720": "Csongrád-Csanád",
+		"7621": "Baranya", "8360": "Zala", "8200": "Veszprém", "9021": "Győr-Moson-Sopron",
+		"9700": "Vas", "0999": "", "123": "",
+	} {
+		if got := (Hitelezo{Irszam: irszam}).County(); got != want {
+			t.Errorf("%q: got %q, wanted %q", irszam, got, want)
+		}
+	}
+}
+
+func TestDirectoryByCity(t *testing.T) {
+	d := NewDirectory([]Hitelezo{
+		{Bankszerv: "10002003", Nev: "Magyar Államkincstár", Irszam: "1139", Cim: "Váci út 71."},
+		{Bankszerv: "11773016", Nev: "OTP Bank", Irszam: "9021", Cim: "Győr, Baross Gábor u. 16."},
+		{Bankszerv: "11773023", Nev: "OTP Bank", Irszam: "2000", Cim: "Szentendre Dumtsa Jenő u. 1."},
+		{Bankszerv: "11773030", Nev: "OTP Bank", Irszam: "2600", Cim: "Vác, Széchenyi u. 1."},
+	})
+	if got := d.ByCity("GYOR"); len(got) != 1 || got[0].Bankszerv != "11773016" {
+		t.Errorf("ByCity(GYOR): got %+v", got)
+	}
+	if got := d.ByCity("budapest"); len(got) != 1 || got[0].Bankszerv != "10002003" {
+		t.Errorf("ByCity(budapest): got %+v", got)
+	}
+	if got := d.ByCity("Szentendre"); len(got) != 1 {
+		t.Errorf("ByCity(Szentendre): got %+v", got)
+	}
+	if got := d.ByCounty("Pest megye"); len(got) != 2 {
+		t.Errorf("ByCounty(Pest megye): got %+v", got)
+	}
+	if got := d.ByCounty("Zala"); len(got) != 0 {
+		t.Errorf("ByCounty(Zala): got %+v", got)
+	}
+}
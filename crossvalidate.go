@@ -0,0 +1,99 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// DiscrepancyKind is the kind of a Discrepancy between the EHT and the SHT.
+type DiscrepancyKind string
+
+const (
+	// MissingFromEHT is a branch listed only in the SHT.
+	MissingFromEHT = DiscrepancyKind("missing-from-eht")
+	// MissingFromSHT is a branch listed only in the EHT.
+	MissingFromSHT = DiscrepancyKind("missing-from-sht")
+	// Mismatch is a field of a branch differing between the publications.
+	Mismatch = DiscrepancyKind("mismatch")
+)
+
+// Discrepancy is a difference between the EHT and the SHT, found by CrossValidate.
+type Discrepancy struct {
+	Bankszerv string
+	Kind      DiscrepancyKind
+	// Field is the differing field (FieldNev, FieldIrszam or FieldCim) of a Mismatch.
+	Field string
+	// EHT and SHT are the values of the Field, or the name of the branch if it is missing from one of them.
+	EHT, SHT string
+}
+
+func (d Discrepancy) String() string {
+	switch d.Kind {
+	case MissingFromEHT:
+		return fmt.Sprintf("%s: %q is missing from the EHT", d.Bankszerv, d.SHT)
+	case MissingFromSHT:
+		return fmt.Sprintf("%s: %q is missing from the SHT", d.Bankszerv, d.EHT)
+	}
+	return fmt.Sprintf("%s: %s differs: EHT=%q SHT=%q", d.Bankszerv, d.Field, d.EHT, d.SHT)
+}
+
+// CrossValidate returns the discrepancies between the EHT (GIRO) and the SHT (MNB) lists, sorted by Bankszerv:
+// the branches listed in only one of them, and the names and addresses differing.
+//
+// The fields are compared by Fold, ignoring the punctuation,
+// and the addresses only if both publications have them (the SHT has them only partially, see Capabilities).
+// Only the first record of each Bankszerv is compared (see WithDuplicates).
+func CrossValidate(eht, sht []Hitelezo) []Discrepancy {
+	shtM := make(map[string]Hitelezo, len(sht))
+	for _, h := range sht {
+		if _, ok := shtM[h.Bankszerv]; !ok && h.Bankszerv != "" {
+			shtM[h.Bankszerv] = h
+		}
+	}
+	var ds []Discrepancy
+	seen := make(map[string]struct{}, len(eht))
+	for _, e := range eht {
+		if _, ok := seen[e.Bankszerv]; ok || e.Bankszerv == "" {
+			continue
+		}
+		seen[e.Bankszerv] = struct{}{}
+		s, ok := shtM[e.Bankszerv]
+		if !ok {
+			ds = append(ds, Discrepancy{Bankszerv: e.Bankszerv, Kind: MissingFromSHT, EHT: e.Nev})
+			continue
+		}
+		delete(shtM, e.Bankszerv)
+		for _, f := range []struct {
+			field    string
+			eht, sht string
+		}{
+			{FieldNev, e.Nev, s.Nev}, {FieldIrszam, e.Irszam, s.Irszam}, {FieldCim, e.Cim, s.Cim},
+		} {
+			if f.field != FieldNev && (f.eht == "" || f.sht == "") {
+				continue
+			}
+			if comparisonKey(f.eht) != comparisonKey(f.sht) {
+				ds = append(ds, Discrepancy{Bankszerv: e.Bankszerv, Kind: Mismatch, Field: f.field, EHT: f.eht, SHT: f.sht})
+			}
+		}
+	}
+	for _, s := range shtM {
+		ds = append(ds, Discrepancy{Bankszerv: s.Bankszerv, Kind: MissingFromEHT, SHT: s.Nev})
+	}
+	slices.SortStableFunc(ds, func(a, b Discrepancy) int { return cmp.Compare(a.Bankszerv, b.Bankszerv) })
+	return ds
+}
+
+// comparisonKey returns the folded words of s, without the punctuation.
+func comparisonKey(s string) string {
+	return strings.Join(strings.FieldsFunc(Fold(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
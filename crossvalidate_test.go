@@ -0,0 +1,37 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCrossValidate(t *testing.T) {
+	eht := []Hitelezo{
+		{Bankszerv: "10002003", Nev: "Magyar Államkincstár", Irszam: "1139", Cim: "Budapest, Váci út 71."},
+		{Bankszerv: "10023002", Nev: "Magyar Államkincstár Dél-budapesti fiók", Irszam: "1117", Cim: "Budapest, Hengermalom út 1."},
+		{Bankszerv: "11773016", Nev: "OTP Bank Nyrt.", Irszam: "1051", Cim: "Budapest, Nádor u. 16."},
+	}
+	sht := []Hitelezo{
+		{Bankszerv: "11773016", Nev: "OTP BANK NYRT", Irszam: "1052", BIC: "OTPVHUHB"},
+		{Bankszerv: "10002003", Nev: "MAGYAR ÁLLAMKINCSTÁR", Cim: "Budapest Váci út 71"},
+		{Bankszerv: "12010855", Nev: "Raiffeisen Bank"},
+	}
+	want := []Discrepancy{
+		{Bankszerv: "10023002", Kind: MissingFromSHT, EHT: "Magyar Államkincstár Dél-budapesti fiók"},
+		{Bankszerv: "11773016", Kind: Mismatch, Field: FieldIrszam, EHT: "1051", SHT: "1052"},
+		{Bankszerv: "12010855", Kind: MissingFromEHT, SHT: "Raiffeisen Bank"},
+	}
+	if got := CrossValidate(eht, sht); !reflect.DeepEqual(got, want) {
+		t.Errorf("got\n%+v\nwanted\n%+v", got, want)
+	}
+	if got := CrossValidate(eht, eht); len(got) != 0 {
+		t.Errorf("got %+v for the same list", got)
+	}
+	if got, want := want[1].String(), `11773016: Irszam differs: EHT="1051" SHT="1052"`; got != want {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+}
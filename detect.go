@@ -0,0 +1,73 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// sniffLen is the length of the prefix DetectFormat looks at.
+const sniffLen = 1024
+
+var (
+	// oleSignature starts the OLE2 Compound File Binary files (XLS, encrypted XLSX).
+	oleSignature = []byte{0xd0, 0xcf, 0x11, 0xe0, 0xa1, 0xb1, 0x1a, 0xe1}
+	zipSignature = []byte("PK\x03\x04")
+	// odsMimetype is the first (stored) entry of the OpenDocument spreadsheets.
+	odsMimetype = []byte("mimetypeapplication/vnd.oasis.opendocument.spreadsheet")
+	utf8BOM     = []byte("\xef\xbb\xbf")
+)
+
+// DetectFormat returns the format of the contents of r by its first bytes,
+// or "" if it is none of the known formats.
+//
+// ZIP files are FormatXLSX, FormatODS, or FormatZIP if r has a Size method
+// and the archive has no spreadsheet in it (see Parse);
+// text is FormatHTML if it looks like HTML (in UTF-8 or the legacy code pages), FormatCSV otherwise.
+func DetectFormat(r io.ReaderAt) Format {
+	var a [sniffLen]byte
+	n, _ := r.ReadAt(a[:], 0)
+	if b := a[:n]; bytes.HasPrefix(b, zipSignature) {
+		return detectZIP(r, b)
+	}
+	return detectFormat(a[:n])
+}
+
+// detectFormat returns the format of the (non-ZIP) content starting with b.
+func detectFormat(b []byte) Format {
+	switch {
+	case bytes.HasPrefix(b, []byte("%PDF-")):
+		return FormatPDF
+	case bytes.HasPrefix(b, oleSignature):
+		return FormatXLS
+	}
+	b = bytes.TrimPrefix(b, utf8BOM)
+	if !isText(b) {
+		return ""
+	}
+	if strings.HasPrefix(http.DetectContentType(b), "text/html") ||
+		bytes.Contains(bytes.ToLower(b), []byte("<table")) {
+		return FormatHTML
+	}
+	return FormatCSV
+}
+
+// isText reports whether b is non-empty text: without NUL and control characters other than whitespace.
+//
+// The bytes above 0x7f are accepted, for the legacy code pages (see detectCharset).
+func isText(b []byte) bool {
+	if len(bytes.TrimSpace(b)) == 0 {
+		return false
+	}
+	for _, c := range b {
+		if c < 0x20 && c != '\t' && c != '\n' && c != '\r' && c != '\f' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,63 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDetectFormat(t *testing.T) {
+	for _, tc := range []struct {
+		Name, Content string
+		Want          Format
+	}{
+		{"pdf", "%PDF-1.7\n%\xe2\xe3\xcf\xd3\n", FormatPDF},
+		{"xls", "\xd0\xcf\x11\xe0\xa1\xb1\x1a\xe1\x00\x00", FormatXLS},
+		{"xlsx", "PK\x03\x04\x14\x00\x06\x00[Content_Types].xml", FormatXLSX},
+		{"ods", "PK\x03\x04\x14\x00\x00\x08\x00\x00mimetypeapplication/vnd.oasis.opendocument.spreadsheetPK", FormatODS},
+		{"html", "<!DOCTYPE html><html><body><table></table></body></html>", FormatHTML},
+		{"html-fragment", "\xef\xbb\xbf\r\n<TABLE><TR><TD>Bankszerv</TD></TR></TABLE>", FormatHTML},
+		{"html-latin2", "<html><body>M\xe1sik \xfat</body></html>", FormatHTML},
+		{"csv", "\"Bankszerv\",\"BIC\",\"Nev\",\"Irszam\",\"Cim\"\r\n", FormatCSV},
+		{"binary", "\x00\x01\x02\x03", ""},
+		{"empty", "", ""},
+		{"blank", " \r\n", ""},
+	} {
+		if got := DetectFormat(strings.NewReader(tc.Content)); got != tc.Want {
+			t.Errorf("%s: got %q, wanted %q", tc.Name, got, tc.Want)
+		}
+	}
+}
+
+func TestParseCSV(t *testing.T) {
+	want := []Hitelezo{
+		{Bankszerv: "10032000", Nev: "Magyar Államkincstár", Irszam: "1054", Cim: "Budapest, Hold u. 4."},
+		{Bankszerv: "11773016", Nev: "OTP Bank", Irszam: "1051", Cim: "Budapest, Nádor u. 16."},
+	}
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, want); err != nil {
+		t.Fatal(err)
+	}
+	var src Source
+	hs, err := Parse(context.Background(), &buf, WithSource(&src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if src.Format != FormatCSV {
+		t.Errorf("format: got %q, wanted %q", src.Format, FormatCSV)
+	}
+	if len(hs) != len(want) || hs[0].Bankszerv != want[0].Bankszerv || hs[1].Cim != want[1].Cim {
+		t.Errorf("got %+v, wanted %+v", hs, want)
+	}
+
+	_, err = Parse(context.Background(), strings.NewReader("PK\x03\x04\x14\x00\x00\x08\x00\x00mimetypeapplication/vnd.oasis.opendocument.spreadsheet"))
+	if !errors.Is(err, ErrFormatUnknown) {
+		t.Errorf("ods: got %+v, wanted ErrFormatUnknown", err)
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Directory is an index of Hitelezo records by Bankszerv.
+//
+// It is safe for concurrent use, and its contents can be refreshed while in use.
+type Directory struct {
+	snap atomic.Pointer[dirSnapshot]
+	afr  atomic.Pointer[afrIndex]
+	sepa atomic.Pointer[map[string]SEPAReachability]
+
+	// mu serializes the replacements with their notifications (so the events arrive in order),
+	// and guards subs.
+	mu   sync.Mutex
+	subs []chan ChangeEvent
+}
+
+type dirSnapshot struct {
+	records     []Hitelezo
+	byBankszerv map[string]int
+	// byCity and byCounty are keyed by the folded names
+	byCity, byCounty map[string][]int
+	hash             func() string
+	// loaded is the time of the load, effective is the EffectiveDate of the records,
+	// fetched is the time of their download (both zero if unknown)
+	loaded, effective, fetched time.Time
+}
+
+// NewDirectory returns a Directory of the given records.
+func NewDirectory(hs []Hitelezo) *Directory {
+	var d Directory
+	d.replace(hs)
+	return &d
+}
+
+func (d *Directory) replace(hs []Hitelezo) { d.replaceAt(hs, time.Time{}) }
+
+// replaceAt replaces the contents of d with the records effective from the date.
+func (d *Directory) replaceAt(hs []Hitelezo, effective time.Time) {
+	d.swap(hs, effective, time.Time{}, nil)
+}
+
+// swap replaces the contents of d with the records effective from the date, fetched at the given time,
+// emitting DirectorySwapped if o is not nil.
+func (d *Directory) swap(hs []Hitelezo, effective, fetched time.Time, o *options) {
+	snap := dirSnapshot{records: hs, byBankszerv: make(map[string]int, len(hs)),
+		loaded: time.Now(), effective: effective, fetched: fetched,
+		byCity: make(map[string][]int), byCounty: make(map[string][]int, len(Counties))}
+	for i, h := range hs {
+		if _, ok := snap.byBankszerv[h.Bankszerv]; !ok {
+			snap.byBankszerv[h.Bankszerv] = i
+		}
+		if city := Fold(h.City()); city != "" {
+			snap.byCity[city] = append(snap.byCity[city], i)
+		}
+		if county := h.County(); county != "" {
+			k := countyKey(county)
+			snap.byCounty[k] = append(snap.byCounty[k], i)
+		}
+	}
+	snap.hash = sync.OnceValue(func() string { return recordsHash(hs) })
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var n int
+	if old := d.snap.Swap(&snap); old != nil {
+		n = len(old.records)
+		d.notify(old, &snap)
+	}
+	if o != nil {
+		o.emit(DirectorySwapped{Old: n, New: len(hs)})
+	}
+}
+
+// Swap replaces the contents of d with the records atomically:
+// the lookups in progress finish with the old records, the next ones see the new ones.
+// The EffectiveDate is kept, and so is FetchedAt, unless set by WithFetched.
+//
+// The subscribers are notified (see Subscribe), and DirectorySwapped is emitted (see WithEvents).
+func (d *Directory) Swap(hs []Hitelezo, opts ...Option) {
+	o := newOptions(opts)
+	fetched := o.fetched
+	if fetched.IsZero() {
+		fetched = d.FetchedAt()
+	}
+	d.swap(hs, d.EffectiveDate(), fetched, o)
+}
+
+// WithFetched sets the time the records were downloaded, for Directory.Swap (see Directory.FetchedAt).
+func WithFetched(t time.Time) Option {
+	return func(o *options) { o.fetched = t }
+}
+
+// ChangeEvent is sent to the subscribers of a Directory when its contents has been replaced.
+type ChangeEvent struct {
+	// Old and New are the number of the records before and after.
+	Old, New int
+	// Hash is the new Hash of the Directory.
+	Hash string
+	// Changes are the differences of the records.
+	Changes Changes
+}
+
+// subscriberBuffer is the number of the ChangeEvents kept for a subscriber.
+const subscriberBuffer = 8
+
+// Subscribe returns a channel receiving a ChangeEvent after each replacement of the contents
+// (by Swap, Refresh or a Refresher), to invalidate the caches derived from the records.
+//
+// The events are not waited for: a subscriber lagging behind by more than a few events
+// misses the later ones, but can compare the Hash with the last one seen.
+// The channel is closed by Unsubscribe.
+func (d *Directory) Subscribe() <-chan ChangeEvent {
+	ch := make(chan ChangeEvent, subscriberBuffer)
+	d.mu.Lock()
+	d.subs = append(d.subs, ch)
+	d.mu.Unlock()
+	return ch
+}
+
+// Unsubscribe stops the events of Subscribe to ch, and closes it.
+func (d *Directory) Unsubscribe(ch <-chan ChangeEvent) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for i, sub := range d.subs {
+		if sub == ch {
+			d.subs = slices.Delete(d.subs, i, i+1)
+			close(sub)
+			return
+		}
+	}
+}
+
+// notify sends the ChangeEvent of the replacement of old by snap to the subscribers.
+//
+// d.mu must be held.
+func (d *Directory) notify(old, snap *dirSnapshot) {
+	if len(d.subs) == 0 {
+		return
+	}
+	ev := ChangeEvent{Old: len(old.records), New: len(snap.records), Hash: snap.hash(),
+		Changes: Diff(old.records, snap.records)}
+	for _, ch := range d.subs {
+		select {
+		case ch <- ev:
+		default:
+		}
+	}
+}
+
+// Hash returns the hex-encoded SHA-256 hash of the records,
+// changing with each change of the contents (such as for an ETag).
+func (d *Directory) Hash() string { return d.snap.Load().hash() }
+
+// recordsHash returns the hex-encoded SHA-256 hash of the length-prefixed fields of the records.
+func recordsHash(hs []Hitelezo) string {
+	h := sha256.New()
+	var a [binary.MaxVarintLen64]byte
+	for _, rec := range hs {
+		for _, s := range []string{rec.Bankszerv, rec.BIC, rec.Nev, rec.Irszam, rec.Cim, rec.SearchKey} {
+			h.Write(a[:binary.PutUvarint(a[:], uint64(len(s)))])
+			io.WriteString(h, s)
+		}
+		if rec.NonStdIrszam {
+			h.Write([]byte{1})
+		} else {
+			h.Write([]byte{0})
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Lookup returns the record of the 8-digit bankszerv.
+func (d *Directory) Lookup(bankszerv string) (Hitelezo, bool) {
+	snap := d.snap.Load()
+	if i, ok := snap.byBankszerv[bankszerv]; ok {
+		return snap.records[i], true
+	}
+	return Hitelezo{}, false
+}
+
+// Len returns the number of records.
+func (d *Directory) Len() int { return len(d.snap.Load().records) }
+
+// LoadedAt returns the time the records were loaded (by NewDirectory or the last refresh).
+func (d *Directory) LoadedAt() time.Time { return d.snap.Load().loaded }
+
+// EffectiveDate returns the date the records are effective from (see Source.EffectiveDate),
+// zero if unknown.
+func (d *Directory) EffectiveDate() time.Time { return d.snap.Load().effective }
+
+// FetchedAt returns the time the records were downloaded (by Refresh or a Refresher),
+// zero if unknown, as for the embedded or stored snapshots.
+func (d *Directory) FetchedAt() time.Time { return d.snap.Load().fetched }
+
+// All returns a copy of all the records.
+func (d *Directory) All() []Hitelezo { return slices.Clone(d.snap.Load().records) }
+
+// Refresh replaces the contents of d with the records returned by Parse(ctx, nil, opts...).
+//
+// On error, d is left intact.
+func (d *Directory) Refresh(ctx context.Context, opts ...Option) error {
+	o := newOptions(opts)
+	src := o.source
+	if src == nil {
+		src = new(Source)
+		opts = append(slices.Clip(opts), WithSource(src))
+	}
+	hs, err := Parse(ctx, nil, opts...)
+	if err != nil {
+		return err
+	}
+	d.swap(hs, src.EffectiveDate, time.Now(), o)
+	return nil
+}
+
+// RefreshInBackground starts Refresh in a new goroutine.
+//
+// The returned channel receives its result, then closed.
+func (d *Directory) RefreshInBackground(ctx context.Context, opts ...Option) <-chan error {
+	errCh := make(chan error, 1)
+	go func() {
+		defer close(errCh)
+		errCh <- d.Refresh(ctx, opts...)
+	}()
+	return errCh
+}
@@ -0,0 +1,115 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLoadEmbedded(t *testing.T) {
+	d := LoadEmbedded()
+	if d.Len() < 1000 {
+		t.Errorf("got %d records, wanted at least 1000", d.Len())
+	}
+	h, ok := d.Lookup("10002003")
+	if !ok {
+		t.Fatal("10002003 not found")
+	}
+	if h.Irszam != "1139" {
+		t.Errorf("got %+v", h)
+	}
+	if _, ok := d.Lookup("00000000"); ok {
+		t.Error("00000000 found")
+	}
+
+	d.replace([]Hitelezo{{Bankszerv: "11773016", Nev: "OTP Bank"}})
+	if _, ok := d.Lookup("10002003"); ok || d.Len() != 1 {
+		t.Errorf("replace failed: %+v", d.All())
+	}
+}
+
+func TestFallback(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel() // no network access
+	if _, err := Parse(ctx, nil); err == nil {
+		t.Fatal("wanted error without fallback")
+	}
+	var rep Report
+	hs, err := Parse(ctx, nil, WithFallback(), WithReport(&rep))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hs) != LoadEmbedded().Len() || len(rep.Warnings) != 1 {
+		t.Errorf("got %d records, %d warnings", len(hs), len(rep.Warnings))
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	var d Directory
+	effective := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	d.replaceAt([]Hitelezo{{Bankszerv: "10002003", Nev: "MÁK"}}, effective)
+	ch := d.Subscribe()
+	var events []Event
+	d.Swap([]Hitelezo{{Bankszerv: "10002003", Nev: "Magyar Államkincstár"}, {Bankszerv: "11773016", Nev: "OTP Bank"}},
+		WithEvents(func(ev Event) { events = append(events, ev) }))
+	if h, ok := d.Lookup("11773016"); !ok || h.Nev != "OTP Bank" {
+		t.Errorf("got %+v, %t", h, ok)
+	}
+	if !d.EffectiveDate().Equal(effective) {
+		t.Errorf("got effective date %v, wanted %v", d.EffectiveDate(), effective)
+	}
+	if len(events) != 1 || events[0] != (DirectorySwapped{Old: 1, New: 2}) {
+		t.Errorf("got events %#v", events)
+	}
+	select {
+	case ev := <-ch:
+		if ev.Old != 1 || ev.New != 2 || ev.Hash != d.Hash() ||
+			len(ev.Changes.Added) != 1 || len(ev.Changes.Modified) != 1 {
+			t.Errorf("got %+v", ev)
+		}
+	default:
+		t.Fatal("no event")
+	}
+
+	for i := 0; i < 2*subscriberBuffer; i++ { // not blocked by the lagging subscriber
+		d.Swap(nil)
+	}
+	d.Unsubscribe(ch)
+	var n int
+	for range ch {
+		n++
+	}
+	if n != subscriberBuffer {
+		t.Errorf("got %d events, wanted %d", n, subscriberBuffer)
+	}
+}
+
+func TestSubscribeOrder(t *testing.T) {
+	d := NewDirectory(nil)
+	ch := d.Subscribe()
+	var wg sync.WaitGroup
+	for i := 1; i < subscriberBuffer; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			d.Swap(make([]Hitelezo, i))
+		}()
+	}
+	wg.Wait()
+	d.Unsubscribe(ch)
+	var last int
+	for ev := range ch {
+		if ev.Old != last {
+			t.Errorf("got %+v after %d records", ev, last)
+		}
+		last = ev.New
+	}
+	if last != d.Len() {
+		t.Errorf("the last event is of %d records, the Directory has %d", last, d.Len())
+	}
+}
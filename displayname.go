@@ -0,0 +1,42 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+// DisplayName returns the name of a record to display.
+type DisplayName func(Hitelezo) string
+
+// IdentityDisplayName is the default DisplayName: the Nev of the record, as is.
+func IdentityDisplayName(h Hitelezo) string { return h.Nev }
+
+// MapDisplayName returns a DisplayName translating the names found in labels,
+// keeping the others as is.
+func MapDisplayName(labels map[string]string) DisplayName {
+	return func(h Hitelezo) string {
+		if s, ok := labels[h.Nev]; ok {
+			return s
+		}
+		return h.Nev
+	}
+}
+
+// EnglishLabels is an example mapping table of the names to English labels, for MapDisplayName.
+var EnglishLabels = map[string]string{
+	"Magyar Államkincstár":                              "Hungarian State Treasury",
+	"Magyar Nemzeti Bank":                               "Central Bank of Hungary",
+	"Magyar Államkincstár. értékp.-pénztár":             "Hungarian State Treasury, securities desk",
+	"Magyar Fejlesztési Bank Zrt.":                      "Hungarian Development Bank",
+	"Magyar Export-Import Bank Zrt.":                    "Hungarian Export-Import Bank",
+	"Nemzeti Adó- és Vámhivatal":                        "National Tax and Customs Administration",
+	"Magyar Posta Zrt.":                                 "Hungarian Post",
+	"Központi Elszámolóház és Értéktár (Budapest) Zrt.": "KELER Central Securities Depository",
+}
+
+// WithDisplayName sets the DisplayName the exporters write in place of Nev
+// (default is IdentityDisplayName).
+//
+// The records themselves are not changed.
+func WithDisplayName(name DisplayName) Option {
+	return func(o *options) { o.displayName = name }
+}
@@ -0,0 +1,34 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestDisplayName(t *testing.T) {
+	hs := []Hitelezo{
+		{Bankszerv: "10002003", Nev: "Magyar Államkincstár", Irszam: "1139", Cim: "Budapest, Váci út 71."},
+		{Bankszerv: "11773016", Nev: "OTP Bank Nyrt.", Irszam: "1051", Cim: "Budapest"},
+	}
+	if got := IdentityDisplayName(hs[0]); got != hs[0].Nev {
+		t.Errorf("identity: got %q", got)
+	}
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, hs, WithDisplayName(MapDisplayName(EnglishLabels))); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ReadCSV(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got[0].Nev != "Hungarian State Treasury" || got[1].Nev != "OTP Bank Nyrt." {
+		t.Errorf("got %+v", got)
+	}
+	if hs[0].Nev != "Magyar Államkincstár" {
+		t.Errorf("record changed: %+v", hs[0])
+	}
+}
@@ -0,0 +1,14 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package giro downloads and parses the list of the Hungarian bank branches (GIRO bankszerv),
+// and serves it as a Directory.
+//
+// The record (Hitelezo) and the validation of the account numbers are in package model,
+// depending on the standard library only: import it to validate account numbers
+// without excelize, xls and the PDF extractors. This package re-exports them as aliases.
+//
+// The fetching (discovery, download, cache) and the parsing of the formats stay in this package,
+// as they share the Option, Source, Report and Event plumbing; there are no fetch and parse packages.
+package giro
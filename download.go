@@ -0,0 +1,180 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrIncomplete is returned by DownloadToFile when less or more bytes are received than the Content-Length.
+	ErrIncomplete = errors.New("incomplete download")
+	// ErrHashMismatch is returned by DownloadToFile when the contents do not match WithExpectedSHA256,
+	// and by the tabula strategy of ParsePDF when the jar does not match its hash (see WithTabulaJar).
+	ErrHashMismatch = errors.New("SHA-256 mismatch")
+	// ErrUnexpectedContent is returned by DownloadFile and DownloadToFile when an HTML page
+	// (such as a maintenance page, served with 200 OK) is received instead of the spreadsheet or PDF.
+	ErrUnexpectedContent = errors.New("unexpected content")
+)
+
+// expectedFormat returns the format expected by the name of the downloaded file, or "" if unknown.
+func expectedFormat(name string) Format {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".xlsx":
+		return FormatXLSX
+	case ".xls":
+		return FormatXLS
+	case ".pdf":
+		return FormatPDF
+	case ".zip":
+		return FormatZIP
+	}
+	return ""
+}
+
+var rTitle = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+
+// checkContent returns the body, or ErrUnexpectedContent if it is HTML where a binary format is expected
+// by the file name or the URL.
+//
+// The returned reader must be used instead of body, as its first bytes are read for the sniffing.
+func checkContent(resp *http.Response, filename string, body io.Reader) (io.Reader, error) {
+	want := expectedFormat(filename)
+	if want == "" {
+		want = expectedFormat(resp.Request.URL.Path)
+	}
+	if want == "" {
+		return body, nil
+	}
+	br := bufio.NewReaderSize(body, sniffLen)
+	b, err := br.Peek(sniffLen)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, bufio.ErrBufferFull) {
+		return br, err
+	}
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if got := detectFormat(b); got == FormatHTML || (mediaType == "text/html" && got != "") {
+		msg := mediaType
+		if m := rTitle.FindSubmatch(b); m != nil {
+			msg += " " + strconv.Quote(strings.TrimSpace(string(m[1])))
+		}
+		return br, fmt.Errorf("%s: got %s instead of %s: %w", resp.Request.URL, msg, want, ErrUnexpectedContent)
+	}
+	return br, nil
+}
+
+// WithExpectedSHA256 makes DownloadToFile verify the hex-encoded SHA-256 hash of the downloaded file.
+func WithExpectedSHA256(hash string) Option {
+	return func(o *options) { o.expectedSHA256 = strings.ToLower(hash) }
+}
+
+// DownloadToFile downloads dlURL into destDir, returning the path of the file.
+//
+// The file is named by the Content-Disposition header (or the URL path),
+// and it is written to a temporary file, synced, verified against the Content-Length
+// (and WithExpectedSHA256), and renamed into place only if complete,
+// so no partial file is left behind.
+//
+// The file is added to the Archive, too (see WithArchive).
+func DownloadToFile(ctx context.Context, dlURL, destDir string, opts ...Option) (_ string, err error) {
+	o := newOptions(opts)
+	ctx, end := o.span(ctx, "DownloadToFile", slog.String("url", dlURL))
+	defer func() { err = inStage(ErrDownload, err); end(err) }()
+	req, err := http.NewRequest("GET", dlURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", dlURL, err)
+	}
+	resp, err := o.do(ctx, o.client(), req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode > 299 {
+		return "", fmt.Errorf("%s: %s", dlURL, resp.Status)
+	}
+	filename := dispositionFilename(resp)
+	o.source.setResponse(resp, filename)
+	// no directories from the header or the URL
+	if filename = filepath.Base(filepath.Clean(filename)); filename == "." || filename == string(filepath.Separator) {
+		filename = path.Base(resp.Request.URL.Path)
+	}
+	if filename == "." || filename == "/" || filename == ".." {
+		filename = "download"
+	}
+	var body io.Reader = resp.Body
+	if o.progress != nil {
+		body = &progressReader{ReadCloser: resp.Body, progress: o.progress, total: resp.ContentLength}
+	}
+	if body, err = checkContent(resp, filename, body); err != nil {
+		return "", err
+	}
+
+	tmp, err := os.CreateTemp(destDir, ".giro-*")
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(tmp, h), body)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", dlURL, err)
+	}
+	if resp.ContentLength >= 0 && n != resp.ContentLength {
+		return "", fmt.Errorf("%s: got %d bytes of %d: %w", dlURL, n, resp.ContentLength, ErrIncomplete)
+	}
+	hash := hex.EncodeToString(h.Sum(nil))
+	if o.expectedSHA256 != "" && hash != o.expectedSHA256 {
+		return "", fmt.Errorf("%s: got %s, wanted %s: %w", dlURL, hash, o.expectedSHA256, ErrHashMismatch)
+	}
+	if err = tmp.Sync(); err != nil {
+		return "", err
+	}
+	if o.archive != nil {
+		if _, err = tmp.Seek(0, io.SeekStart); err != nil {
+			return "", err
+		}
+		lastModified, _ := http.ParseTime(resp.Header.Get("Last-Modified"))
+		if _, err = o.archive.Add(tmp, ArchiveEntry{
+			URL: resp.Request.URL.String(), Filename: filename, LastModified: lastModified,
+		}); err != nil {
+			return "", err
+		}
+	}
+	if err = tmp.Close(); err != nil {
+		return "", err
+	}
+	dest := filepath.Join(destDir, filename)
+	if err = os.Rename(tmp.Name(), dest); err != nil {
+		return "", err
+	}
+	if o.source != nil {
+		o.source.SHA256, o.source.ContentLength = hash, n
+	}
+	// make the rename durable - not supported everywhere
+	if dir, err := os.Open(destDir); err == nil {
+		_ = dir.Sync()
+		dir.Close()
+	}
+	return dest, nil
+}
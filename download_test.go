@@ -0,0 +1,132 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rogpeppe/retry"
+)
+
+func TestDownloadToFile(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/eht":
+			w.Header().Set("Content-Disposition", `attachment; filename="../EHT_20260101.xlsx"`)
+			io.WriteString(w, "data")
+		case "/short":
+			w.Header().Set("Content-Length", "10")
+			io.WriteString(w, "data")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	noRetry := WithRetry(retry.Strategy{MaxCount: 1})
+	dir := t.TempDir()
+	var src Source
+	const dataSHA256 = "3a6eb0790f39ac87c94f3856b2dd2c5d110e6811602261a9a923d3bb23adc8b7"
+	fn, err := DownloadToFile(ctx, srv.URL+"/eht", dir, noRetry, WithSource(&src), WithExpectedSHA256(dataSHA256))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fn != filepath.Join(dir, "EHT_20260101.xlsx") || src.SHA256 != dataSHA256 || src.ContentLength != 4 {
+		t.Errorf("got %q, %+v", fn, src)
+	}
+	if b, err := os.ReadFile(fn); err != nil || string(b) != "data" {
+		t.Errorf("got %q, %+v", b, err)
+	}
+
+	if _, err = DownloadToFile(ctx, srv.URL+"/eht", dir, noRetry, WithExpectedSHA256("00")); !errors.Is(err, ErrHashMismatch) {
+		t.Errorf("got %+v, wanted ErrHashMismatch", err)
+	}
+	if _, err = DownloadToFile(ctx, srv.URL+"/short", dir, noRetry); err == nil {
+		t.Error("short: wanted error")
+	}
+	if _, err = DownloadToFile(ctx, srv.URL+"/missing", dir, noRetry); err == nil {
+		t.Error("missing: wanted error")
+	}
+	des, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(des) != 1 {
+		t.Errorf("partial files left: %v", des)
+	}
+}
+
+func TestUnexpectedContent(t *testing.T) {
+	const page = "<!DOCTYPE html><html><head><title>Karbantartás</title></head><body>Hamarosan visszatérünk.</body></html>"
+	var buf bytes.Buffer
+	if err := WriteXLSX(&buf, []Hitelezo{
+		{Bankszerv: "10002003", Nev: "Magyar Államkincstár", Irszam: "1139", Cim: "Budapest, Váci út 71."},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/maintenance.xlsx":
+			io.WriteString(w, page)
+		case "/page":
+			w.Header().Set("Content-Disposition", `attachment; filename="EHT_20260101.pdf"`)
+			io.WriteString(w, page)
+		case "/lista.html":
+			io.WriteString(w, page)
+		default:
+			w.Write(buf.Bytes())
+		}
+	}))
+	defer srv.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	noRetry := WithRetry(retry.Strategy{MaxCount: 1})
+
+	for _, path := range []string{"/maintenance.xlsx", "/page"} {
+		_, rc, err := DownloadFile(ctx, srv.URL+path, noRetry)
+		if !errors.Is(err, ErrUnexpectedContent) || !errors.Is(err, ErrDownload) {
+			t.Errorf("%s: got %+v, wanted ErrUnexpectedContent", path, err)
+		} else if !strings.Contains(err.Error(), "Karbantartás") {
+			t.Errorf("%s: no title in %q", path, err)
+		}
+		if rc != nil {
+			rc.Close()
+		}
+		if _, err = DownloadToFile(ctx, srv.URL+path, t.TempDir(), noRetry); !errors.Is(err, ErrUnexpectedContent) {
+			t.Errorf("%s: got %+v, wanted ErrUnexpectedContent", path, err)
+		}
+	}
+	_, rc, err := DownloadFile(ctx, srv.URL+"/lista.html", noRetry)
+	if err != nil {
+		t.Fatalf("HTML expected: %+v", err)
+	}
+	if b, _ := io.ReadAll(rc); string(b) != page {
+		t.Errorf("got %q, wanted %q", b, page)
+	}
+	rc.Close()
+
+	maintenance := Upstream{Name: "maintenance", URL: srv.URL + "/maintenance.xlsx"}
+	mirror := Upstream{Name: "mirror", URL: srv.URL + "/sht.xlsx"}
+	var src Source
+	hs, err := Parse(ctx, nil, noRetry, WithSource(&src), WithUpstreams(maintenance, mirror))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hs) != 1 || src.Upstream != "mirror" {
+		t.Errorf("got %+v from %+v", hs, src)
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"errors"
+	"fmt"
+)
+
+// DuplicatePolicy tells what Parse does with the records of the same Bankszerv.
+type DuplicatePolicy uint8
+
+const (
+	// KeepAll keeps all the records, without checking (the default).
+	KeepAll = DuplicatePolicy(iota)
+	// KeepFirst keeps the first record of each Bankszerv.
+	KeepFirst
+	// KeepLast keeps the last record of each Bankszerv, at the place of the first.
+	KeepLast
+	// ReportError keeps all the records, but Parse returns the duplicates as error.
+	ReportError
+	// Merge keeps the first record of each Bankszerv,
+	// with its empty fields filled from the later ones.
+	Merge
+)
+
+// ErrDuplicate is the error reported for a Bankszerv found more than once.
+var ErrDuplicate = errors.New("duplicate Bankszerv")
+
+// DuplicateError is reported (see WithReport) for each record
+// with the Bankszerv of an earlier one, unless the policy is KeepAll.
+type DuplicateError struct {
+	First, Duplicate Hitelezo
+}
+
+func (e *DuplicateError) Error() string {
+	return fmt.Sprintf("%s: %s and %s", e.First.Bankszerv, e.First, e.Duplicate)
+}
+func (e *DuplicateError) Unwrap() error { return ErrDuplicate }
+
+// WithDuplicates sets the DuplicatePolicy of Parse.
+func WithDuplicates(policy DuplicatePolicy) Option {
+	return func(o *options) { o.duplicates = policy }
+}
+
+// dedup applies the DuplicatePolicy to hs (and the origins),
+// returning the duplicates as error with ReportError.
+func (o *options) dedup(hs []Hitelezo) ([]Hitelezo, error) {
+	if o.duplicates == KeepAll {
+		return hs, nil
+	}
+	var origins []RowOrigin
+	if o.origins != nil && len(*o.origins) == len(hs) {
+		origins = *o.origins
+	}
+	var errs []error
+	seen := make(map[string]int, len(hs))
+	j := 0
+	for i, h := range hs {
+		k, ok := seen[h.Bankszerv]
+		if !ok {
+			seen[h.Bankszerv] = j
+			hs[j] = h
+			if origins != nil {
+				origins[j] = origins[i]
+			}
+			j++
+			continue
+		}
+		err := &DuplicateError{First: hs[k], Duplicate: h}
+		o.warn(err)
+		switch o.duplicates {
+		case KeepLast:
+			hs[k] = h
+			if origins != nil {
+				origins[k] = origins[i]
+			}
+		case Merge:
+			hs[k] = mergeHitelezo(hs[k], h)
+		case ReportError:
+			errs = append(errs, err)
+			hs[j] = h
+			if origins != nil {
+				origins[j] = origins[i]
+			}
+			j++
+		}
+	}
+	if origins != nil {
+		*o.origins = origins[:j]
+	}
+	return hs[:j], errors.Join(errs...)
+}
+
+// mergeHitelezo returns a, with its empty fields filled from b.
+func mergeHitelezo(a, b Hitelezo) Hitelezo {
+	for _, f := range []struct{ dst, src *string }{
+		{&a.BIC, &b.BIC}, {&a.Nev, &b.Nev}, {&a.Cim, &b.Cim},
+	} {
+		if *f.dst == "" {
+			*f.dst = *f.src
+		}
+	}
+	if a.Irszam == "" {
+		a.Irszam, a.NonStdIrszam = b.Irszam, b.NonStdIrszam
+	}
+	if a.SearchKey != "" {
+		a.SearchKey = Fold(a.Nev + " " + a.Cim)
+	}
+	return a
+}
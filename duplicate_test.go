@@ -0,0 +1,55 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestDuplicates(t *testing.T) {
+	hs := []Hitelezo{
+		{Bankszerv: "10002003", Nev: "Magyar Államkincstár", Irszam: "1139", Cim: "Budapest, Váci út 71."},
+		{Bankszerv: "11773016", Nev: "OTP Bank", Irszam: "1051", Cim: "Budapest"},
+		{Bankszerv: "10002003", BIC: "HUSTHUHB", Nev: "MÁK", Irszam: "1054", Cim: "Budapest, Hold u. 4."},
+	}
+	var buf bytes.Buffer
+	if err := WriteXLSX(&buf, hs); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	for _, tc := range []struct {
+		Policy  DuplicatePolicy
+		Len     int
+		Cim     string
+		BIC     string
+		WantErr bool
+	}{
+		{Policy: KeepAll, Len: 3, Cim: hs[0].Cim},
+		{Policy: KeepFirst, Len: 2, Cim: hs[0].Cim},
+		{Policy: KeepLast, Len: 2, Cim: hs[2].Cim, BIC: "HUSTHUHB"},
+		{Policy: Merge, Len: 2, Cim: hs[0].Cim, BIC: "HUSTHUHB"},
+		{Policy: ReportError, Len: 3, Cim: hs[0].Cim, WantErr: true},
+	} {
+		var rep Report
+		var origins []RowOrigin
+		got, err := Parse(ctx, bytes.NewReader(buf.Bytes()), WithDuplicates(tc.Policy), WithReport(&rep), withOrigins(&origins))
+		if (err != nil) != tc.WantErr || (err != nil && !errors.Is(err, ErrDuplicate)) {
+			t.Errorf("%d: got error %+v", tc.Policy, err)
+		}
+		if len(got) != tc.Len || got[0].Cim != tc.Cim || got[0].BIC != tc.BIC || len(origins) != len(got) {
+			t.Errorf("%d: got %+v (origins %+v)", tc.Policy, got, origins)
+		}
+		if tc.Policy == KeepLast && origins[0].Row != 4 {
+			t.Errorf("KeepLast: got origin %+v", origins[0])
+		}
+		var de *DuplicateError
+		if tc.Policy != KeepAll && (len(rep.Warnings) != 1 || !errors.As(rep.Warnings[0], &de) || de.Duplicate.Cim != hs[2].Cim) {
+			t.Errorf("%d: got warnings %+v", tc.Policy, rep.Warnings)
+		}
+	}
+}
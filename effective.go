@@ -0,0 +1,90 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"path"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// effectiveDatePatterns are the file name patterns containing the effective date,
+// with the year, month, day submatch indexes.
+var effectiveDatePatterns = []struct {
+	rx      *regexp.Regexp
+	y, m, d int
+}{
+	{regexp.MustCompile(`EHT_([0-9]{4})[_-]?([0-9]{2})[_-]?([0-9]{2})\b`), 1, 2, 3},
+	{regexp.MustCompile(`EHT_(2[0-9])([0-9]{2})([0-9]{2})\b`), 1, 2, 3},
+	{regexp.MustCompile(`AVT_([0-9]{2})_([0-9]{2})_(2[0-9]{3})\b`), 3, 2, 1},
+}
+
+// EffectiveDate returns the date from which the list is valid,
+// parsed from its file name (EHT_YYYYMMDD, EHT_YYYY-MM-DD, EHT_YYMMDD or AVT_DD_MM_YYYY).
+func EffectiveDate(filename string) (time.Time, bool) {
+	bn := path.Base(filename)
+	for _, p := range effectiveDatePatterns {
+		m := p.rx.FindStringSubmatch(bn)
+		if m == nil {
+			continue
+		}
+		y, _ := strconv.Atoi(m[p.y])
+		if y < 100 {
+			y += 2000
+		}
+		if t, ok := makeDate(y, m[p.m], m[p.d]); ok {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// rTitleDate matches the date on the title page of the EHT,
+// such as "2021.04.01-től érvényes Egyszerűsített Hitelesítő Tábla".
+var rTitleDate = regexp.MustCompile(`(2[0-9]{3})\. ?([0-9]{2})\. ?([0-9]{2})\.? ?-(?:tól|től|tol|tõl)`)
+
+// titleDate returns the effective date from the title of the list.
+func titleDate(s string) (time.Time, bool) {
+	m := rTitleDate.FindStringSubmatch(s)
+	if m == nil {
+		return time.Time{}, false
+	}
+	y, _ := strconv.Atoi(m[1])
+	return makeDate(y, m[2], m[3])
+}
+
+func makeDate(y int, month, day string) (time.Time, bool) {
+	m, _ := strconv.Atoi(month)
+	d, _ := strconv.Atoi(day)
+	if !(1 <= m && m <= 12 && 1 <= d && d <= 31) {
+		return time.Time{}, false
+	}
+	t := time.Date(y, time.Month(m), d, 0, 0, 0, 0, time.UTC)
+	if t.Day() != d { // such as Feb 30
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// setFilenameDate sets EffectiveDate from the name, if not set yet.
+func (src *Source) setFilenameDate(name string) {
+	if src == nil || !src.EffectiveDate.IsZero() {
+		return
+	}
+	if t, ok := EffectiveDate(name); ok {
+		src.EffectiveDate = t
+	}
+}
+
+// setTitleDate sets EffectiveDate from the title of the list, if not set yet.
+func (src *Source) setTitleDate(s string) {
+	if src == nil || !src.EffectiveDate.IsZero() {
+		return
+	}
+	if t, ok := titleDate(s); ok {
+		src.EffectiveDate = t
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestEffectiveDate(t *testing.T) {
+	want := time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC)
+	for _, fn := range []string{
+		"EHT_20210401.pdf", "EHT_2021-04-01.xlsx", "EHT_2021_04_01.xls", "EHT_210401.pdf",
+		"https://www.giro.hu/documents/EHT_20210401.pdf", "AVT_01_04_2021.xlsx",
+	} {
+		if got, ok := EffectiveDate(fn); !ok || !got.Equal(want) {
+			t.Errorf("%q: got %v, %t", fn, got, ok)
+		}
+	}
+	for _, fn := range []string{"sht.xlsx", "EHT_20211301.pdf", "EHT_20210231.pdf"} {
+		if got, ok := EffectiveDate(fn); ok {
+			t.Errorf("%q: got %v", fn, got)
+		}
+	}
+
+	fh, err := os.Open(filepath.Join("testdata", "EHT_20210401.txt"))
+	if err != nil {
+		t.Skip(err)
+	}
+	defer fh.Close()
+	o := newOptions([]Option{WithSource(&Source{})})
+	if _, err := parseTXT(context.Background(), fh, o); err != nil {
+		t.Fatal(err)
+	}
+	if got := o.source.EffectiveDate; !got.Equal(want) {
+		t.Errorf("title date: got %v, wanted %v", got, want)
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+//go:generate go run gen_snapshot.go -o snapshot.json.gz
+
+import (
+	"bytes"
+	"compress/gzip"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// snapshotGz is a gzip-compressed JSON array of the Hitelezo records,
+// bundled for offline use: currently the EHT effective from 2021-04-01,
+// refresh it with "go generate".
+//
+//go:embed snapshot.json.gz
+var snapshotGz []byte
+
+var embeddedRecords = sync.OnceValues(func() ([]Hitelezo, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(snapshotGz))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	var hs []Hitelezo
+	err = json.NewDecoder(zr).Decode(&hs)
+	return hs, err
+})
+
+// LoadEmbedded returns a Directory of the snapshot bundled into the package,
+// without touching the disk or the network.
+//
+// The snapshot may be outdated: call Directory.RefreshInBackground to update it.
+func LoadEmbedded() *Directory {
+	return NewDirectory(Fallback())
+}
+
+// Fallback returns the records of the snapshot bundled into the package.
+func Fallback() []Hitelezo {
+	hs, err := embeddedRecords()
+	if err != nil {
+		// the embedded data is generated, thus always valid
+		panic(err)
+	}
+	return slices.Clone(hs)
+}
+
+// WithFallback makes Parse(ctx, nil) return the bundled snapshot (see Fallback)
+// when the download fails, instead of the error.
+//
+// The download error is reported as a warning (see WithReport).
+func WithFallback() Option {
+	return func(o *options) { o.fallback = true }
+}
+
+// fallbackFor returns the bundled snapshot if enabled, err otherwise.
+func (o *options) fallbackFor(err error) ([]Hitelezo, error) {
+	if !o.fallback {
+		return nil, err
+	}
+	o.warn(fmt.Errorf("using the bundled snapshot: %w", err))
+	hs := Fallback()
+	o.parsed(hs, nil)
+	return hs, nil
+}
@@ -0,0 +1,141 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/UNO-SOFT/filecache"
+	"github.com/rogpeppe/retry"
+	"golang.org/x/sync/errgroup"
+)
+
+// EnrichmentCache is a persistent cache of the enrichment results,
+// keyed by the hash of the enrichment name and the lookup key.
+type EnrichmentCache interface {
+	Get(key [32]byte) ([]byte, bool)
+	Put(key [32]byte, value []byte) error
+}
+
+// OpenEnrichmentCache opens (creates) a file cache in dir.
+func OpenEnrichmentCache(dir string) (EnrichmentCache, error) {
+	cache, err := newOptions([]Option{WithCacheDir(dir)}).openCache()
+	if err != nil {
+		return nil, err
+	}
+	return fileEnrichmentCache{cache}, nil
+}
+
+type fileEnrichmentCache struct{ *filecache.Cache }
+
+func (c fileEnrichmentCache) Get(key [32]byte) ([]byte, bool) {
+	b, _, err := c.Cache.GetBytes(filecache.ActionID(key))
+	return b, err == nil
+}
+func (c fileEnrichmentCache) Put(key [32]byte, value []byte) error {
+	_, _, err := c.Cache.Put(filecache.ActionID(key), bytes.NewReader(value))
+	return err
+}
+
+// Enrichment is a per-record external lookup (geocoding, LEI, company registry),
+// run by a limited number of workers, with retries and an optional persistent cache.
+type Enrichment[T any] struct {
+	// Name distinguishes the cached results of the different enrichments.
+	Name string
+	// Key returns the input of the lookup for the record (default is the Bankszerv).
+	// Records with the same key are looked up only once, records with an empty key are skipped.
+	Key func(Hitelezo) string
+	// Lookup does the external lookup.
+	Lookup func(ctx context.Context, key string) (T, error)
+	// Concurrency is the number of parallel lookups (default is 4).
+	Concurrency int
+	// Retry is the retry strategy of the failed lookups (default is DefaultRetry).
+	Retry *retry.Strategy
+	// Cache stores the results (as JSON), if not nil.
+	Cache EnrichmentCache
+}
+
+// Run looks up each record, and returns the results in the order of the records.
+//
+// Failed lookups leave the zero value in their place, their errors are returned joined.
+func (e *Enrichment[T]) Run(ctx context.Context, hs []Hitelezo) ([]T, error) {
+	keyOf := e.Key
+	if keyOf == nil {
+		keyOf = func(h Hitelezo) string { return h.Bankszerv }
+	}
+	strategy := e.Retry
+	if strategy == nil {
+		strategy = &DefaultRetry
+	}
+	concurrency := e.Concurrency
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+
+	keys := make(map[string][]int)
+	for i, h := range hs {
+		if k := keyOf(h); k != "" {
+			keys[k] = append(keys[k], i)
+		}
+	}
+	results := make([]T, len(hs))
+	var mu sync.Mutex
+	var errs []error
+	grp, grpCtx := errgroup.WithContext(ctx)
+	grp.SetLimit(concurrency)
+	for k, idx := range keys {
+		grp.Go(func() error {
+			v, err := e.lookup(grpCtx, strategy, k)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s %q: %w", e.Name, k, err))
+				return nil
+			}
+			for _, i := range idx {
+				results[i] = v
+			}
+			return nil
+		})
+	}
+	_ = grp.Wait()
+	if err := ctx.Err(); err != nil {
+		errs = append(errs, err)
+	}
+	return results, errors.Join(errs...)
+}
+
+// lookup returns the cached result for key, or looks it up with retries.
+func (e *Enrichment[T]) lookup(ctx context.Context, strategy *retry.Strategy, key string) (T, error) {
+	var v T
+	var cacheKey [32]byte
+	if e.Cache != nil {
+		cacheKey = sha256.Sum256([]byte(e.Name + "\x00" + key))
+		if b, ok := e.Cache.Get(cacheKey); ok && json.Unmarshal(b, &v) == nil {
+			return v, nil
+		}
+	}
+	var err error
+	for iter := strategy.Start(); ; {
+		if v, err = e.Lookup(ctx, key); err == nil || ctx.Err() != nil || !iter.Next(ctx.Done()) {
+			break
+		}
+	}
+	if err != nil {
+		return v, err
+	}
+	if e.Cache != nil {
+		if b, mErr := json.Marshal(v); mErr == nil {
+			_ = e.Cache.Put(cacheKey, b)
+		}
+	}
+	return v, ctx.Err()
+}
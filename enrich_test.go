@@ -0,0 +1,77 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rogpeppe/retry"
+)
+
+type mapCache struct {
+	mu sync.Mutex
+	m  map[[32]byte][]byte
+}
+
+func (c *mapCache) Get(key [32]byte) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	b, ok := c.m[key]
+	return b, ok
+}
+func (c *mapCache) Put(key [32]byte, value []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.m[key] = value
+	return nil
+}
+
+func TestEnrichment(t *testing.T) {
+	hs := []Hitelezo{
+		{Bankszerv: "11773016", Nev: "OTP", Cim: "Budapest"},
+		{Bankszerv: "10002003", Nev: "MÁK", Cim: "Budapest"},
+		{Bankszerv: "50400113", Nev: "Tksz.", Cim: "Paks"},
+		{Bankszerv: "19000002", Nev: "Hiba", Cim: "Sehol"},
+	}
+	var calls atomic.Int32
+	e := Enrichment[string]{
+		Name: "geo",
+		Key:  func(h Hitelezo) string { return h.Cim },
+		Lookup: func(ctx context.Context, city string) (string, error) {
+			calls.Add(1)
+			if city == "Sehol" {
+				return "", errors.New("not found")
+			}
+			return "HU-" + city, nil
+		},
+		Retry: &retry.Strategy{Delay: time.Millisecond, MaxCount: 2},
+		Cache: &mapCache{m: make(map[[32]byte][]byte)},
+	}
+	ctx := context.Background()
+	got, err := e.Run(ctx, hs)
+	if err == nil {
+		t.Error("wanted error for Sehol")
+	}
+	if want := []string{"HU-Budapest", "HU-Budapest", "HU-Paks", ""}; len(got) != len(want) ||
+		got[0] != want[0] || got[1] != want[1] || got[2] != want[2] || got[3] != want[3] {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+	if n := calls.Load(); n != 4 { // Budapest, Paks, Sehol twice
+		t.Errorf("got %d calls, wanted 4", n)
+	}
+
+	calls.Store(0)
+	if got, _ = e.Run(ctx, hs[:3]); got[2] != "HU-Paks" {
+		t.Errorf("cached: got %q", got)
+	}
+	if n := calls.Load(); n != 0 {
+		t.Errorf("got %d calls, wanted all from the cache", n)
+	}
+}
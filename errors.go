@@ -0,0 +1,77 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"errors"
+	"fmt"
+)
+
+// The errors of the stages of getting the list, wrapping the underlying errors,
+// so the callers can tell a changed web site from a corrupt file or a missing tool with errors.Is.
+var (
+	// ErrDiscovery wraps the errors of finding the publication (SearchCandidates, SearchXLSURL).
+	ErrDiscovery = errors.New("discovery")
+	// ErrDownload wraps the errors of downloading the publication (DownloadFile, DownloadToFile).
+	ErrDownload = errors.New("download")
+	// ErrFormatUnknown is returned by Parse for content of none of the known formats.
+	ErrFormatUnknown = errors.New("unknown format")
+	// ErrPDFExtraction wraps the errors of extracting the tables from the PDF (by all the PDFStrategies).
+	ErrPDFExtraction = errors.New("PDF extraction")
+	// ErrSpreadsheet wraps the errors of reading the XLSX or XLS.
+	ErrSpreadsheet = errors.New("spreadsheet")
+	// ErrHTML wraps the errors of reading the HTML table.
+	ErrHTML = errors.New("HTML")
+)
+
+// ErrUnsupportedInBuild is returned by ParsePDF when the package is built without the external commands:
+// with the giro_noexec build tag, or for js or wasip1 (such as for WebAssembly),
+// where only the spreadsheets, HTML and CSV can be parsed.
+var ErrUnsupportedInBuild = errors.New("not supported in this build")
+
+// ErrorPolicy decides what the parsers return on an error.
+type ErrorPolicy uint8
+
+const (
+	// BestEffort parsers skip the unreadable rows, and return the records parsed so far,
+	// with the errors joined (see errors.Join).
+	BestEffort = ErrorPolicy(iota)
+	// FailFast parsers abort on the first error, returning no records.
+	//
+	// ParsePDF still tries the next strategy after a failed one (see WithPDFStrategies).
+	FailFast
+)
+
+// WithErrorPolicy sets the ErrorPolicy of ParseXLSX, ParseXLS, ParsePDF, ParseHTML and Parse
+// (default is BestEffort).
+func WithErrorPolicy(policy ErrorPolicy) Option {
+	return func(o *options) { o.errorPolicy = policy }
+}
+
+// rowError returns err to abort the parsing with FailFast,
+// or collects it into errs and returns nil to continue with BestEffort.
+func (o *options) rowError(errs *[]error, err error) error {
+	if o.errorPolicy == FailFast {
+		return err
+	}
+	*errs = append(*errs, err)
+	return nil
+}
+
+// result returns the records and err by the ErrorPolicy: no records on error with FailFast.
+func (o *options) result(hs []Hitelezo, err error) ([]Hitelezo, error) {
+	if err != nil && o.errorPolicy == FailFast {
+		return nil, err
+	}
+	return hs, err
+}
+
+// inStage returns err wrapped by the stage error, if it is not already.
+func inStage(stage, err error) error {
+	if err == nil || errors.Is(err, stage) {
+		return err
+	}
+	return fmt.Errorf("%w: %w", stage, err)
+}
@@ -0,0 +1,47 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rogpeppe/retry"
+)
+
+func TestStageErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "gone", http.StatusGone)
+	}))
+	defer srv.Close()
+	ctx := context.Background()
+
+	if _, err := SearchCandidates(ctx, srv.URL, DefaultPattern); !errors.Is(err, ErrDiscovery) {
+		t.Errorf("SearchCandidates: got %+v, wanted ErrDiscovery", err)
+	}
+	if _, _, err := DownloadFile(ctx, "http://127.0.0.1:1/x.xlsx", WithRetry(retry.Strategy{MaxCount: 1})); !errors.Is(err, ErrDownload) {
+		t.Errorf("DownloadFile: got %+v, wanted ErrDownload", err)
+	}
+	if _, err := Parse(ctx, strings.NewReader("\x00\x01\x02 binary")); !errors.Is(err, ErrFormatUnknown) {
+		t.Errorf("Parse: got %+v, wanted ErrFormatUnknown", err)
+	}
+	if _, err := Parse(ctx, strings.NewReader("PK\x03\x04 not a zip at all")); !errors.Is(err, ErrSpreadsheet) {
+		t.Errorf("Parse: got %+v, wanted ErrSpreadsheet", err)
+	}
+	if _, err := ParseXLSX(ctx, strings.NewReader("garbage")); !errors.Is(err, ErrSpreadsheet) {
+		t.Errorf("ParseXLSX: got %+v, wanted ErrSpreadsheet", err)
+	}
+	sinkErr := errors.New("sink")
+	if err := ParseXLSXFunc(ctx, strings.NewReader("garbage"), func(Hitelezo) error { return sinkErr }); !errors.Is(err, ErrSpreadsheet) {
+		t.Errorf("ParseXLSXFunc: got %+v, wanted ErrSpreadsheet", err)
+	}
+	if _, err := ParsePDF(ctx, strings.NewReader("%PDF-1.4\n"), WithPDFStrategies(PDFText)); !errors.Is(err, ErrPDFExtraction) {
+		t.Errorf("ParsePDF: got %+v, wanted ErrPDFExtraction", err)
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+// Event is a lifecycle event: one of FetchStarted, FetchFailed,
+// VersionParsed, DirectorySwapped, ValidationWarning and NewPublication.
+type Event interface {
+	isEvent()
+}
+
+// FetchStarted is emitted when a HTTP request is started.
+type FetchStarted struct{ URL string }
+
+// FetchFailed is emitted when a HTTP request failed (after all retries).
+type FetchFailed struct {
+	URL string
+	Err error
+}
+
+// VersionParsed is emitted when Parse finished successfully.
+type VersionParsed struct {
+	Source  Source
+	Records int
+}
+
+// DirectorySwapped is emitted when a Directory's contents has been replaced.
+type DirectorySwapped struct{ Old, New int }
+
+// ValidationWarning is emitted for each warning collected into the Report.
+type ValidationWarning struct{ Err error }
+
+func (FetchStarted) isEvent()      {}
+func (FetchFailed) isEvent()       {}
+func (VersionParsed) isEvent()     {}
+func (DirectorySwapped) isEvent()  {}
+func (ValidationWarning) isEvent() {}
+
+// WithEvents sets the function to be called with the lifecycle events.
+//
+// It is called synchronously, possibly from several goroutines,
+// so it must be quick and safe for concurrent use.
+func WithEvents(sink func(Event)) Option {
+	return func(o *options) { o.events = sink }
+}
+
+func (o *options) emit(ev Event) {
+	if o.events != nil {
+		o.events(ev)
+	}
+}
+
+// parsed emits VersionParsed on success.
+func (o *options) parsed(hs []Hitelezo, err error) {
+	if err != nil || o.events == nil {
+		return
+	}
+	ev := VersionParsed{Records: len(hs)}
+	if o.source != nil {
+		ev.Source = *o.source
+	}
+	o.emit(ev)
+}
+
+// warn reports the non-fatal problem found.
+func (o *options) warn(err error) {
+	o.report.warn(err)
+	o.emit(ValidationWarning{Err: err})
+}
@@ -0,0 +1,45 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestEvents(t *testing.T) {
+	const doc = `<html><body><table>
+<tr><th>Bankszerv</th><th>CDV</th><th>Név</th><th>Irsz.</th><th>Cím</th></tr>
+<tr><td>10024003</td><td>9</td><td>Magyar Államkincstár</td><td>1138</td><td>Budapest, Dunavirág u. 2.</td></tr>
+</table></body></html>`
+	var mu sync.Mutex
+	var events []Event
+	opt := WithEvents(func(ev Event) { mu.Lock(); events = append(events, ev); mu.Unlock() })
+
+	r := Refresher{
+		Directory: NewDirectory(nil),
+		Options:   []Option{opt},
+		Fetch: func(ctx context.Context, _ *Source) ([]Hitelezo, error) {
+			return Parse(ctx, strings.NewReader(doc), opt)
+		},
+	}
+	if err := r.TriggerRefresh(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 3 {
+		t.Fatalf("got %d events, wanted 3: %+v", len(events), events)
+	}
+	if _, ok := events[0].(ValidationWarning); !ok {
+		t.Errorf("0. got %#v, wanted ValidationWarning", events[0])
+	}
+	if ev, ok := events[1].(VersionParsed); !ok || ev.Records != 1 {
+		t.Errorf("1. got %#v, wanted VersionParsed", events[1])
+	}
+	if ev, ok := events[2].(DirectorySwapped); !ok || ev.Old != 0 || ev.New != 1 {
+		t.Errorf("2. got %#v, wanted DirectorySwapped", events[2])
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Command service is an example service, serving the GIRO records over HTTP:
+// it refreshes a giro.Directory periodically, serves it with server.Server,
+// and publishes its metrics on /debug/vars (expvar).
+package main
+
+import (
+	"context"
+	"expvar"
+	"flag"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"slices"
+	"time"
+
+	"github.com/UNO-SOFT/giro"
+	"github.com/UNO-SOFT/giro/server"
+)
+
+var metrics = expvar.NewMap("giro")
+
+func main() {
+	if err := Main(); err != nil {
+		slog.Error("main", "error", err)
+		os.Exit(1)
+	}
+}
+
+func Main() error {
+	flagAddr := flag.String("addr", ":8080", "address to listen on")
+	flagURL := flag.String("url", giro.DefaultXLSXURL, "URL of the list")
+	flagInterval := flag.Duration("interval", 24*time.Hour, "refresh interval")
+	flagWebhook := flag.String("webhook", "", "URL to POST the change summaries to (signed with $GIRO_WEBHOOK_SECRET)")
+	flag.Parse()
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	handler, refresher := newService(*flagURL, *flagInterval, os.Getenv("GIRO_REFRESH_TOKEN"))
+	if err := refresher.TriggerRefresh(ctx); err != nil {
+		slog.Warn("initial refresh failed, serving the embedded snapshot", "error", err)
+	}
+	if *flagWebhook != "" {
+		// after the initial refresh, not to notify the changes since the embedded snapshot
+		refresher.Webhook = &giro.Webhook{URL: *flagWebhook, Secret: []byte(os.Getenv("GIRO_WEBHOOK_SECRET"))}
+	}
+	go refresher.Run(ctx)
+
+	srv := http.Server{Addr: *flagAddr, Handler: handler}
+	go func() {
+		<-ctx.Done()
+		shutCtx, shutCancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer shutCancel()
+		_ = srv.Shutdown(shutCtx)
+		if err := refresher.Close(shutCtx); err != nil {
+			slog.Warn("close refresher", "error", err)
+		}
+	}()
+	slog.Info("listening", "addr", *flagAddr)
+	if err := srv.ListenAndServe(); err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+// newService returns the handler of the service, and its Refresher downloading from URL.
+func newService(URL string, interval time.Duration, token string) (http.Handler, *giro.Refresher) {
+	dir := giro.LoadEmbedded()
+	opts := []giro.Option{giro.WithEvents(countEvent)}
+	refresher := &giro.Refresher{
+		Directory: dir,
+		Interval:  interval,
+		Options:   opts,
+		Fetch: func(ctx context.Context, src *giro.Source) ([]giro.Hitelezo, error) {
+			opts := append(slices.Clip(opts), giro.WithSource(src))
+			_, rc, err := giro.DownloadFile(ctx, URL, opts...)
+			if err != nil {
+				return nil, err
+			}
+			defer rc.Close()
+			return giro.Parse(ctx, rc, opts...)
+		},
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/debug/vars", expvar.Handler())
+	mux.Handle("/", &server.Server{Directory: dir, Refresher: refresher, RefreshToken: token})
+	return mux, refresher
+}
+
+// countEvent counts the events in metrics.
+func countEvent(ev giro.Event) {
+	switch ev := ev.(type) {
+	case giro.FetchStarted:
+		metrics.Add("fetches", 1)
+	case giro.FetchFailed:
+		metrics.Add("fetch_failures", 1)
+	case giro.ValidationWarning:
+		metrics.Add("warnings", 1)
+	case giro.DirectorySwapped:
+		metrics.Add("swaps", 1)
+		var records expvar.Int
+		records.Set(int64(ev.New))
+		metrics.Set("records", &records)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/UNO-SOFT/giro"
+)
+
+// TestPipeline runs the whole pipeline against a fake upstream:
+// download, parse, swap into the Directory, serve and count.
+func TestPipeline(t *testing.T) {
+	var xlsx bytes.Buffer
+	if err := giro.WriteXLSX(&xlsx, []giro.Hitelezo{
+		{Bankszerv: "03000007", Nev: "Próba Bank", Irszam: "1139", Cim: "Budapest, Váci út 71."},
+		{Bankszerv: "11773016", BIC: "OTPVHUHB", Nev: "OTP Bank", Irszam: "1051", Cim: "Budapest, Nádor u. 16."},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Disposition", `attachment; filename="sht.xlsx"`)
+		_, _ = w.Write(xlsx.Bytes())
+	}))
+	defer upstream.Close()
+
+	handler, refresher := newService(upstream.URL+"/sht.xlsx", time.Hour, "secret")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go refresher.Run(ctx)
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+
+	do := func(method, path string, want int) []byte {
+		t.Helper()
+		req, _ := http.NewRequestWithContext(ctx, method, srv.URL+path, nil)
+		req.Header.Set("Authorization", "Bearer secret")
+		resp, err := srv.Client().Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		b, _ := io.ReadAll(resp.Body)
+		if resp.StatusCode != want {
+			t.Fatalf("%s %s: got %s (%s), wanted %d", method, path, resp.Status, b, want)
+		}
+		return b
+	}
+	do("POST", "/refresh", http.StatusOK)
+
+	var h giro.Hitelezo
+	if err := json.Unmarshal(do("GET", "/hitelezo/03000007", http.StatusOK), &h); err != nil {
+		t.Fatal(err)
+	}
+	if h.Nev != "Próba Bank" || h.Irszam != "1139" {
+		t.Errorf("got %+v", h)
+	}
+
+	var vars struct {
+		Giro struct{ Fetches, Swaps, Records int }
+	}
+	if err := json.Unmarshal(do("GET", "/debug/vars", http.StatusOK), &vars); err != nil {
+		t.Fatal(err)
+	}
+	if vars.Giro.Fetches == 0 || vars.Giro.Swaps == 0 || vars.Giro.Records != 2 {
+		t.Errorf("got metrics %+v", vars.Giro)
+	}
+}
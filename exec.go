@@ -0,0 +1,78 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"errors"
+	"os"
+	"time"
+)
+
+var (
+	// ErrExecTimeout is returned when an external command (tabula, pdftotext)
+	// runs longer than the timeout set by WithExecLimits.
+	ErrExecTimeout = errors.New("external command timed out")
+	// ErrOutputLimit is returned when an external command writes more
+	// than the limit set by WithExecLimits.
+	ErrOutputLimit = errors.New("external command output limit exceeded")
+)
+
+// WithExecLimits limits the runtime and the output size of each external command
+// (tabula, pdftotext), independently of the deadline of the context,
+// so a hung JVM cannot consume the whole time of the parse.
+// The command is killed when either is exceeded. Zero means no limit.
+func WithExecLimits(timeout time.Duration, maxOutput int64) Option {
+	return func(o *options) { o.execTimeout, o.execMaxOutput = timeout, maxOutput }
+}
+
+// DefaultExecEnv are the names of the environment variables passed to the external commands,
+// which do not get the rest of the environment of the process (such as the credentials).
+var DefaultExecEnv = []string{
+	"PATH", "HOME", "TMPDIR", "LANG", "LC_ALL", "LC_CTYPE",
+	"JAVA_HOME", "TESSDATA_PREFIX",
+	// for WithTabulaContainer
+	"DOCKER_HOST", "CONTAINER_HOST", "XDG_RUNTIME_DIR",
+	// for Windows
+	"SYSTEMROOT", "TEMP", "TMP",
+}
+
+// WithExecEnv adds the environment variables ("KEY=value") to the environment of the external commands,
+// which get only the variables named by DefaultExecEnv from the environment of the process.
+func WithExecEnv(env ...string) Option {
+	return func(o *options) { o.execEnv = append(o.execEnv, env...) }
+}
+
+// execEnviron returns the environment of the external commands.
+func (o *options) execEnviron() []string {
+	env := make([]string, 0, len(DefaultExecEnv)+len(o.execEnv))
+	for _, k := range DefaultExecEnv {
+		if v, ok := os.LookupEnv(k); ok {
+			env = append(env, k+"="+v)
+		}
+	}
+	// the later ones take precedence in os/exec
+	return append(env, o.execEnv...)
+}
+
+// ErrJavaNotFound is returned by the tabula strategy of ParsePDF when the Java executable is not found.
+var ErrJavaNotFound = errors.New("java not found")
+
+// WithJava sets the Java executable running tabula (default is "java" from PATH),
+// and its JVM arguments (such as "-Xmx512m").
+func WithJava(path string, jvmArgs ...string) Option {
+	return func(o *options) { o.java, o.jvmArgs = path, jvmArgs }
+}
+
+// WithTabulaJSON makes the tabula strategy of ParsePDF read the JSON output of tabula,
+// with the cells of each table on each page, instead of the CSV rows of exactly four columns.
+// The headers are recognized (see WithColumns), and the multi-line names and addresses are joined.
+func WithTabulaJSON() Option {
+	return func(o *options) { o.tabulaJSON = true }
+}
+
+// WithTabulaArgs appends extra arguments to the tabula command line.
+func WithTabulaArgs(args ...string) Option {
+	return func(o *options) { o.tabulaArgs = append(o.tabulaArgs, args...) }
+}
@@ -0,0 +1,381 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !giro_noexec && !js && !wasip1
+
+package giro
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExecLimits(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip(err)
+	}
+	ctx := context.Background()
+	t.Setenv("GIRO_SECRET", "secret")
+	o := newOptions([]Option{WithExecEnv("GIRO_EXTRA=extra", "LANG=C")})
+	cmd := o.command(ctx, "sh", "-c", `echo "$GIRO_SECRET/$GIRO_EXTRA/$LANG"`)
+	var buf strings.Builder
+	cmd.Stdout = &buf
+	if err := run(cmd); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(buf.String()); got != "/extra/C" {
+		t.Errorf("environment: got %q, wanted only the allowed variables", got)
+	}
+
+	run := func(o *options, script string) error {
+		cmd := o.command(ctx, "sh", "-c", script)
+		pr, err := cmd.StdoutPipe()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = cmd.Start(); err != nil {
+			t.Fatal(err)
+		}
+		_, err = io.Copy(io.Discard, pr)
+		if waitErr := cmd.Wait(); waitErr != nil {
+			return waitErr
+		}
+		return err
+	}
+
+	if err := run(newOptions(nil), "echo ok"); err != nil {
+		t.Errorf("no limits: %+v", err)
+	}
+	if err := run(newOptions([]Option{WithExecLimits(10*time.Millisecond, 0)}), "exec sleep 10"); !errors.Is(err, ErrExecTimeout) {
+		t.Errorf("timeout: got %+v, wanted ErrExecTimeout", err)
+	}
+	if err := run(newOptions([]Option{WithExecLimits(0, 1000)}), "while true; do echo 0123456789; done"); !errors.Is(err, ErrOutputLimit) {
+		t.Errorf("output: got %+v, wanted ErrOutputLimit", err)
+	}
+}
+
+func TestJava(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip(err)
+	}
+	ctx := context.Background()
+	pdf := []byte("%PDF-1.4\n")
+	if _, err := parsePDFTabula(ctx, bytes.NewReader(pdf), newOptions([]Option{WithJava(filepath.Join(t.TempDir(), "java"))})); !errors.Is(err, ErrJavaNotFound) {
+		t.Fatalf("got %+v, wanted ErrJavaNotFound", err)
+	}
+
+	// a fake java printing the tabula CSV, with the cached jar
+	dir := t.TempDir()
+	cache, err := newOptions([]Option{WithCacheDir(filepath.Join(dir, "cache"))}).openCache()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err = cache.Put(tabulaJarKey(DefaultTabulaJarURL, DefaultTabulaJarSHA256), bytes.NewReader([]byte("jar"))); err != nil {
+		t.Fatal(err)
+	}
+	java, argsFn := filepath.Join(dir, "java"), filepath.Join(dir, "args")
+	if err = os.WriteFile(java, []byte(`#!/bin/sh
+echo "$@" >`+argsFn+`
+echo '10002003,Magyar Államkincstár,1139,"Budapest, Váci út 71."'
+`), 0700); err != nil {
+		t.Fatal(err)
+	}
+	hs, err := parsePDFTabula(ctx, bytes.NewReader(pdf), newOptions([]Option{
+		WithCacheDir(filepath.Join(dir, "cache")), WithJava(java, "-Xmx64m"), WithTabulaArgs("--silent"),
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hs) != 1 || hs[0].Bankszerv != "10002003" {
+		t.Errorf("got %+v", hs)
+	}
+	b, err := os.ReadFile(argsFn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if args := strings.Fields(string(b)); len(args) != 10 || args[0] != "-Xmx64m" || args[1] != "-jar" || args[8] != "--silent" {
+		t.Errorf("got args %q", args)
+	}
+}
+
+func TestParsePDFFilter(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip(err)
+	}
+	dir := t.TempDir()
+	cache, err := newOptions([]Option{WithCacheDir(filepath.Join(dir, "cache"))}).openCache()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err = cache.Put(tabulaJarKey(DefaultTabulaJarURL, DefaultTabulaJarSHA256), bytes.NewReader([]byte("jar"))); err != nil {
+		t.Fatal(err)
+	}
+	java := filepath.Join(dir, "java")
+	if err = os.WriteFile(java, []byte(`#!/bin/sh
+echo '10002003,Magyar Államkincstár,1139,"Budapest, Váci út 71."'
+echo '99900005,GIRO tesztfiók,1054,Budapest'
+echo '10002003,Magyar Államkincstár (másodszor),1139,"Budapest, Váci út 71."'
+`), 0700); err != nil {
+		t.Fatal(err)
+	}
+	hs, err := Parse(context.Background(), strings.NewReader("%PDF-1.4\n"),
+		WithCacheDir(filepath.Join(dir, "cache")), WithJava(java), WithPDFStrategies(PDFTabula),
+		WithTechnical(false), WithDuplicates(KeepFirst))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hs) != 1 || hs[0].Nev != "Magyar Államkincstár" {
+		t.Errorf("got %+v, wanted the first MÁK only", hs)
+	}
+}
+
+func TestTabulaContainer(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip(err)
+	}
+	dir := t.TempDir()
+	cache, err := newOptions([]Option{WithCacheDir(filepath.Join(dir, "cache"))}).openCache()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err = cache.Put(tabulaJarKey(DefaultTabulaJarURL, DefaultTabulaJarSHA256), bytes.NewReader([]byte("jar"))); err != nil {
+		t.Fatal(err)
+	}
+	// a fake container runtime checking the bind mount
+	runtime, argsFn := filepath.Join(dir, "podman"), filepath.Join(dir, "args")
+	if err = os.WriteFile(runtime, []byte(`#!/bin/sh
+echo "$@" >`+argsFn+`
+src=${5%%:*}
+test -f "$src/tabula.jar" && test -f "$src/x.pdf" || exit 1
+echo '10002003,Magyar Államkincstár,1139,"Budapest, Váci út 71."'
+`), 0700); err != nil {
+		t.Fatal(err)
+	}
+	hs, err := parsePDFTabula(context.Background(), bytes.NewReader([]byte("%PDF-1.4\n")), newOptions([]Option{
+		WithCacheDir(filepath.Join(dir, "cache")), WithJava(filepath.Join(dir, "java"), "-Xmx64m"),
+		WithTabulaContainer(TabulaContainer{Runtime: runtime, Image: "temurin"}),
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hs) != 1 {
+		t.Errorf("got %+v", hs)
+	}
+	b, err := os.ReadFile(argsFn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	args := strings.Fields(string(b))
+	if len(args) < 10 || args[0] != "run" || args[5] != "temurin" || args[6] != "java" || args[7] != "-Xmx64m" ||
+		args[len(args)-1] != "/work/x.pdf" {
+		t.Errorf("got args %q", args)
+	}
+
+	_, err = parsePDFTabula(context.Background(), bytes.NewReader([]byte("%PDF-1.4\n")), newOptions([]Option{
+		WithCacheDir(filepath.Join(dir, "cache")), WithJava(filepath.Join(dir, "java")),
+		WithTabulaContainer(TabulaContainer{Runtime: runtime}),
+	}))
+	if !errors.Is(err, ErrJavaNotFound) || !strings.Contains(err.Error(), "TabulaContainer.Image") {
+		t.Errorf("no image: got %+v", err)
+	}
+}
+
+func TestPDFAttempts(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip(err)
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "pdftotext"), []byte("#!/bin/sh\nexit 3\n"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir)
+	var rep Report
+	_, err := ParsePDF(context.Background(), strings.NewReader("%PDF-1.4\n"),
+		WithReport(&rep), WithJava(filepath.Join(dir, "java")))
+	if !errors.Is(err, ErrJavaNotFound) {
+		t.Errorf("got %+v, wanted ErrJavaNotFound", err)
+	}
+	if len(rep.PDFAttempts) != 2 {
+		t.Fatalf("got %+v", rep.PDFAttempts)
+	}
+	for i, s := range DefaultPDFStrategies {
+		if a := rep.PDFAttempts[i]; a.Strategy != s || a.Err == nil || a.Duration <= 0 {
+			t.Errorf("%d. got %+v", i, a)
+		}
+	}
+
+	_, err = ParsePDF(context.Background(), strings.NewReader("%PDF-1.4\n"),
+		WithoutPDFStrategies(DefaultPDFStrategies...))
+	if !errors.Is(err, ErrPDFExtraction) {
+		t.Errorf("got %+v, wanted ErrPDFExtraction", err)
+	}
+}
+
+func TestTabulaJSON(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip(err)
+	}
+	dir := t.TempDir()
+	cache, err := newOptions([]Option{WithCacheDir(filepath.Join(dir, "cache"))}).openCache()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err = cache.Put(tabulaJarKey(DefaultTabulaJarURL, DefaultTabulaJarSHA256), bytes.NewReader([]byte("jar"))); err != nil {
+		t.Fatal(err)
+	}
+	const tables = `[{"extraction_method":"lattice","page_number":1,"data":[
+[{"text":"2026.01.01-től érvényes Egyszerűsített Hitelesítő Tábla"}],
+[{"text":"Bankszerv"},{"text":"Név"},{"text":"Irsz."},{"text":"Cím"}],
+[{"text":"10002003"},{"text":"Magyar\rÁllamkincstár"},{"text":"1139"},{"text":"Budapest, Váci út 71."}],
+[{"text":"10023002"},{"text":"Magyar Államkincstár Baranya"},{"text":"7621"},{"text":"Pécs, Rákóczi út 34."}],
+[{"text":""},{"text":"Vármegyei Igazgatóság"},{"text":""},{"text":"(bejárat a Király utcából)"}]
+]},{"extraction_method":"lattice","page_number":2,"data":[
+[{"text":"Bankszerv"},{"text":"Név"},{"text":"Irsz."},{"text":"Cím"}],
+[{"text":"11773016"},{"text":"OTP Bank Nyrt."},{"text":"1051"}],
+[{"text":"1. oldal"}]
+]}]`
+	java := filepath.Join(dir, "java")
+	if err = os.WriteFile(java, []byte(`#!/bin/sh
+case "$*" in
+*"-f JSON"*) cat <<'EOF'
+`+tables+`
+EOF
+;;
+*) echo '10002003,Magyar Államkincstár,1139,"Budapest, Váci út 71."'
+echo '11773016,OTP Bank Nyrt.'
+;;
+esac
+`), 0700); err != nil {
+		t.Fatal(err)
+	}
+	var origins []RowOrigin
+	opts := []Option{WithCacheDir(filepath.Join(dir, "cache")), WithJava(java), withOrigins(&origins)}
+	hs, err := parsePDFTabula(context.Background(), strings.NewReader("%PDF-1.4\n"),
+		newOptions(append(opts, WithTabulaJSON())))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Hitelezo{
+		{Bankszerv: "10002003", Nev: "Magyar Államkincstár", Irszam: "1139", Cim: "Budapest, Váci út 71."},
+		{Bankszerv: "10023002", Nev: "Magyar Államkincstár Baranya Vármegyei Igazgatóság", Irszam: "7621",
+			Cim: "Pécs, Rákóczi út 34. (bejárat a Király utcából)"},
+		{Bankszerv: "11773016", Nev: "OTP Bank Nyrt.", Irszam: "1051"},
+	}
+	if len(hs) != len(want) {
+		t.Fatalf("got %+v", hs)
+	}
+	for i, h := range hs {
+		if h != want[i] {
+			t.Errorf("%d. got %+v, wanted %+v", i, h, want[i])
+		}
+	}
+	if want := []RowOrigin{{Page: 1, Row: 3}, {Page: 1, Row: 4}, {Page: 2, Row: 2}}; !slices.Equal(origins, want) {
+		t.Errorf("got origins %v, wanted %v", origins, want)
+	}
+
+	// the short CSV rows have the missing cells empty
+	if hs, err = parsePDFTabula(context.Background(), strings.NewReader("%PDF-1.4\n"), newOptions(opts)); err != nil {
+		t.Fatal(err)
+	}
+	if len(hs) != 2 || hs[1].Nev != "OTP Bank Nyrt." || hs[1].Cim != "" {
+		t.Errorf("got %+v", hs)
+	}
+}
+
+func TestTabulaReadError(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip(err)
+	}
+	dir := t.TempDir()
+	cache, err := newOptions([]Option{WithCacheDir(filepath.Join(dir, "cache"))}).openCache()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err = cache.Put(tabulaJarKey(DefaultTabulaJarURL, DefaultTabulaJarSHA256), bytes.NewReader([]byte("jar"))); err != nil {
+		t.Fatal(err)
+	}
+	// a bad JSON, followed by more output than the pipe buffer
+	java := filepath.Join(dir, "java")
+	if err = os.WriteFile(java, []byte(`#!/bin/sh
+echo '[{oops'
+head -c 1048576 /dev/zero
+`), 0700); err != nil {
+		t.Fatal(err)
+	}
+	_, err = parsePDFTabula(context.Background(), strings.NewReader("%PDF-1.4\n"), newOptions([]Option{
+		WithCacheDir(filepath.Join(dir, "cache")), WithJava(java), WithTabulaJSON(),
+		WithErrorPolicy(FailFast), WithExecLimits(10*time.Second, 0),
+	}))
+	if err == nil || errors.Is(err, ErrExecTimeout) {
+		t.Errorf("got %+v, wanted the JSON error", err)
+	}
+}
+
+func TestTabulaJar(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip(err)
+	}
+	dir := t.TempDir()
+	java := filepath.Join(dir, "java")
+	// a fake java checking the jar
+	if err := os.WriteFile(java, []byte(`#!/bin/sh
+test "$(cat $2)" = "jar" || exit 1
+echo '10002003,Magyar Államkincstár,1139,"Budapest, Váci út 71."'
+`), 0700); err != nil {
+		t.Fatal(err)
+	}
+	var hits int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		io.WriteString(w, "jar")
+	}))
+	defer srv.Close()
+	sum := sha256.Sum256([]byte("jar"))
+	hash := hex.EncodeToString(sum[:])
+	parse := func(opts ...Option) error {
+		_, err := parsePDFTabula(context.Background(), strings.NewReader("%PDF-1.4\n"), newOptions(append([]Option{
+			WithCacheDir(filepath.Join(dir, "cache")), WithJava(java),
+		}, opts...)))
+		return err
+	}
+
+	if err := parse(WithTabulaJar(srv.URL+"/tabula.jar", strings.Repeat("0", 64))); !errors.Is(err, ErrHashMismatch) {
+		t.Errorf("got %+v, wanted ErrHashMismatch", err)
+	}
+	for range 2 {
+		if err := parse(WithTabulaJar(srv.URL+"/tabula.jar", strings.ToUpper(hash))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if hits != 2 {
+		t.Errorf("got %d downloads, wanted the cached jar reused", hits)
+	}
+	if err := parse(WithTabulaJar(srv.URL+"/tabula.jar", "")); err == nil {
+		t.Error("wanted error for the missing hash")
+	}
+
+	jarFn := filepath.Join(dir, "tabula.jar")
+	if err := os.WriteFile(jarFn, []byte("jar"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := parse(WithTabulaJar(jarFn, "")); err != nil {
+		t.Errorf("local jar: %+v", err)
+	}
+	if err := parse(WithTabulaJar("file://"+jarFn, hash+"0")); !errors.Is(err, ErrHashMismatch) {
+		t.Errorf("local jar: got %+v, wanted ErrHashMismatch", err)
+	}
+}
@@ -0,0 +1,274 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// The exporters keep all the fields as text,
+// thus codes with leading zeros (03000007) survive the round trip.
+
+// exportHeader is the header row of the exported tables.
+var exportHeader = []string{"Bankszerv", "BIC", "Nev", "Irszam", "Cim"}
+
+// exportRow returns the fields of h, with the display name, transliterated and truncated if asked for.
+func (o *options) exportRow(h Hitelezo) []string {
+	nev := h.Nev
+	if o.displayName != nil {
+		nev = o.displayName(h)
+	}
+	return o.truncateRow(h, []string{h.Bankszerv, h.BIC, transliterate(nev, o.translit), h.Irszam, transliterate(h.Cim, o.translit)})
+}
+
+// WriteCSV writes the records as CSV, with a header row.
+//
+// All the fields are quoted, to mark them as text.
+func WriteCSV(w io.Writer, hs []Hitelezo, opts ...Option) error {
+	o := newOptions(opts)
+	bw := bufio.NewWriter(w)
+	writeRow := func(row []string) {
+		for i, s := range row {
+			if i != 0 {
+				bw.WriteByte(',')
+			}
+			bw.WriteByte('"')
+			bw.WriteString(strings.ReplaceAll(s, `"`, `""`))
+			bw.WriteByte('"')
+		}
+		bw.WriteString("\r\n")
+	}
+	writeRow(exportHeader)
+	for _, h := range hs {
+		writeRow(o.exportRow(h))
+	}
+	return bw.Flush()
+}
+
+// ReadCSV reads the records written by WriteCSV.
+func ReadCSV(r io.Reader) ([]Hitelezo, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = len(exportHeader)
+	cr.ReuseRecord = true
+	var hs []Hitelezo
+	for {
+		row, err := cr.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return hs, err
+		}
+		if len(hs) == 0 && row[0] == exportHeader[0] {
+			continue
+		}
+		hs = append(hs, Hitelezo{
+			Bankszerv: row[0], BIC: row[1], Nev: row[2], Irszam: row[3], Cim: row[4],
+		})
+	}
+	return hs, nil
+}
+
+// WriteJSONL writes the records as JSON, one per line (newline-delimited JSON),
+// with the display name, transliterated and truncated as the other exporters.
+func WriteJSONL(w io.Writer, hs []Hitelezo, opts ...Option) error {
+	o := newOptions(opts)
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	for _, h := range hs {
+		row := o.exportRow(h)
+		h.Bankszerv, h.BIC, h.Nev, h.Irszam, h.Cim = row[0], row[1], row[2], row[3], row[4]
+		if err := enc.Encode(h); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// maxJSONLLine is the longest line accepted by ReadJSONL.
+const maxJSONLLine = 1 << 20
+
+// ReadJSONL reads the records written by WriteJSONL.
+func ReadJSONL(r io.Reader) ([]Hitelezo, error) {
+	var hs []Hitelezo
+	err := ReadJSONLFunc(r, func(h Hitelezo) error {
+		hs = append(hs, h)
+		return nil
+	})
+	return hs, err
+}
+
+// ReadJSONLFunc calls sink with each record of the JSONL, as it is read,
+// stopping at the first error. The empty lines are skipped.
+func ReadJSONLFunc(r io.Reader, sink func(Hitelezo) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 4096), maxJSONLLine)
+	var line int
+	for scanner.Scan() {
+		line++
+		b := bytes.TrimSpace(scanner.Bytes())
+		if len(b) == 0 {
+			continue
+		}
+		var h Hitelezo
+		if err := json.Unmarshal(b, &h); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		if err := sink(h); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// WriteXLSX writes the records as an XLSX workbook, with a header row.
+//
+// The cells are strings, the columns are formatted as text,
+// the header row is bold, frozen and filterable.
+// With WithSheetPerBank, the records of each bank are written to a separate sheet, too.
+func WriteXLSX(w io.Writer, hs []Hitelezo, opts ...Option) error {
+	o := newOptions(opts)
+	wb := excelize.NewFile()
+	defer wb.Close()
+	// 49 is the built-in "@" (text) number format
+	textStyle, err := wb.NewStyle(&excelize.Style{NumFmt: 49})
+	if err != nil {
+		return err
+	}
+	headerStyle, err := wb.NewStyle(&excelize.Style{NumFmt: 49, Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		return err
+	}
+	writeSheet := func(sheet string, hs []Hitelezo) error {
+		if err := wb.SetColStyle(sheet, "A:E", textStyle); err != nil {
+			return err
+		}
+		for i, width := range []float64{10, 12, 50, 8, 50} {
+			col, _ := excelize.ColumnNumberToName(i + 1)
+			if err := wb.SetColWidth(sheet, col, col, width); err != nil {
+				return err
+			}
+		}
+		for i, row := range append([][]string{exportHeader}, mapRows(hs, o.exportRow)...) {
+			cell, err := excelize.CoordinatesToCellName(1, i+1)
+			if err != nil {
+				return err
+			}
+			if err = wb.SetSheetRow(sheet, cell, &row); err != nil {
+				return err
+			}
+		}
+		if err := wb.SetCellStyle(sheet, "A1", "E1", headerStyle); err != nil {
+			return err
+		}
+		if err := wb.SetPanes(sheet, &excelize.Panes{
+			Freeze: true, YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft",
+		}); err != nil {
+			return err
+		}
+		return wb.AutoFilter(sheet, fmt.Sprintf("A1:E%d", len(hs)+1), nil)
+	}
+	if err = writeSheet(wb.GetSheetName(0), hs); err != nil {
+		return err
+	}
+	if o.sheetPerBank {
+		for _, bank := range groupByBank(hs) {
+			sheet := bankSheetName(bank)
+			if _, err = wb.NewSheet(sheet); err != nil {
+				return err
+			}
+			if err = writeSheet(sheet, bank); err != nil {
+				return err
+			}
+		}
+	}
+	_, err = wb.WriteTo(w)
+	return err
+}
+
+// WithSheetPerBank makes WriteXLSX write the records of each bank
+// (by the first 3 digits of Bankszerv) to a separate sheet, after the sheet of all the records.
+func WithSheetPerBank() Option {
+	return func(o *options) { o.sheetPerBank = true }
+}
+
+// groupByBank groups the records by the first 3 digits of Bankszerv, in the order of their first appearance.
+func groupByBank(hs []Hitelezo) [][]Hitelezo {
+	var groups [][]Hitelezo
+	idx := make(map[string]int)
+	for _, h := range hs {
+		if len(h.Bankszerv) < 3 {
+			continue
+		}
+		i, ok := idx[h.BankCode()]
+		if !ok {
+			i = len(groups)
+			idx[h.BankCode()] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], h)
+	}
+	return groups
+}
+
+// bankSheetName returns the sheet name of the records of a bank:
+// the bank code and the name of its first branch, at most 31 characters.
+func bankSheetName(bank []Hitelezo) string {
+	name := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\'`, r) {
+			return -1
+		}
+		return r
+	}, bank[0].BankCode()+" "+bank[0].Nev)
+	if r := []rune(name); len(r) > 31 {
+		name = string(r[:31])
+	}
+	return strings.TrimSpace(name)
+}
+
+func mapRows(hs []Hitelezo, f func(Hitelezo) []string) [][]string {
+	rows := make([][]string, len(hs))
+	for i, h := range hs {
+		rows[i] = f(h)
+	}
+	return rows
+}
+
+// WriteSQL writes SQL statements creating the table and inserting the records into it.
+//
+// The codes are stored in CHAR columns.
+// The table name is written as is, it must be a valid SQL identifier.
+func WriteSQL(w io.Writer, table string, hs []Hitelezo, opts ...Option) error {
+	o := newOptions(opts)
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, `CREATE TABLE %s (
+  bankszerv CHAR(8) NOT NULL,
+  bic VARCHAR(11),
+  nev VARCHAR(255) NOT NULL,
+  irszam VARCHAR(10),
+  cim VARCHAR(255)
+);
+`, table)
+	for _, h := range hs {
+		row := o.exportRow(h)
+		fmt.Fprintf(bw, "INSERT INTO %s (bankszerv, bic, nev, irszam, cim) VALUES (%s, %s, %s, %s, %s);\n",
+			table, sqlString(row[0]), sqlString(row[1]), sqlString(row[2]), sqlString(row[3]), sqlString(row[4]))
+	}
+	return bw.Flush()
+}
+
+// sqlString returns s as a quoted SQL string literal.
+func sqlString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
@@ -0,0 +1,144 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// leadingZeros are records with codes starting with zero.
+var leadingZeros = []Hitelezo{
+	{Bankszerv: "03000007", BIC: "ABCDHUHB", Nev: `Próba "Zéró" Bank`, Irszam: "0123", Cim: "Budapest, O'Neill utca 1."},
+	{Bankszerv: "00700006", Nev: "Nullás fiók", Irszam: "1139", Cim: "Budapest, Váci út 71."},
+}
+
+func TestLeadingZeros(t *testing.T) {
+	ctx := context.Background()
+	for _, tc := range []struct {
+		Name      string
+		RoundTrip func([]Hitelezo) ([]Hitelezo, error)
+	}{
+		{"csv", func(hs []Hitelezo) ([]Hitelezo, error) {
+			var buf bytes.Buffer
+			if err := WriteCSV(&buf, hs); err != nil {
+				return nil, err
+			}
+			return ReadCSV(&buf)
+		}},
+		{"xlsx", func(hs []Hitelezo) ([]Hitelezo, error) {
+			var buf bytes.Buffer
+			if err := WriteXLSX(&buf, hs); err != nil {
+				return nil, err
+			}
+			return Parse(ctx, &buf)
+		}},
+		{"json", func(hs []Hitelezo) ([]Hitelezo, error) {
+			b, err := json.Marshal(hs)
+			if err != nil {
+				return nil, err
+			}
+			var got []Hitelezo
+			err = json.Unmarshal(b, &got)
+			return got, err
+		}},
+		{"jsonl", func(hs []Hitelezo) ([]Hitelezo, error) {
+			var buf bytes.Buffer
+			if err := WriteJSONL(&buf, hs); err != nil {
+				return nil, err
+			}
+			if n := strings.Count(buf.String(), "\n"); n != len(hs) {
+				return nil, fmt.Errorf("got %d lines, wanted %d", n, len(hs))
+			}
+			return ReadJSONL(&buf)
+		}},
+		{"xml", func(hs []Hitelezo) ([]Hitelezo, error) {
+			var buf bytes.Buffer
+			if err := WriteXML(&buf, hs); err != nil {
+				return nil, err
+			}
+			if !strings.Contains(buf.String(), `<Hitelezok version="1">`) {
+				return nil, fmt.Errorf("no root element in\n%s", buf.String())
+			}
+			return ParseXML(&buf)
+		}},
+		{"html", func(hs []Hitelezo) ([]Hitelezo, error) {
+			var buf strings.Builder
+			buf.WriteString("<html><body><table>\n")
+			for _, h := range hs {
+				fmt.Fprintf(&buf, "<tr><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>\n",
+					h.Bankszerv, h.Nev, h.Irszam, h.Cim)
+			}
+			buf.WriteString("</table></body></html>")
+			got, err := Parse(ctx, strings.NewReader(buf.String()))
+			for i := range got { // no BIC in the EHT
+				got[i].BIC = hs[i].BIC
+			}
+			return got, err
+		}},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			got, err := tc.RoundTrip(leadingZeros)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, leadingZeros) {
+				t.Errorf("got\n%+v\nwanted\n%+v", got, leadingZeros)
+			}
+		})
+	}
+
+	var buf strings.Builder
+	if err := WriteSQL(&buf, "hitelezo", leadingZeros); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"bankszerv CHAR(8)", "VALUES ('03000007',", "'0123'", "O''Neill", "VALUES ('00700006',"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("%q missing from\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestWriteXLSXPerBank(t *testing.T) {
+	hs := append([]Hitelezo{
+		{Bankszerv: "11773016", Nev: "OTP Bank Nyrt. Budapesti Régió: Fiók/Központ", Irszam: "1051", Cim: "Budapest"},
+	}, leadingZeros...)
+	hs = append(hs, Hitelezo{Bankszerv: "11773023", Nev: "OTP Bank Nyrt.", Irszam: "6720", Cim: "Szeged"})
+	var buf bytes.Buffer
+	if err := WriteXLSX(&buf, hs, WithSheetPerBank()); err != nil {
+		t.Fatal(err)
+	}
+	wb, err := excelize.OpenReader(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer wb.Close()
+	sheets := wb.GetSheetList()
+	if want := []string{"Sheet1", "117 OTP Bank Nyrt. Budapesti Ré", "030 Próba \"Zéró\" Bank", "007 Nullás fiók"}; !reflect.DeepEqual(sheets, want) {
+		t.Fatalf("got sheets %q, wanted %q", sheets, want)
+	}
+	rows, err := wb.GetRows(sheets[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 3 || rows[2][0] != "11773023" {
+		t.Errorf("got %q", rows)
+	}
+
+	got, err := Parse(context.Background(), &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, hs) {
+		t.Errorf("got\n%+v\nwanted\n%+v", got, hs)
+	}
+}
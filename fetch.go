@@ -0,0 +1,104 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/rogpeppe/retry"
+)
+
+// DefaultRetry is the default retry strategy of the HTTP requests.
+var DefaultRetry = retry.Strategy{Delay: time.Second, MaxDelay: 10 * time.Second, Factor: 1.25, MaxCount: 3}
+
+// WithRetry sets the retry strategy of the HTTP requests (default is DefaultRetry).
+//
+// Network errors and 5xx responses are retried.
+func WithRetry(strategy retry.Strategy) Option {
+	return func(o *options) { o.retry = strategy }
+}
+
+// WithUserAgent sets the User-Agent header of the HTTP requests.
+func WithUserAgent(userAgent string) Option {
+	return WithHeader("User-Agent", userAgent)
+}
+
+// WithHeader sets the header of the HTTP requests (such as Accept-Language),
+// replacing the previously set values of key.
+func WithHeader(key, value string) Option {
+	return func(o *options) {
+		if o.header == nil {
+			o.header = make(http.Header)
+		}
+		o.header.Set(key, value)
+	}
+}
+
+// do executes the request with client, retrying network errors and 5xx responses.
+func (o *options) do(ctx context.Context, client *http.Client, req *http.Request) (*http.Response, error) {
+	logger := o.logger(ctx)
+	for k, vv := range o.header {
+		if _, ok := req.Header[k]; !ok {
+			req.Header[k] = vv
+		}
+	}
+	o.emit(FetchStarted{URL: req.URL.String()})
+	var err error
+	for iter := o.retry.Start(); ; {
+		var resp *http.Response
+		if resp, err = client.Do(req.WithContext(ctx)); err == nil {
+			if resp.StatusCode < 500 {
+				return resp, nil
+			}
+			err = fmt.Errorf("%s: %s", req.URL, resp.Status)
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		} else {
+			err = fmt.Errorf("%s: %w", req.URL, err)
+		}
+		if ctx.Err() != nil || !iter.Next(ctx.Done()) {
+			break
+		}
+		logger.Debug("retry", "url", req.URL.String(), "count", iter.Count(), "error", err)
+		// the body of the previous try has been consumed
+		if req.GetBody != nil {
+			if req.Body, err = req.GetBody(); err != nil {
+				break
+			}
+		}
+	}
+	if err == nil {
+		err = fmt.Errorf("%s: %w", req.URL, ctx.Err())
+	}
+	o.emit(FetchFailed{URL: req.URL.String(), Err: err})
+	return nil, err
+}
+
+// WithProgress sets a callback to report the progress of DownloadFile.
+//
+// It is called after each read with the number of bytes read so far,
+// and the Content-Length (-1 if unknown).
+func WithProgress(progress func(read, total int64)) Option {
+	return func(o *options) { o.progress = progress }
+}
+
+type progressReader struct {
+	io.ReadCloser
+	progress    func(read, total int64)
+	read, total int64
+}
+
+func (pr *progressReader) Read(p []byte) (int, error) {
+	n, err := pr.ReadCloser.Read(p)
+	if n > 0 {
+		pr.read += int64(n)
+		pr.progress(pr.read, pr.total)
+	}
+	return n, err
+}
@@ -0,0 +1,103 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rogpeppe/retry"
+)
+
+func TestDownloadRetry(t *testing.T) {
+	var n atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if n.Add(1) < 3 {
+			http.Error(w, "busy", http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Disposition", `attachment; filename="sht.xlsx"`)
+		io.WriteString(w, "data")
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	strategy := retry.Strategy{Delay: time.Millisecond, MaxCount: 2}
+	if _, _, err := DownloadFile(ctx, srv.URL, WithRetry(strategy)); err == nil {
+		t.Fatal("wanted error after 2 tries")
+	}
+	n.Store(0)
+	strategy.MaxCount = 3
+	var read, total int64
+	var src Source
+	fn, rc, err := DownloadFile(ctx, srv.URL, WithRetry(strategy), WithSource(&src),
+		WithProgress(func(r, t int64) { read, total = r, t }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := io.ReadAll(rc)
+	rc.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fn != "sht.xlsx" || string(b) != "data" {
+		t.Errorf("got %q, %q", fn, b)
+	}
+	if read != 4 || total != 4 {
+		t.Errorf("got progress %d/%d, wanted 4/4", read, total)
+	}
+	if src.URL != srv.URL || src.Filename != "sht.xlsx" || src.ContentLength != 4 {
+		t.Errorf("got source %+v", src)
+	}
+}
+
+func TestHeaders(t *testing.T) {
+	var mu sync.Mutex
+	var got []http.Header
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		got = append(got, r.Header.Clone())
+		mu.Unlock()
+		switch r.URL.Path {
+		case "/dokumentumok":
+			fmt.Fprintf(w, `<a href="%s/documents/eht">EHT</a>`, srv.URL)
+		case "/documents/eht":
+			http.Redirect(w, r, "/files/EHT_20260101.pdf", http.StatusFound)
+		default:
+			io.WriteString(w, "data")
+		}
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	opts := []Option{WithUserAgent("bank-importer/1.0"), WithHeader("Accept-Language", "hu"), WithHeader("X-Proxy", "a")}
+	u, err := SearchXLSURL(ctx, srv.URL+"/dokumentumok", DefaultPattern, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, rc, err := DownloadFile(ctx, u, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rc.Close()
+	if len(got) != 3 {
+		t.Fatalf("got %d requests", len(got))
+	}
+	for _, h := range got {
+		if h.Get("User-Agent") != "bank-importer/1.0" || h.Get("Accept-Language") != "hu" || h.Get("X-Proxy") != "a" {
+			t.Errorf("got %v", h)
+		}
+	}
+}
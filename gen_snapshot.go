@@ -0,0 +1,67 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build ignore
+
+// gen_snapshot writes the parsed records as gzip-compressed JSON,
+// for embedding into the package.
+package main
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/UNO-SOFT/giro"
+)
+
+func main() {
+	flagOut := flag.String("o", "snapshot.json.gz", "output file")
+	flag.Parse()
+	if err := Main(*flagOut, flag.Arg(0)); err != nil {
+		log.Fatalf("%+v", err)
+	}
+}
+
+// Main parses inp (or the default XLSX if empty) and writes it into out.
+func Main(out, inp string) error {
+	ctx := context.Background()
+	var hs []giro.Hitelezo
+	var err error
+	if inp == "" {
+		hs, err = giro.Parse(ctx, nil)
+	} else {
+		var fh *os.File
+		if fh, err = os.Open(inp); err != nil {
+			return err
+		}
+		hs, err = giro.Parse(ctx, fh)
+		fh.Close()
+	}
+	if err != nil {
+		return err
+	}
+	fh, err := os.Create(out + ".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(fh.Name())
+	zw, _ := gzip.NewWriterLevel(fh, gzip.BestCompression)
+	enc := json.NewEncoder(zw)
+	if err = enc.Encode(hs); err != nil {
+		fh.Close()
+		return err
+	}
+	if err = zw.Close(); err != nil {
+		fh.Close()
+		return err
+	}
+	if err = fh.Close(); err != nil {
+		return err
+	}
+	return os.Rename(fh.Name(), out)
+}
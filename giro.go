@@ -9,27 +9,23 @@ import (
 	"bytes"
 	"context"
 	_ "embed"
-	"encoding/csv"
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"mime"
 	"net/http"
 	"net/url"
-	"os"
-	"os/exec"
 	"path"
-	"path/filepath"
 	"regexp"
-	"sort"
 	"strings"
+	"sync"
 	"time"
+	"unicode"
 
-	"github.com/UNO-SOFT/filecache"
+	"github.com/UNO-SOFT/giro/model"
 	"github.com/UNO-SOFT/zlog/v2"
 
-	"github.com/rogpeppe/retry"
-	"github.com/tgulacsi/go/iohlp"
 	"golang.org/x/net/html"
 	"golang.org/x/sync/errgroup"
 
@@ -43,11 +39,37 @@ const DefaultPattern = `^(.*-xls-.*$|EHT_([0-9]{8}|[0-9]{4}[_-][0-9]{2}[_-][0-9]
 
 var ErrNotFound = errors.New("not found")
 
-func SearchXLSURL(ctx context.Context, searchURL, pattern string) (string, error) {
+// SearchXLSURL returns the URL of the latest file linked from the searchURL page,
+// with its name matching pattern (see SearchCandidates).
+//
+// If there are EHT lists among the candidates, the latest of them is returned,
+// even if a later AVT publication matches the pattern, too (as DefaultPattern does).
+func SearchXLSURL(ctx context.Context, searchURL, pattern string, opts ...Option) (string, error) {
+	candidates, err := SearchCandidates(ctx, searchURL, pattern, opts...)
+	if err != nil {
+		return "", err
+	}
+	for i := len(candidates) - 1; i >= 0; i-- {
+		if candidates[i].Kind == "EHT" {
+			return candidates[i].URL, nil
+		}
+	}
+	return candidates[len(candidates)-1].URL, nil
+}
+
+// SearchCandidates returns the files linked (through the /documents/ redirects) from the searchURL page,
+// with their name matching pattern, sorted by Date then Filename.
+//
+// ErrNotFound is returned if there is no such file, joined with the errors of the failed candidates.
+func SearchCandidates(ctx context.Context, searchURL, pattern string, opts ...Option) (_ []Candidate, err error) {
 	if searchURL == DefaultXLSXURL {
-		return searchURL, nil
+		return []Candidate{newCandidate(searchURL)}, nil
 	}
+	o := newOptions(opts)
+	ctx, end := o.span(ctx, "SearchCandidates", slog.String("url", searchURL))
+	defer func() { err = inStage(ErrDiscovery, err); end(err) }()
 	noRedir := http.Client{
+		Transport: o.probeTransport(),
 		CheckRedirect: func(req *http.Request, via []*http.Request) error {
 			return http.ErrUseLastResponse
 		},
@@ -55,17 +77,17 @@ func SearchXLSURL(ctx context.Context, searchURL, pattern string) (string, error
 
 	req, err := http.NewRequest("GET", searchURL, nil)
 	if err != nil {
-		return "", fmt.Errorf("%s: %w", searchURL, err)
+		return nil, fmt.Errorf("%s: %w", searchURL, err)
 	}
-	resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+	resp, err := o.do(ctx, o.client(), req)
 	if err != nil {
-		return "", fmt.Errorf("%s: %w", searchURL, err)
+		return nil, err
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode > 399 {
 		var buf strings.Builder
 		_, _ = io.Copy(&buf, resp.Body)
-		return "", fmt.Errorf("%q: %s: %s", searchURL, resp.Status, buf.String())
+		return nil, fmt.Errorf("%q: %s: %s", searchURL, resp.Status, buf.String())
 	}
 
 	logger := zlog.SFromContext(ctx)
@@ -82,7 +104,7 @@ Loop:
 			if errors.Is(err, io.EOF) {
 				break Loop
 			}
-			return "", err
+			return nil, err
 
 		case html.StartTagToken:
 			if hasAttr && bytes.Equal(tagName, []byte("a")) {
@@ -102,17 +124,21 @@ Loop:
 	}
 	resp.Body.Close()
 
-	strategy := retry.Strategy{Delay: time.Second, MaxDelay: 10 * time.Second, Factor: 1.25, MaxCount: 3}
 	rPattern := regexp.MustCompile(pattern)
-	resultsCh := make(chan string, 1024)
+	// mu protects results and errs
+	var mu sync.Mutex
+	var results []Candidate
 	errs := make([]error, 0, len(candidates))
 	grp, ctx := errgroup.WithContext(ctx)
-	grp.SetLimit(8)
+	grp.SetLimit(o.probeConcurrency)
 	for _, v := range candidates {
 		grp.Go(func() error {
 			sub, err := url.Parse(v)
 			if err != nil {
 				logger.Warn("wrong url", "url", string(v), "error", err)
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("%q: %w", v, err))
+				mu.Unlock()
 				return nil
 			}
 			if !(sub.Scheme != "" && sub.IsAbs()) {
@@ -121,42 +147,25 @@ Loop:
 			u := sub
 			logger := logger.With("url", u.String())
 			logger.Debug("try")
-			if false {
-				u := resp.Request.URL.ResolveReference(sub)
-				if u.Scheme == "" {
-					return nil
-				}
-			}
-			req, err := http.NewRequest("GET", u.String(), nil)
+			loc, err := o.probe(ctx, &noRedir, u.String())
 			if err != nil {
-				return fmt.Errorf("%s: %w", u.String(), err)
-			}
-			var resp *http.Response
-			for iter := strategy.Start(); ; {
-				if resp, err = noRedir.Do(req.WithContext(ctx)); err != nil {
-					errs = append(errs, fmt.Errorf("%s: %w", u.String(), err))
-				} else {
-					break
-				}
-				if !iter.Next(ctx.Done()) {
-					return nil
-				}
-			}
-			defer resp.Body.Close()
-			if resp.StatusCode != 302 {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
 				return nil
 			}
-			loc := resp.Header.Get("Location")
 			bn := path.Base(loc)
-			logger.Debug("got", "statusCode", resp.StatusCode, "location", loc)
-			if bn == "" {
+			logger.Debug("got", "location", loc)
+			if loc == "" || bn == "" {
 				return nil
 			}
 			if rPattern.MatchString(bn) {
-				select {
-				case resultsCh <- loc:
-				default:
+				if locURL, err := u.Parse(loc); err == nil {
+					loc = locURL.String()
 				}
+				mu.Lock()
+				results = append(results, newCandidate(loc))
+				mu.Unlock()
 			} else if strings.Contains(bn, "EHT") {
 				logger.Warn("no match", "pat", rPattern, "loc", loc, "base", bn)
 			}
@@ -164,207 +173,151 @@ Loop:
 		})
 	}
 	if err := grp.Wait(); err != nil {
-		return "", err
-	}
-	close(resultsCh)
-	var results []string
-	for s := range resultsCh {
-		results = append(results, s)
+		return nil, err
 	}
 	if len(results) == 0 {
-		return "", fmt.Errorf("%w: %w", ErrNotFound, errors.Join(errs...))
+		return nil, fmt.Errorf("%w: %w", ErrNotFound, errors.Join(errs...))
 	}
-	sort.Slice(results, func(i, j int) bool {
-		return path.Base(results[i]) < path.Base(results[j])
-	})
-	logger.Debug("SearchXLSXURL", "results", results)
-	return results[len(results)-1], nil
+	if len(errs) != 0 {
+		logger.Warn("some candidates failed", "error", errors.Join(errs...))
+	}
+	sortCandidates(results)
+	logger.Debug("SearchCandidates", "results", results)
+	return results, nil
 }
 
-// Parse the reader.
+// Parse the reader, in the format detected from its contents (see DetectFormat).
+// From a ZIP archive, the file matching DefaultPattern (or the first spreadsheet, PDF or HTML) is parsed.
 //
-// Pass nil as reader to get the default XLSX.
-func Parse(ctx context.Context, r io.Reader) ([]Hitelezo, error) {
+// Pass nil as reader to get the default XLSX (see WithUpstreams for the alternatives,
+// and WithFallback for the offline case).
+func Parse(ctx context.Context, r io.Reader, opts ...Option) (hs []Hitelezo, err error) {
+	o := newOptions(opts)
+	ctx, end := o.span(ctx, "Parse")
+	defer func() { end(err, slog.Int("records", len(hs))) }()
 	if r == nil {
-		_, rc, err := DownloadFile(ctx, DefaultXLSXURL)
-		if err != nil {
-			return nil, err
-		}
-		defer rc.Close()
-		r = rc
+		return o.parseUpstreams(ctx, opts)
 	}
-	sr, err := iohlp.MakeSectionReader(r, 1<<20)
+	sr, err := o.sectionReader(r)
 	if err != nil {
 		return nil, err
 	}
-	var a [1024]byte
-	n, err := sr.ReadAt(a[:], 0)
-	if err != nil {
+	if err = o.source.setContent(sr); err != nil {
 		return nil, err
 	}
-	b := a[:n]
-	logger := zlog.SFromContext(ctx)
-	//logger.Debug("Parse", "prefix", string(b))
-	if bytes.HasPrefix(b, []byte("%PDF-1")) {
-		return ParsePDF(ctx, sr)
+	format := DetectFormat(sr)
+	if format == FormatZIP {
+		if sr, err = o.openZIP(sr); err != nil {
+			return nil, err
+		}
+		format = DetectFormat(sr)
 	}
-
-	hit, err := ParseXLSX(ctx, io.NewSectionReader(sr, 0, sr.Size()))
-	logger.Info("ParseXLSX", "hitelezok", len(hit), "error", err)
-	if err != nil &&
-		(strings.Contains(err.Error(), "not a valid zip") ||
-			strings.Contains(err.Error(), "unsupported")) {
-		hit, err = ParseXLS(ctx, sr)
-	}
-	for i := 0; i < len(hit); i++ {
-		if hit[i].Bankszerv == "" || hit[i].Nev == "" || (hit[i].Irszam == "" && hit[i].Cim == "") {
-			hit[i] = hit[len(hit)-1]
-			hit = hit[:len(hit)-1]
-			i--
+	if format == "" || format == FormatZIP {
+		return nil, ErrFormatUnknown
+	}
+	if err = o.checkFormat(format); err != nil {
+		return nil, err
+	}
+	o.source.setFormat(format)
+	var hit []Hitelezo
+	switch format {
+	case FormatPDF:
+		hit, err = ParsePDF(ctx, sr, opts...)
+	case FormatHTML:
+		hit, err = ParseHTML(ctx, io.NewSectionReader(sr, 0, sr.Size()), opts...)
+	case FormatXLSX:
+		hit, err = ParseXLSX(ctx, io.NewSectionReader(sr, 0, sr.Size()), opts...)
+	case FormatXLS:
+		hit, err = ParseXLS(ctx, io.NewSectionReader(sr, 0, sr.Size()), opts...)
+	case FormatCSV:
+		var records []Hitelezo
+		records, err = ReadCSV(newUTF8Reader(io.NewSectionReader(sr, 0, sr.Size())))
+		o.resetOrigins()
+		for _, h := range records {
+			o.origin.Row++
+			hit = o.checkAppend(hit, h)
 		}
+	default:
+		return nil, fmt.Errorf("%s is not supported: %w", format, ErrFormatUnknown)
 	}
-	return hit, err
-}
-func ParsePDF(ctx context.Context, r io.Reader) ([]Hitelezo, error) {
-	logger := zlog.SFromContext(ctx)
-	var buf bytes.Buffer
-	hit, err := parsePDFTabula(ctx, io.TeeReader(r, &buf))
-	logger.Info("parsePDFTabula", "hit", len(hit), "error", err)
+	zlog.SFromContext(ctx).Info("Parse", "format", format, "hitelezok", len(hit), "error", err)
+	hit = o.filter(hit)
 	if err == nil {
-		return hit, nil
+		hit, err = o.dedup(hit)
 	}
-
-	return parsePDFPdfToText(ctx, io.MultiReader(bytes.NewReader(buf.Bytes()), r))
+	if err == nil && o.changes != nil {
+		o.diffSnapshot(hit)
+	}
+	o.parsed(hit, err)
+	return o.result(hit, err)
 }
 
-func parsePDFTabula(ctx context.Context, r io.Reader) ([]Hitelezo, error) {
-	logger := zlog.SFromContext(ctx)
-	logger.Info("ParsePDF tabula")
-	dir, err := os.MkdirTemp("", "giro-*")
-	if err != nil {
-		return nil, fmt.Errorf("create temp dir: %w", err)
+// ParsePDF extracts the records from the PDF with the external commands of the PDFStrategies.
+//
+// ErrUnsupportedInBuild is returned when the package is built without them (see the giro_noexec build tag).
+func ParsePDF(ctx context.Context, r io.Reader, opts ...Option) (hs []Hitelezo, err error) {
+	o := newOptions(opts)
+	ctx, end := o.span(ctx, "ParsePDF", slog.String("format", string(FormatPDF)))
+	defer func() { err = inStage(ErrPDFExtraction, err); end(err, slog.Int("records", len(hs))) }()
+	if !execSupported {
+		return nil, fmt.Errorf("ParsePDF: %w", ErrUnsupportedInBuild)
 	}
-	defer os.RemoveAll(dir)
-
-	const tabulaJarURL = "https://github.com/tabulapdf/tabula-java/releases/download/v1.0.5/tabula-1.0.5-jar-with-dependencies.jar"
-	ucd, _ := os.UserCacheDir()
-	cache, err := filecache.Open(filepath.Join(ucd, "giro"))
+	logger := zlog.SFromContext(ctx)
+	// both strategies read the whole PDF, which is kept in memory up to the budget
+	sr, err := o.sectionReader(r)
 	if err != nil {
 		return nil, err
 	}
-	actionID := filecache.ActionID([]byte(tabulaJarURL))
-	var rc io.ReadCloser
-	if fn, _, _ := cache.GetFile(actionID); fn != "" {
-		if fh, err := os.Open(fn); err == nil {
-			defer fh.Close()
-			rc = fh
-		}
-	}
-	if rc == nil {
-		resp, err := http.Get(tabulaJarURL)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode >= 400 {
-			return nil, fmt.Errorf("%s: %s", resp.Request.URL, resp.Status)
-		}
-		b, err := io.ReadAll(resp.Body)
-		resp.Body.Close()
-		if err != nil {
+	pages := 0
+	if o.parallelPages > 0 {
+		if pages, err = pdfPageCount(io.NewSectionReader(sr, 0, sr.Size())); err != nil {
 			return nil, err
 		}
-		if _, _, err = cache.Put(actionID, bytes.NewReader(b)); err != nil {
-			return nil, err
-		}
-		rc = struct {
-			io.Reader
-			io.Closer
-		}{bytes.NewReader(b), resp.Body}
-	}
-
-	jarFn := filepath.Join(dir, "tabula.jar")
-	if fh, err := os.OpenFile(jarFn, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0400); err != nil {
-		return nil, fmt.Errorf("write jar file: %w", err)
-	} else if _, err = io.Copy(fh, rc); err != nil {
-		fh.Close()
-		return nil, err
-	} else if err = fh.Close(); err != nil {
-		return nil, err
-	}
-	pdfFh, err := os.Create(filepath.Join(dir, "x.pdf"))
-	if err != nil {
-		return nil, fmt.Errorf("create temp pdf: %w", err)
-	}
-	if _, err = io.Copy(pdfFh, r); err != nil {
-		return nil, fmt.Errorf("write temp pdf: %w", err)
 	}
-	if _, err = pdfFh.Seek(0, 0); err != nil {
-		return nil, fmt.Errorf("seek %q: %w", pdfFh.Name(), err)
+	strategies := o.strategies()
+	if len(strategies) == 0 {
+		return nil, errors.New("all the PDF strategies are disabled")
 	}
-	cmd := exec.CommandContext(ctx, "java", "-jar", jarFn, "-l", "-p", "all", "-f", "CSV", pdfFh.Name())
-	cmd.Stdin = pdfFh
-	cmd.Stderr = os.Stderr
-	pr, err := cmd.StdoutPipe()
-	if err != nil {
-		return nil, fmt.Errorf("create stdout pipe: %w", err)
-	}
-	logger.Debug("start", "args", cmd.Args)
-	if err := cmd.Start(); err != nil {
-		return nil, fmt.Errorf("start %v: %w", cmd.Args, err)
-	}
-	cr := csv.NewReader(pr)
-	var hit []Hitelezo
-	for {
-		row, err := cr.Read()
+	errs := make([]error, 0, len(strategies))
+	for _, s := range strategies {
+		parse, err := s.parser()
 		if err != nil {
-			if errors.Is(err, io.EOF) {
-				break
-			}
-			return hit, fmt.Errorf("read csv: %w", err)
+			return nil, err
 		}
-		hit = append(hit, Hitelezo{
-			Bankszerv: row[0], Nev: row[1], Irszam: row[2], Cim: row[3],
-		})
-	}
-	return hit, cmd.Wait()
-}
-
-func parsePDFPdfToText(ctx context.Context, r io.Reader) ([]Hitelezo, error) {
-	logger := zlog.SFromContext(ctx)
-	logger.Info("ParsePDF pdftotext")
-	cmd := exec.CommandContext(ctx, "pdftotext", "-", "-")
-	cmd.Stdin = r
-	pr, err := cmd.StdoutPipe()
-	if err != nil {
-		return nil, err
-	}
-	if err := cmd.Start(); err != nil {
-		return nil, fmt.Errorf("%v: %w", cmd.Args, err)
-	}
-	hit, err := parseTXT(ctx, pr)
-	if waitErr := cmd.Wait(); waitErr != nil {
+		start := time.Now()
+		if pages > o.parallelPages {
+			hs, err = o.parsePDFChunks(ctx, sr, pages, parse)
+		} else {
+			hs, err = parse(ctx, io.NewSectionReader(sr, 0, sr.Size()), o)
+		}
+		o.report.attempt(PDFAttempt{Strategy: s, Duration: time.Since(start), Records: len(hs), Err: err})
+		logger.Info("ParsePDF", "strategy", s, "pages", pages, "hit", len(hs), "dur", time.Since(start).String(), "error", err)
 		if err == nil {
-			err = fmt.Errorf("%v: %w", cmd.Args, waitErr)
+			return hs, nil
 		}
+		errs = append(errs, fmt.Errorf("%s: %w", s, err))
 	}
-	return hit, err
+	// the partial results of the last strategy
+	return o.result(hs, errors.Join(errs...))
 }
 
-func parseTXT(ctx context.Context, r io.Reader) ([]Hitelezo, error) {
+func parseTXT(ctx context.Context, r io.Reader, o *options) ([]Hitelezo, error) {
 	logger := zlog.SFromContext(ctx)
-	scanner := bufio.NewScanner(r)
+	scanner := bufio.NewScanner(newUTF8Reader(r))
 	records := make([]Hitelezo, 0, 8192)
 	lines := make([]string, 0, 4*32)
+	o.resetOrigins()
+	o.origin = RowOrigin{Page: 1}
 	processLines := func() {
 		cols := len(lines) / 4
 		for i := 0; i < cols; i++ {
+			o.origin.Row = i + 1
 			//Log(i, lines[i:i+4])
 			h := Hitelezo{
 				Bankszerv: lines[0*cols+i], Nev: lines[1*cols+i], Irszam: lines[2*cols+i], Cim: lines[3*cols+i],
 			}
 			logger.Debug("processLines", "line", lines, "record", h)
-			records = checkAppend(records, h)
+			records = o.checkAppend(records, h)
 		}
 		lines = lines[:0]
 	}
@@ -374,6 +327,9 @@ func parseTXT(ctx context.Context, r io.Reader) ([]Hitelezo, error) {
 		if len(line) == 0 {
 			continue
 		}
+		if len(records) == 0 && len(lines) < 4 {
+			o.source.setTitleDate(string(line))
+		}
 		if !numberSeen {
 			if numberSeen = '0' <= line[0] && line[0] <= '9'; !numberSeen {
 				continue
@@ -387,6 +343,7 @@ func parseTXT(ctx context.Context, r io.Reader) ([]Hitelezo, error) {
 
 		if line[0] == 12 { // Ctrl-L
 			processLines()
+			o.origin.Page++
 			rest := line[1:]
 
 			if len(rest) == 0 {
@@ -396,90 +353,170 @@ func parseTXT(ctx context.Context, r io.Reader) ([]Hitelezo, error) {
 		lines = append(lines, string(bytes.TrimSpace(line)))
 	}
 	processLines()
-	return records, nil
+	return o.result(records, scanner.Err())
+}
+
+// ParseXLSX parses the first sheet of the XLSX,
+// or the one selected by WithSheet, WithSheetPattern or WithSheetAutoDetect.
+//
+// The header row is found by matching the known Hungarian and English column names
+// (ignoring case, accents and small typos), and the columns are mapped by it;
+// without a header the columns are read as Bankszerv, Nev, Irszam, Cim.
+func ParseXLSX(ctx context.Context, r io.Reader, opts ...Option) (hs []Hitelezo, err error) {
+	o := newOptions(opts)
+	ctx, end := o.span(ctx, "ParseXLSX", slog.String("format", string(FormatXLSX)))
+	defer func() { err = inStage(ErrSpreadsheet, err); end(err, slog.Int("records", len(hs))) }()
+	err = parseXLSX(ctx, r, o, func(h Hitelezo) error {
+		hs = append(hs, h)
+		return nil
+	})
+	return o.result(hs, err)
 }
-func ParseXLSX(ctx context.Context, r io.Reader) ([]Hitelezo, error) {
+
+// ParseXLSXFunc parses the XLSX like ParseXLSX, but calls sink with each record
+// (filtered as by Parse) instead of collecting them,
+// so the memory use does not grow with the number of records.
+//
+// The worksheet is read by excelize's streaming row reader,
+// spilling the large worksheets to temporary files.
+// An error returned by sink stops the parsing, and is returned.
+func ParseXLSXFunc(ctx context.Context, r io.Reader, sink func(Hitelezo) error, opts ...Option) error {
+	return parseXLSXFunc(ctx, "ParseXLSXFunc", r, func(rec Record) error { return sink(rec.Hitelezo) }, opts)
+}
+
+// parseXLSXFunc is ParseXLSXFunc and ParseXLSXRecordsFunc, in the span of name.
+func parseXLSXFunc(ctx context.Context, name string, r io.Reader, sink func(Record) error, opts []Option) (err error) {
+	o := newOptions(opts)
+	ctx, end := o.span(ctx, name, slog.String("format", string(FormatXLSX)))
+	var n int
+	var sinkErr error
+	defer func() {
+		if err != sinkErr {
+			err = inStage(ErrSpreadsheet, err)
+		}
+		end(err, slog.Int("records", n))
+	}()
+	o.origins = nil // the sink gets the records one by one
+	return parseXLSX(ctx, r, o, func(h Hitelezo) error {
+		if !o.keep(h) {
+			return nil
+		}
+		n++
+		sinkErr = sink(Record{Hitelezo: h, Origin: o.origin})
+		return sinkErr
+	})
+}
+
+// streamXMLSizeLimit is the size of the worksheets above which
+// excelize spills them to temporary files, instead of keeping them in memory.
+const streamXMLSizeLimit = 1 << 20
+
+// parseXLSX parses the first sheet of the XLSX, calling sink with each record.
+func parseXLSX(ctx context.Context, r io.Reader, o *options, sink func(Hitelezo) error) error {
 	logger := zlog.SFromContext(ctx)
 	logger.Info("ParseXLSX")
-	wb, err := excelize.OpenReader(r)
+	o.source.setBackend("excelize")
+	wb, err := excelize.OpenReader(r, excelize.Options{UnzipXMLSizeLimit: streamXMLSizeLimit})
 	if err != nil {
-		return nil, err
+		return err
 	}
-	rows, err := wb.Rows(wb.GetSheetName(0))
+	defer wb.Close()
+	sheet, err := o.selectSheet(wb)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	records := make([]Hitelezo, 0, 8192)
-	var headerSkipped, noIrszam bool
+	rows, err := wb.Rows(sheet)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	o.resetOrigins()
+	o.origin = RowOrigin{Sheet: sheet}
+	var n int
+	hdr := o.newHeaderScanner()
 	var rec Hitelezo
-	dst := []*string{&rec.Bankszerv, &rec.Nev, &rec.Irszam, &rec.Cim}
+	var errs []error
 	for rows.Next() {
+		o.origin.Row++
 		row, err := rows.Columns()
 		if err != nil {
-			break
-		}
-		if !headerSkipped {
-			headerSkipped = true
-			// Branch office code
-			// BIC code
-			// Name of the branch office
-			// Address of the branch office
-			// Branch office may send VIBER items
-			// Branch office may receive VIBER items
-			// logger.Info("header", "row", strings.Join(row, ", "))
-			if noIrszam = row[3] == "Address of the branch office"; noIrszam {
-				dst = append(dst[:0],
-					&rec.Bankszerv, &rec.BIC, &rec.Nev, &rec.Cim)
-				// logger.Warn("sht.xlsx", "dst", dst)
+			if err = o.rowError(&errs, fmt.Errorf("%s: %w", o.origin, err)); err != nil {
+				return err
 			}
 			continue
 		}
-		for j, p := range dst {
-			*p = row[j]
+		cdv, ok := hdr.record(&rec, row)
+		if !ok {
+			continue
 		}
-		records = checkAppend(records, rec)
-		select {
-		case <-ctx.Done():
-			return records, ctx.Err()
-		default:
+		// numeric cells may be formatted in various ways
+		if hdr.cdvCol >= 0 {
+			rec.Bankszerv = cellToCode(rec.Bankszerv, 7)
+			o.applyCDV(&rec, cellToCode(cdv, 1))
+		} else {
+			rec.Bankszerv = cellToCode(rec.Bankszerv, 8)
+		}
+		rec.Irszam = cellToCode(rec.Irszam, 0)
+		if h, ok := o.check(rec); ok {
+			n++
+			if err := sink(h); err != nil {
+				return err
+			}
+		}
+		if err := ctx.Err(); err != nil {
+			return err
 		}
 	}
-	logger.Info("ParseXLSX", "records", len(records))
-	return records, nil
+	logger.Info("ParseXLSX", "records", n)
+	return errors.Join(append(errs, rows.Error())...)
 }
 
-func ParseXLS(ctx context.Context, r io.ReadSeeker) ([]Hitelezo, error) {
+func ParseXLS(ctx context.Context, r io.ReadSeeker, opts ...Option) (hs []Hitelezo, err error) {
+	o := newOptions(opts)
+	ctx, end := o.span(ctx, "ParseXLS", slog.String("format", string(FormatXLS)))
+	defer func() { err = inStage(ErrSpreadsheet, err); end(err, slog.Int("records", len(hs))) }()
 	logger := zlog.SFromContext(ctx)
 	logger.Info("ParseXLS")
+	o.source.setBackend("xls")
 	wb, err := xls.OpenReader(r, "utf8")
 	if err != nil {
 		logger.Error("xls open", "r", r, "error", err)
 		if _, err = r.Seek(0, 0); err != nil {
 			return nil, err
 		}
-		return ParseXLSX(ctx, r)
+		return ParseXLSX(ctx, r, opts...)
 	}
 	sheet := wb.GetSheet(0)
 	if sheet == nil {
 		return nil, fmt.Errorf("this XLS file does not contain sheet no %d", 0)
 	}
+	o.resetOrigins()
+	o.origin = RowOrigin{Sheet: sheet.Name}
 	records := make([]Hitelezo, 0, 8192)
-	const skip = 1
+	hdr := o.newHeaderScanner()
 	var rec Hitelezo
-	dst := []*string{&rec.Bankszerv, &rec.Nev, &rec.Irszam, &rec.Cim}
+	var cells []string
 	for n := 0; n < int(sheet.MaxRow); n++ {
 		row := sheet.Row(n)
-		if n < skip || row == nil {
+		if row == nil {
 			continue
 		}
-		off := row.FirstCol()
-		for j, p := range dst {
-			*p = row.Col(off + j)
+		o.origin.Row = n + 1
+		cells = cells[:0]
+		for j := row.FirstCol(); j < row.LastCol(); j++ {
+			cells = append(cells, fixLatin2(row.Col(j)))
 		}
-		records = checkAppend(records, rec)
+		cdv, ok := hdr.record(&rec, cells)
+		if !ok {
+			continue
+		}
+		if hdr.cdvCol >= 0 {
+			o.applyCDV(&rec, cdv)
+		}
+		records = o.checkAppend(records, rec)
 		select {
 		case <-ctx.Done():
-			return records, ctx.Err()
+			return o.result(records, ctx.Err())
 		default:
 		}
 	}
@@ -487,49 +524,112 @@ func ParseXLS(ctx context.Context, r io.ReadSeeker) ([]Hitelezo, error) {
 	return records, nil
 }
 
-// 10002003	Magyar Államkincstár. értékp.-pénztár	1139	Budapest, Váci út 71.
-type Hitelezo struct {
-	Bankszerv, BIC, Nev, Irszam, Cim string
-}
+// Hitelezo is the record of a branch (see model.Hitelezo).
+type Hitelezo = model.Hitelezo
 
-func (h Hitelezo) String() string {
-	return fmt.Sprintf("%s=%q (%s) %s", h.Bankszerv, h.Nev, h.Irszam, h.Cim)
+func (o *options) checkAppend(records []Hitelezo, rec Hitelezo) []Hitelezo {
+	if rec, ok := o.check(rec); ok {
+		records = append(records, rec)
+	}
+	return records
 }
 
-func checkAppend(records []Hitelezo, rec Hitelezo) []Hitelezo {
-	for _, p := range []*string{&rec.Bankszerv, &rec.Nev, &rec.Irszam, &rec.Cim} {
-		*p = strings.TrimSpace(strings.ReplaceAll(*p, "\x00", ""))
-	}
-	if rec.Irszam == "" && len(rec.Cim) > 5 {
-		if i := strings.IndexByte(rec.Cim, ' '); i == 4 &&
-			strings.IndexFunc(rec.Cim[:4],
-				func(r rune) bool { return !('0' <= r && r <= '9') },
-			) < 0 {
-			rec.Irszam, rec.Cim = rec.Cim[:4], rec.Cim[5:]
+// check cleans and normalizes rec, and reports whether it is a record to keep,
+// recording its origin if asked for.
+func (o *options) check(rec Hitelezo) (Hitelezo, bool) {
+	rec.Bankszerv = cleanField(rec.Bankszerv)
+	rec.Nev = cleanField(rec.Nev)
+	rec.Irszam = cleanField(rec.Irszam)
+	rec.Cim = cleanField(rec.Cim)
+	o.normalization.normalize(&rec)
+	if rec.Irszam == "" {
+		if i := strings.IndexByte(rec.Cim, ' '); i > 0 && i < len(rec.Cim)-1 &&
+			o.validIrszam(rec.Cim[:i]) {
+			rec.Irszam, rec.Cim = rec.Cim[:i], rec.Cim[i+1:]
 		}
 	}
-	// fmt.Printf("checkAppend rec=%q\n", rec)
-	if rec != (Hitelezo{}) && len(rec.Bankszerv) == 8 {
-		records = append(records, rec)
+	rec.NonStdIrszam = rec.Irszam != "" && !o.validIrszam(rec.Irszam)
+	if o.normalization&NormalizeFold != 0 {
+		rec.SearchKey = Fold(rec.Nev + " " + rec.Cim)
 	}
-	return records
+	if rec == (Hitelezo{}) || len(rec.Bankszerv) != 8 {
+		return rec, false
+	}
+	if o.origins != nil {
+		*o.origins = append(*o.origins, o.origin)
+	}
+	return rec, true
+}
+
+// cleanField removes the NUL bytes and the surrounding whitespace from s.
+//
+// It is equivalent to strings.TrimSpace(strings.ReplaceAll(s, "\x00", "")),
+// but allocates only when there is a NUL byte inside the trimmed value.
+func cleanField(s string) string {
+	s = strings.TrimFunc(s, func(r rune) bool { return r == 0 || unicode.IsSpace(r) })
+	if strings.IndexByte(s, 0) < 0 {
+		return s
+	}
+	return strings.ReplaceAll(s, "\x00", "")
 }
 
-func DownloadFile(ctx context.Context, dlURL string) (string, io.ReadCloser, error) {
+// DownloadFile downloads the file at dlURL,
+// returning its name from the Content-Disposition header (if any) and its contents.
+//
+// An HTML page received instead of the spreadsheet or PDF expected by the name
+// (such as a maintenance page) is reported as ErrUnexpectedContent,
+// thus Parse(ctx, nil) fails over to the next Upstream.
+func DownloadFile(ctx context.Context, dlURL string, opts ...Option) (_ string, _ io.ReadCloser, err error) {
+	o := newOptions(opts)
+	ctx, end := o.span(ctx, "DownloadFile", slog.String("url", dlURL))
+	defer func() { err = inStage(ErrDownload, err); end(err) }()
 	logger := zlog.SFromContext(ctx)
 	logger.Info("DownloadFile", "url", dlURL)
 	req, err := http.NewRequest("GET", dlURL, nil)
 	if err != nil {
 		return "", nil, fmt.Errorf("%s: %w", dlURL, err)
 	}
-	resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+	resp, err := o.do(ctx, o.client(), req)
 	if err != nil {
-		return "", nil, fmt.Errorf("%s: %w", dlURL, err)
+		return "", nil, err
+	}
+	filename := dispositionFilename(resp)
+	o.source.setResponse(resp, filename)
+	var body io.ReadCloser = resp.Body
+	if o.progress != nil {
+		body = &progressReader{ReadCloser: resp.Body, progress: o.progress, total: resp.ContentLength}
 	}
-	cd := resp.Header.Get("Content-Disposition")
-	var filename string
-	if _, params, err := mime.ParseMediaType(cd); err == nil {
-		filename = params["filename"]
+	sniffed, err := checkContent(resp, filename, body)
+	if err != nil {
+		body.Close()
+		return filename, nil, err
+	}
+	body = struct {
+		io.Reader
+		io.Closer
+	}{sniffed, body}
+	if o.archive == nil {
+		return filename, body, nil
+	}
+	defer body.Close()
+	lastModified, _ := http.ParseTime(resp.Header.Get("Last-Modified"))
+	e, err := o.archive.Add(body, ArchiveEntry{
+		URL: resp.Request.URL.String(), Filename: filename, LastModified: lastModified,
+	})
+	if err != nil {
+		return filename, nil, err
+	}
+	fh, err := o.archive.Open(e.SHA256)
+	if err != nil {
+		return filename, nil, err
+	}
+	return filename, fh, nil
+}
+
+// dispositionFilename returns the file name of the Content-Disposition header, if any.
+func dispositionFilename(resp *http.Response) string {
+	if _, params, err := mime.ParseMediaType(resp.Header.Get("Content-Disposition")); err == nil {
+		return params["filename"]
 	}
-	return filename, resp.Body, nil
+	return ""
 }
@@ -5,11 +5,16 @@
 package giro
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"testing/iotest"
 	"time"
 )
 
@@ -34,7 +39,7 @@ func TestParsePDF(t *testing.T) {
 
 	fh, err := os.Open(filepath.Join("testdata", "EHT_20210401.txt"))
 	if err == nil {
-		hs, err := parseTXT(ctx, fh)
+		hs, err := parseTXT(ctx, fh, newOptions(nil))
 		fh.Close()
 		if err != nil {
 			t.Error(err)
@@ -63,8 +68,62 @@ func checkHs(t *testing.T, hs []Hitelezo) {
 		if len(h.Bankszerv) != 8 {
 			t.Errorf("%d. bankszerv=%q", i, h.Bankszerv)
 		}
-		if len(h.Irszam) != 4 {
+		if len(h.Irszam) != 4 && !h.NonStdIrszam {
 			t.Errorf("%d. irszam=%q", i, h.Irszam)
 		}
 	}
 }
+
+func TestCleanField(t *testing.T) {
+	for _, s := range []string{
+		"", "abc", " abc ", "\x00abc\x00", "a\x00b", " \x00 a \x00 b \x00 ", "\t1139\n",
+	} {
+		want := strings.TrimSpace(strings.ReplaceAll(s, "\x00", ""))
+		if got := cleanField(s); got != want {
+			t.Errorf("cleanField(%q)=%q, wanted %q", s, got, want)
+		}
+	}
+}
+
+func BenchmarkCheckAppend(b *testing.B) {
+	rec := Hitelezo{Bankszerv: "10002003", Nev: "Magyar Államkincstár. értékp.-pénztár", Cim: "1139 Budapest, Váci út 71."}
+	records := make([]Hitelezo, 0, 1)
+	o := newOptions(nil)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		records = o.checkAppend(records[:0], rec)
+	}
+}
+
+func TestErrorPolicy(t *testing.T) {
+	ctx := context.Background()
+	b, err := os.ReadFile(filepath.Join("testdata", "EHT_20210401.txt"))
+	if err != nil {
+		t.Skip(err)
+	}
+	errBroken := errors.New("broken")
+	r := func() io.Reader {
+		return io.MultiReader(bytes.NewReader(b[:len(b)/2]), iotest.ErrReader(errBroken))
+	}
+	hs, err := parseTXT(ctx, r(), newOptions(nil))
+	if !errors.Is(err, errBroken) || len(hs) == 0 {
+		t.Errorf("best effort: got %d records, %+v", len(hs), err)
+	}
+	hs, err = parseTXT(ctx, r(), newOptions([]Option{WithErrorPolicy(FailFast)}))
+	if !errors.Is(err, errBroken) || hs != nil {
+		t.Errorf("fail fast: got %d records, %+v", len(hs), err)
+	}
+
+	var buf bytes.Buffer
+	if err = WriteXLSX(&buf, []Hitelezo{{Bankszerv: "10002003", Nev: "Magyar Államkincstár", Irszam: "1139", Cim: "Budapest"}}); err != nil {
+		t.Fatal(err)
+	}
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	for _, policy := range []ErrorPolicy{BestEffort, FailFast} {
+		hs, err := ParseXLSX(cctx, bytes.NewReader(buf.Bytes()), WithErrorPolicy(policy))
+		if !errors.Is(err, context.Canceled) || (policy == FailFast) != (hs == nil) {
+			t.Errorf("%d: got %d records, %+v", policy, len(hs), err)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/UNO-SOFT/giro/model"
+)
+
+// hitelezoColumns maps the known header words (lowercase, ASCII) to the Hitelezo fields,
+// the first match wins: "postal code" is Irszam, "name of the branch office" is Nev.
+var hitelezoColumns = []struct {
+	field string
+	words []string
+}{
+	{FieldBIC, []string{"bic", "swift"}},
+	{FieldIrszam, []string{"iranyitoszam", "irszam", "irsz", "postal", "zip", "postcode"}},
+	{FieldCim, []string{"cim", "address"}},
+	{FieldNev, []string{"nev", "megnevezes", "name"}},
+	{FieldBankszerv, []string{"bankszerv", "fiokkod", "kod", "code", "azonosito"}},
+}
+
+// headerField returns the Hitelezo field of the header cell, or "".
+//
+// The words of the cell are compared to the known words ignoring case and accents,
+// allowing one typo (or swapped letters) in the words of at least 5 letters,
+// and the compound words ("neve" for "nev", "bankkód" for "kód").
+func headerField(h string) string {
+	words := headerWords(h)
+	for _, c := range hitelezoColumns {
+		for _, known := range c.words {
+			for _, w := range words {
+				if w == known ||
+					strings.Contains(w, known) ||
+					len(known) >= 5 && len(w) >= 5 && editDistance(w, known) <= 1 {
+					return c.field
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// headerWords returns the lowercase ASCII words of the header cell.
+func headerWords(h string) []string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(h) {
+		if s, ok := HungarianToASCII[r]; ok {
+			b.WriteString(strings.ToLower(s))
+		} else if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte(' ')
+		}
+	}
+	return strings.Fields(b.String())
+}
+
+// editDistance returns the optimal string alignment distance of the ASCII strings:
+// the number of insertions, deletions, substitutions and transpositions.
+func editDistance(a, b string) int {
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(a)][len(b)]
+}
+
+// WithColumns maps the header names to the Hitelezo fields (FieldBankszerv, FieldBIC, FieldNev, FieldIrszam, FieldCim),
+// for the layouts not recognized by the built-in names, or to override them.
+//
+// The names are compared ignoring case, accents and the repeated whitespace,
+// and take precedence over the built-in names. An empty field ignores the column.
+func WithColumns(columns map[string]string) Option {
+	return func(o *options) {
+		if o.columns == nil {
+			o.columns = make(map[string]string, len(columns))
+		}
+		for h, field := range columns {
+			o.columns[Fold(h)] = field
+		}
+	}
+}
+
+// isHitelezoHeader reports whether the row is a header with at least the Bankszerv and Nev columns.
+func isHitelezoHeader(row []string, columns map[string]string) bool {
+	_, ok := headerMapping(row, columns)
+	return ok
+}
+
+// columnMapping maps the columns of a row to the Hitelezo fields, nil for the ignored ones.
+type columnMapping []func(*Hitelezo) *string
+
+var (
+	fieldBankszerv = func(h *Hitelezo) *string { return &h.Bankszerv }
+	fieldBIC       = func(h *Hitelezo) *string { return &h.BIC }
+	fieldNev       = func(h *Hitelezo) *string { return &h.Nev }
+	fieldIrszam    = func(h *Hitelezo) *string { return &h.Irszam }
+	fieldCim       = func(h *Hitelezo) *string { return &h.Cim }
+)
+
+// defaultMapping is the column order of the EHT, used when there is no header.
+var defaultMapping = columnMapping{fieldBankszerv, fieldNev, fieldIrszam, fieldCim}
+
+// headerMapping derives the columnMapping from the header row,
+// reporting whether it is a header with at least the Bankszerv and Nev columns.
+//
+// The columns (see WithColumns) are consulted before headerField.
+// Only the first column of each field is mapped.
+func headerMapping(row []string, columns map[string]string) (columnMapping, bool) {
+	m := make(columnMapping, len(row))
+	seen := make(map[string]bool, len(hitelezoColumns))
+	for i, h := range row {
+		field, ok := columns[Fold(h)]
+		if !ok {
+			field = headerField(h)
+		}
+		if field == "" || seen[field] {
+			continue
+		}
+		seen[field] = true
+		switch field {
+		case FieldBankszerv:
+			m[i] = fieldBankszerv
+		case FieldBIC:
+			m[i] = fieldBIC
+		case FieldNev:
+			m[i] = fieldNev
+		case FieldIrszam:
+			m[i] = fieldIrszam
+		case FieldCim:
+			m[i] = fieldCim
+		}
+	}
+	return m, seen[FieldBankszerv] && seen[FieldNev]
+}
+
+// apply sets the fields of rec from the row, the missing trailing cells as empty.
+func (m columnMapping) apply(rec *Hitelezo, row []string) {
+	for j, p := range m {
+		if p == nil {
+			continue
+		}
+		if j < len(row) {
+			*p(rec) = row[j]
+		} else {
+			*p(rec) = ""
+		}
+	}
+}
+
+// looksLikeRecord reports whether the row starts with a Bankszerv (with or without its check digit).
+func looksLikeRecord(row []string) bool {
+	if len(row) == 0 {
+		return false
+	}
+	code := cellToCode(row[0], 7)
+	return (len(code) == 7 || len(code) == 8) && model.IsDigits(code)
+}
+
+// headerScanner finds the header row and maps the following rows by it.
+//
+// The title rows before the header are skipped;
+// if a record comes first, the columns are read as defaultMapping.
+type headerScanner struct {
+	columns map[string]string
+	mapping columnMapping
+	// cdvCol is the index of the separate check digit column, or -1.
+	cdvCol int
+}
+
+func (o *options) newHeaderScanner() *headerScanner {
+	return &headerScanner{columns: o.columns, cdvCol: -1}
+}
+
+// record fills rec from the row, returning the value of the check digit column,
+// and reports whether row is a record (not a header or title row).
+func (s *headerScanner) record(rec *Hitelezo, row []string) (string, bool) {
+	if s.mapping == nil {
+		// Some EHT variants have a separate check digit column
+		cdvCol := cdvColumn(row)
+		r, _ := splitCDV(slices.Clone(row), cdvCol)
+		if m, ok := headerMapping(r, s.columns); ok {
+			s.mapping, s.cdvCol = m, cdvCol
+			return "", false
+		}
+		if !looksLikeRecord(r) {
+			return "", false
+		}
+		s.mapping = defaultMapping
+	}
+	row, cdv := splitCDV(row, s.cdvCol)
+	*rec = Hitelezo{}
+	s.mapping.apply(rec, row)
+	return cdv, true
+}
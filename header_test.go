@@ -0,0 +1,92 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestHeaderField(t *testing.T) {
+	for h, want := range map[string]string{
+		"Branch office code":                 FieldBankszerv,
+		"BIC code":                           FieldBIC,
+		"Name of the branch office":          FieldNev,
+		"Address of the branch office":       FieldCim,
+		"Postal code":                        FieldIrszam,
+		"Branch office may send VIBER items": "",
+		"Bankszerv":                          FieldBankszerv,
+		"Bankszrev":                          FieldBankszerv,
+		"BANKKÓD":                            FieldBankszerv,
+		"Fiók neve":                          FieldNev,
+		"Irányítószám":                       FieldIrszam,
+		"Iranyitoszam":                       FieldIrszam,
+		"Irányitószam":                       FieldIrszam,
+		"Cím":                                FieldCim,
+		"Megjegyzés":                         "",
+	} {
+		if got := headerField(h); got != want {
+			t.Errorf("%q: got %q, wanted %q", h, got, want)
+		}
+	}
+}
+
+func TestParseXLSXHeader(t *testing.T) {
+	ctx := context.Background()
+	for _, tc := range []struct {
+		Name string
+		Rows [][]string
+		Want Hitelezo
+	}{
+		{Name: "reordered",
+			Rows: [][]string{
+				{"Bankszervek listája"},
+				{},
+				{"Cím", "Irányitószam", "Fiók megnevezése", "Bankszrev kód"},
+				{"Budapest, Váci út 71.", "1139", "Magyar Államkincstár", "10002003"},
+			},
+			Want: Hitelezo{Bankszerv: "10002003", Nev: "Magyar Államkincstár", Irszam: "1139", Cim: "Budapest, Váci út 71."},
+		},
+		{Name: "sht",
+			Rows: [][]string{
+				{"Branch office code", "BIC code", "Name of the branch office", "Address of the branch office", "Branch office may send VIBER items"},
+				{"10002003", "HUSTHUHB", "Magyar Államkincstár", "1139 Budapest, Váci út 71.", "Y"},
+			},
+			Want: Hitelezo{Bankszerv: "10002003", BIC: "HUSTHUHB", Nev: "Magyar Államkincstár", Irszam: "1139", Cim: "Budapest, Váci út 71."},
+		},
+		{Name: "no header",
+			Rows: [][]string{
+				{"10002003", "Magyar Államkincstár", "1139", "Budapest, Váci út 71."},
+			},
+			Want: Hitelezo{Bankszerv: "10002003", Nev: "Magyar Államkincstár", Irszam: "1139", Cim: "Budapest, Váci út 71."},
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			wb := excelize.NewFile()
+			for i, row := range tc.Rows {
+				if err := wb.SetSheetRow("Sheet1", "A"+string(rune('1'+i)), &row); err != nil {
+					t.Fatal(err)
+				}
+			}
+			var buf bytes.Buffer
+			if err := wb.Write(&buf); err != nil {
+				t.Fatal(err)
+			}
+			hs, err := ParseXLSX(ctx, &buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(hs) != 1 {
+				t.Fatalf("got %d records, wanted 1: %+v", len(hs), hs)
+			}
+			if hs[0] != tc.Want {
+				t.Errorf("got %+v, wanted %+v", hs[0], tc.Want)
+			}
+		})
+	}
+}
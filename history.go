@@ -0,0 +1,147 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// ErrOutOfOrder is returned by History.Add for a snapshot not newer than the last one added.
+var ErrOutOfOrder = errors.New("snapshot out of order")
+
+// Version is a version of the record of a branch, valid in the [ValidFrom, ValidTo) period.
+type Version struct {
+	Hitelezo
+	// ValidFrom is the effective date of the snapshot introducing or changing the record.
+	ValidFrom time.Time
+	// ValidTo is the effective date of the snapshot removing or changing the record,
+	// zero if it is still valid.
+	ValidTo time.Time `json:",omitempty"`
+}
+
+// Valid reports whether the version is valid at the date.
+func (v Version) Valid(date time.Time) bool {
+	return !date.Before(v.ValidFrom) && (v.ValidTo.IsZero() || date.Before(v.ValidTo))
+}
+
+// History is the validity periods of the records of the branches,
+// built from the snapshots of the list added in the order of their effective dates.
+//
+// It is safe for concurrent use.
+type History struct {
+	mu       sync.RWMutex
+	versions map[string][]Version
+	last     time.Time
+}
+
+// NewHistory returns an empty History.
+func NewHistory() *History {
+	return &History{versions: make(map[string][]Version)}
+}
+
+// Add adds the snapshot of the list effective from the date:
+// the records missing from it are closed, the changed ones get a new Version.
+//
+// The date must be after the date of the last snapshot added, or ErrOutOfOrder is returned.
+// Only the first record of each Bankszerv is used (see WithDuplicates).
+func (h *History) Add(effective time.Time, hs []Hitelezo) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if !h.last.IsZero() && !effective.After(h.last) {
+		return fmt.Errorf("%s is not after %s: %w", effective.Format(time.DateOnly), h.last.Format(time.DateOnly), ErrOutOfOrder)
+	}
+	h.last = effective
+	seen := make(map[string]struct{}, len(hs))
+	for _, rec := range hs {
+		if _, ok := seen[rec.Bankszerv]; ok || rec.Bankszerv == "" {
+			continue
+		}
+		seen[rec.Bankszerv] = struct{}{}
+		vs := h.versions[rec.Bankszerv]
+		if n := len(vs); n != 0 && vs[n-1].ValidTo.IsZero() {
+			if vs[n-1].Hitelezo == rec {
+				continue
+			}
+			vs[n-1].ValidTo = effective
+		}
+		h.versions[rec.Bankszerv] = append(vs, Version{Hitelezo: rec, ValidFrom: effective})
+	}
+	for k, vs := range h.versions {
+		if _, ok := seen[k]; !ok && vs[len(vs)-1].ValidTo.IsZero() {
+			vs[len(vs)-1].ValidTo = effective
+		}
+	}
+	return nil
+}
+
+// At returns the record of the Bankszerv valid at the date.
+func (h *History) At(bankszerv string, date time.Time) (Hitelezo, bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	vs := h.versions[bankszerv]
+	// the last version starting not after the date
+	i, found := slices.BinarySearchFunc(vs, date, func(v Version, t time.Time) int { return v.ValidFrom.Compare(t) })
+	if !found {
+		i--
+	}
+	if i < 0 || !vs[i].Valid(date) {
+		return Hitelezo{}, false
+	}
+	return vs[i].Hitelezo, true
+}
+
+// Versions returns the versions of the record of the Bankszerv, in the order of their ValidFrom.
+func (h *History) Versions(bankszerv string) []Version {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return slices.Clone(h.versions[bankszerv])
+}
+
+// HistoryFromArchive returns the History of the files in the Archive,
+// parsed in the order of their effective dates (or the Last-Modified, or the download time, if unknown).
+//
+// The files with the same contents are parsed only once,
+// the ones failing to parse are reported as warnings (see WithReport).
+func HistoryFromArchive(ctx context.Context, a *Archive, opts ...Option) (*History, error) {
+	entries, err := a.List()
+	if err != nil {
+		return nil, err
+	}
+	date := func(e ArchiveEntry) time.Time {
+		for _, t := range []time.Time{e.EffectiveDate, e.LastModified} {
+			if !t.IsZero() {
+				return t
+			}
+		}
+		return e.Downloaded
+	}
+	slices.SortStableFunc(entries, func(a, b ArchiveEntry) int { return cmp.Compare(date(a).Unix(), date(b).Unix()) })
+	o := newOptions(opts)
+	h := NewHistory()
+	seen := make(map[string]struct{}, len(entries))
+	for _, e := range entries {
+		if _, ok := seen[e.SHA256]; ok {
+			continue
+		}
+		seen[e.SHA256] = struct{}{}
+		hs, _, err := a.Parse(ctx, e, opts...)
+		if err == nil {
+			err = h.Add(date(e), hs)
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return h, err
+			}
+			o.warn(fmt.Errorf("%s (%s): %w", e.Filename, e.SHA256, err))
+		}
+	}
+	return h, nil
+}
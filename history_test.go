@@ -0,0 +1,103 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestHistory(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 1, d, 0, 0, 0, 0, time.Local) }
+	a := Hitelezo{Bankszerv: "11773016", Nev: "OTP Bank", Irszam: "1051", Cim: "Budapest, Nádor u. 16."}
+	b := Hitelezo{Bankszerv: "10023002", Nev: "Magyar Államkincstár", Irszam: "1117", Cim: "Budapest"}
+	a2 := a
+	a2.Cim = "Budapest, Nádor utca 16."
+
+	h := NewHistory()
+	for _, snap := range []struct {
+		Day int
+		Hs  []Hitelezo
+	}{
+		{1, []Hitelezo{a, b}},
+		{8, []Hitelezo{a, b}},
+		{15, []Hitelezo{a2}},
+		{22, []Hitelezo{a2, b}},
+	} {
+		if err := h.Add(day(snap.Day), snap.Hs); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := h.Add(day(22), nil); !errors.Is(err, ErrOutOfOrder) {
+		t.Errorf("got %+v, wanted ErrOutOfOrder", err)
+	}
+
+	for _, tc := range []struct {
+		Bankszerv string
+		Day       int
+		Want      Hitelezo
+		OK        bool
+	}{
+		{a.Bankszerv, 0, Hitelezo{}, false},
+		{a.Bankszerv, 1, a, true},
+		{a.Bankszerv, 14, a, true},
+		{a.Bankszerv, 15, a2, true},
+		{a.Bankszerv, 30, a2, true},
+		{b.Bankszerv, 10, b, true},
+		{b.Bankszerv, 15, Hitelezo{}, false},
+		{b.Bankszerv, 21, Hitelezo{}, false},
+		{b.Bankszerv, 22, b, true},
+		{"12010855", 10, Hitelezo{}, false},
+	} {
+		if got, ok := h.At(tc.Bankszerv, day(tc.Day)); got != tc.Want || ok != tc.OK {
+			t.Errorf("%s at %d: got %v, %t, wanted %v, %t", tc.Bankszerv, tc.Day, got, ok, tc.Want, tc.OK)
+		}
+	}
+	if vs := h.Versions(a.Bankszerv); len(vs) != 2 || !vs[0].ValidTo.Equal(day(15)) || !vs[1].ValidTo.IsZero() {
+		t.Errorf("got %+v", vs)
+	}
+	if vs := h.Versions(b.Bankszerv); len(vs) != 2 || !vs[0].ValidTo.Equal(day(15)) || !vs[1].ValidFrom.Equal(day(22)) {
+		t.Errorf("got %+v", vs)
+	}
+}
+
+func TestHistoryFromArchive(t *testing.T) {
+	a, err := OpenArchive(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	old := Hitelezo{Bankszerv: "11773016", Nev: "OTP Bank", Irszam: "1051", Cim: "Budapest, Nádor u. 16."}
+	cur := old
+	cur.Irszam = "1052"
+	// added out of order
+	for _, e := range []struct {
+		Filename string
+		H        Hitelezo
+	}{
+		{"EHT_20260201.csv", cur},
+		{"EHT_20260101.csv", old},
+	} {
+		var buf bytes.Buffer
+		if err := WriteCSV(&buf, []Hitelezo{e.H}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := a.Add(&buf, ArchiveEntry{URL: "http://example.com/" + e.Filename, Filename: e.Filename}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	h, err := HistoryFromArchive(context.Background(), a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, ok := h.At(old.Bankszerv, time.Date(2026, 1, 15, 0, 0, 0, 0, time.Local)); !ok || got.Irszam != "1051" {
+		t.Errorf("got %+v, %t", got, ok)
+	}
+	if got, ok := h.At(old.Bankszerv, time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local)); !ok || got.Irszam != "1052" {
+		t.Errorf("got %+v, %t", got, ok)
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/UNO-SOFT/zlog/v2"
+	"golang.org/x/net/html"
+)
+
+// ParseHTML parses the branch table rendered as a HTML <table>.
+//
+// The columns are mapped by the header row (see ParseXLSX),
+// or read as Bankszerv, Nev, Irszam, Cim if there is none.
+func ParseHTML(ctx context.Context, r io.Reader, opts ...Option) (hs []Hitelezo, err error) {
+	o := newOptions(opts)
+	ctx, end := o.span(ctx, "ParseHTML", slog.String("format", string(FormatHTML)))
+	defer func() { err = inStage(ErrHTML, err); end(err, slog.Int("records", len(hs))) }()
+	logger := zlog.SFromContext(ctx)
+	logger.Info("ParseHTML")
+	o.source.setBackend("html")
+	records := make([]Hitelezo, 0, 8192)
+	var rec Hitelezo
+	hdr := o.newHeaderScanner()
+	o.resetOrigins()
+	o.origin = RowOrigin{}
+	if err := htmlRows(ctx, r, o, func(row []string) error {
+		if len(row) < len(defaultMapping) { // layout and footer rows
+			return nil
+		}
+		cdv, ok := hdr.record(&rec, row)
+		if !ok {
+			return nil
+		}
+		if hdr.cdvCol >= 0 {
+			o.applyCDV(&rec, cdv)
+		}
+		records = o.checkAppend(records, rec)
+		return nil
+	}); err != nil {
+		return o.result(records, err)
+	}
+	logger.Info("ParseHTML", "records", len(records))
+	return records, nil
+}
+
+// htmlRows calls fn with the cells of each non-empty <tr> of the HTML,
+// counting the rows in o.origin.
+func htmlRows(ctx context.Context, r io.Reader, o *options, fn func(row []string) error) error {
+	z := html.NewTokenizer(r)
+	var row []string
+	var cell strings.Builder
+	var inCell bool
+	endRow := func() error {
+		if inCell {
+			row = append(row, cell.String())
+			inCell = false
+		}
+		if len(row) == 0 {
+			return nil
+		}
+		o.origin.Row++
+		err := fn(row)
+		row = nil
+		return err
+	}
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			if err := z.Err(); !errors.Is(err, io.EOF) {
+				return err
+			}
+			return endRow()
+
+		case html.TextToken:
+			if inCell {
+				cell.Write(z.Text())
+			}
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			tagName, _ := z.TagName()
+			switch {
+			case bytes.Equal(tagName, []byte("tr")):
+				if err := endRow(); err != nil {
+					return err
+				}
+			case bytes.Equal(tagName, []byte("td")) || bytes.Equal(tagName, []byte("th")):
+				if inCell {
+					row = append(row, cell.String())
+				}
+				cell.Reset()
+				inCell = true
+			case bytes.Equal(tagName, []byte("br")):
+				if inCell {
+					cell.WriteByte(' ')
+				}
+			}
+
+		case html.EndTagToken:
+			tagName, _ := z.TagName()
+			switch {
+			case bytes.Equal(tagName, []byte("td")) || bytes.Equal(tagName, []byte("th")):
+				if inCell {
+					row = append(row, cell.String())
+					inCell = false
+				}
+			case bytes.Equal(tagName, []byte("tr")) || bytes.Equal(tagName, []byte("table")):
+				if err := endRow(); err != nil {
+					return err
+				}
+			}
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestParseHTML(t *testing.T) {
+	const doc = `<!DOCTYPE html>
+<html><head><title>EHT</title></head><body>
+<table>
+<tr><th>Bankszerv</th><th>Név</th><th>Irsz.</th><th>Cím</th></tr>
+<tr><td>10002003</td><td>Magyar Államkincstár. értékp.-pénztár</td><td>1139</td><td>Budapest, Váci út 71.</td></tr>
+<tr><td>10023002</td><td>Magyar Államkincstár<br/>Dél-budapesti fiók</td><td></td><td>1117 Budapest, Hengermalom út 1.</td>
+</table>
+</body></html>`
+	ctx := context.Background()
+	var src Source
+	hs, err := Parse(ctx, strings.NewReader(doc), WithSource(&src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if src.Format != FormatHTML || len(src.SHA256) != 64 || src.ContentLength != int64(len(doc)) {
+		t.Errorf("got source %+v", src)
+	}
+	if len(hs) != 2 {
+		t.Fatalf("got %d records, wanted 2: %+v", len(hs), hs)
+	}
+	checkHs(t, hs)
+	if got, want := hs[1].Nev, "Magyar Államkincstár Dél-budapesti fiók"; got != want {
+		t.Errorf("got name %q, wanted %q", got, want)
+	}
+}
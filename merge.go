@@ -0,0 +1,140 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"cmp"
+	"slices"
+)
+
+// mergedFields are the fields merged by Merger, with their accessors.
+var mergedFields = []struct {
+	name  string
+	field func(*Hitelezo) *string
+}{
+	{FieldBIC, fieldBIC}, {FieldNev, fieldNev}, {FieldIrszam, fieldIrszam}, {FieldCim, fieldCim},
+}
+
+// MergedRecord is a Hitelezo merged from several lists by Merger.
+type MergedRecord struct {
+	Hitelezo
+	// Provenance is the index of the list (in the arguments of Merge) each non-empty field is taken from,
+	// by the field names (FieldBankszerv, FieldBIC, FieldNev, FieldIrszam, FieldCim).
+	Provenance map[string]int
+}
+
+// Merger merges several lists into one authoritative record per Bankszerv.
+type Merger struct {
+	// Precedence is the order of the lists (their indexes in the arguments of Merge) to take each field from,
+	// the first non-empty value wins.
+	// The fields not listed, and the lists not listed for a field are taken in the order of the arguments.
+	Precedence map[string][]int
+}
+
+// MergerFor returns the Merger with the precedence of the Capabilities of the Publications
+// (such as the BIC from the SHT, and the address from the EHT),
+// for merging their lists in the same order.
+func MergerFor(publications ...Publication) Merger {
+	m := Merger{Precedence: make(map[string][]int, len(mergedFields))}
+	for _, f := range mergedFields {
+		order := make([]int, len(publications))
+		for i := range order {
+			order[i] = i
+		}
+		slices.SortStableFunc(order, func(a, b int) int {
+			return cmp.Compare(Capability(publications[b], f.name), Capability(publications[a], f.name))
+		})
+		m.Precedence[f.name] = order
+	}
+	return m
+}
+
+// Merge returns one record per Bankszerv from the lists, sorted by Bankszerv,
+// taking each field from the lists in the order of the Precedence.
+//
+// Only the first record of each Bankszerv of a list is used (see WithDuplicates),
+// the records without Bankszerv are skipped.
+func (m Merger) Merge(sources ...[]Hitelezo) []MergedRecord {
+	bySource := make([]map[string]Hitelezo, len(sources))
+	var keys []string
+	seen := make(map[string]int)
+	for i, hs := range sources {
+		bySource[i] = make(map[string]Hitelezo, len(hs))
+		for _, h := range hs {
+			if _, ok := bySource[i][h.Bankszerv]; ok || h.Bankszerv == "" {
+				continue
+			}
+			bySource[i][h.Bankszerv] = h
+			if _, ok := seen[h.Bankszerv]; !ok {
+				seen[h.Bankszerv] = i
+				keys = append(keys, h.Bankszerv)
+			}
+		}
+	}
+	slices.Sort(keys)
+
+	merged := make([]MergedRecord, 0, len(keys))
+	for _, k := range keys {
+		first := seen[k]
+		rec := MergedRecord{
+			Hitelezo:   Hitelezo{Bankszerv: k},
+			Provenance: map[string]int{FieldBankszerv: first},
+		}
+		var searchKey bool
+		for _, f := range mergedFields {
+			for _, i := range m.order(f.name, len(sources)) {
+				h, ok := bySource[i][k]
+				if !ok || *f.field(&h) == "" {
+					continue
+				}
+				*f.field(&rec.Hitelezo) = *f.field(&h)
+				if f.name == FieldIrszam {
+					rec.NonStdIrszam = h.NonStdIrszam
+				}
+				rec.Provenance[f.name] = i
+				searchKey = searchKey || h.SearchKey != ""
+				break
+			}
+		}
+		if searchKey {
+			rec.SearchKey = Fold(rec.Nev + " " + rec.Cim)
+		}
+		merged = append(merged, rec)
+	}
+	return merged
+}
+
+// order returns the indexes of the n lists in the order of the precedence of the field.
+func (m Merger) order(field string, n int) []int {
+	order := make([]int, 0, n)
+	listed := make([]bool, n)
+	for _, i := range m.Precedence[field] {
+		if 0 <= i && i < n && !listed[i] {
+			order = append(order, i)
+			listed[i] = true
+		}
+	}
+	for i := range n {
+		if !listed[i] {
+			order = append(order, i)
+		}
+	}
+	return order
+}
+
+// MergeLists returns one record per Bankszerv from the lists, sorted by Bankszerv,
+// taking each field from the first list having it.
+//
+// It is not called Merge, as that is the DuplicatePolicy merging the records of one list.
+// Use a Merger (such as the one returned by MergerFor) for a different precedence,
+// and for the provenance of the fields.
+func MergeLists(sources ...[]Hitelezo) []Hitelezo {
+	merged := Merger{}.Merge(sources...)
+	hs := make([]Hitelezo, len(merged))
+	for i, rec := range merged {
+		hs[i] = rec.Hitelezo
+	}
+	return hs
+}
@@ -0,0 +1,50 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeLists(t *testing.T) {
+	eht := []Hitelezo{
+		{Bankszerv: "11773016", Nev: "OTP Bank Nyrt. Nádor utcai fiók", Irszam: "1051", Cim: "Budapest, Nádor u. 16."},
+		{Bankszerv: "10023002", Nev: "Magyar Államkincstár Dél-budapesti fiók", Irszam: "1117", Cim: "Budapest, Hengermalom út 1."},
+	}
+	sht := []Hitelezo{
+		{Bankszerv: "11773016", BIC: "OTPVHUHB", Nev: "OTP BANK NYRT", Irszam: "1052"},
+		{Bankszerv: "12010855", BIC: "UBRTHUHB", Nev: "Raiffeisen Bank"},
+	}
+
+	got := MergerFor(PublicationEHT, PublicationSHT).Merge(eht, sht)
+	want := []MergedRecord{
+		{
+			Hitelezo:   eht[1],
+			Provenance: map[string]int{FieldBankszerv: 0, FieldNev: 0, FieldIrszam: 0, FieldCim: 0},
+		},
+		{
+			Hitelezo:   Hitelezo{Bankszerv: "11773016", BIC: "OTPVHUHB", Nev: eht[0].Nev, Irszam: "1051", Cim: eht[0].Cim},
+			Provenance: map[string]int{FieldBankszerv: 0, FieldBIC: 1, FieldNev: 0, FieldIrszam: 0, FieldCim: 0},
+		},
+		{
+			Hitelezo:   sht[1],
+			Provenance: map[string]int{FieldBankszerv: 1, FieldBIC: 1, FieldNev: 1},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got\n%+v\nwanted\n%+v", got, want)
+	}
+
+	m := Merger{Precedence: map[string][]int{FieldNev: {1}, FieldIrszam: {1, 0}}}
+	if got := m.Merge(eht, sht)[1]; got.Nev != "OTP BANK NYRT" || got.Irszam != "1052" || got.Cim != eht[0].Cim ||
+		got.Provenance[FieldNev] != 1 || got.Provenance[FieldCim] != 0 {
+		t.Errorf("got %+v", got)
+	}
+
+	if hs := MergeLists(sht, eht); len(hs) != 3 || hs[1].Nev != "OTP BANK NYRT" || hs[1].Cim != eht[0].Cim {
+		t.Errorf("got %+v", hs)
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrCheckDigit is the error reported for a check digit (CDV) mismatch.
+	ErrCheckDigit = errors.New("check digit mismatch")
+	// ErrAccountNumber is returned by NormalizeAccountNumber for an impossible account number.
+	ErrAccountNumber = errors.New("invalid account number")
+)
+
+// CheckDigitError is reported when the check digit column of a record
+// contradicts the check digit computed from its Bankszerv.
+type CheckDigitError struct {
+	Bankszerv, CDV string
+	Want           byte
+}
+
+func (e *CheckDigitError) Error() string {
+	return fmt.Sprintf("%s: CDV=%q, wanted %q", e.Bankszerv, e.CDV, e.Want)
+}
+func (e *CheckDigitError) Unwrap() error { return ErrCheckDigit }
+
+// cdvWeights are the weights of the GIRO check digit calculation, repeated.
+var cdvWeights = [...]int{9, 7, 3, 1}
+
+// ComputeCheckDigit returns the GIRO check digit completing the digits of s,
+// or false if s is not all digits.
+func ComputeCheckDigit(s string) (byte, bool) {
+	var sum int
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !('0' <= c && c <= '9') {
+			return 0, false
+		}
+		sum += int(c-'0') * cdvWeights[i%len(cdvWeights)]
+	}
+	return byte('0' + (10-sum%10)%10), true
+}
+
+// BankCode returns the 3-digit bank code of the Bankszerv, or "" if it is too short.
+func (h Hitelezo) BankCode() string {
+	if len(h.Bankszerv) < 3 {
+		return ""
+	}
+	return h.Bankszerv[:3]
+}
+
+// BranchCode returns the 4-digit branch code of the Bankszerv (following the bank code),
+// or "" if it is too short.
+func (h Hitelezo) BranchCode() string {
+	if len(h.Bankszerv) < 7 {
+		return ""
+	}
+	return h.Bankszerv[3:7]
+}
+
+// CheckDigit returns the 8th, check digit of the Bankszerv, or 0 if it is not 8 long.
+func (h Hitelezo) CheckDigit() byte {
+	if len(h.Bankszerv) != 8 {
+		return 0
+	}
+	return h.Bankszerv[7]
+}
+
+// Valid reports whether the Bankszerv is 8 digits, with the GIRO check digit as the last one.
+func (h Hitelezo) Valid() bool {
+	if len(h.Bankszerv) != 8 {
+		return false
+	}
+	want, ok := ComputeCheckDigit(h.Bankszerv[:7])
+	return ok && h.Bankszerv[7] == want
+}
+
+// NormalizeAccountNumber returns the canonical form of the Hungarian account number:
+// its 16 or 24 digits, without the spaces, hyphens and the IBAN prefix ("HU" and the 2 IBAN check digits).
+//
+// The 24-digit form ending in 8 zeros (as in the IBAN) is shortened to 16 digits.
+// The check digits are not verified.
+func NormalizeAccountNumber(s string) (string, error) {
+	t := compactAccountNumber(s)
+	if isIBAN(t) {
+		t = t[4:]
+	}
+	if !IsDigits(t) {
+		return "", fmt.Errorf("%q: not digits: %w", s, ErrAccountNumber)
+	}
+	switch len(t) {
+	case 16:
+	case 24:
+		if t[16:] == "00000000" {
+			t = t[:16]
+		}
+	default:
+		return "", fmt.Errorf("%q: %d digits: %w", s, len(t), ErrAccountNumber)
+	}
+	return t, nil
+}
+
+// compactAccountNumber returns s without the spaces and hyphens.
+func compactAccountNumber(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '\u00a0', '\t':
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// isIBAN reports whether the compacted account number is of the form of a Hungarian IBAN.
+func isIBAN(t string) bool {
+	return len(t) == 28 && strings.EqualFold(t[:2], "HU") && IsDigits(t[2:4])
+}
+
+// ValidateAccountNumber returns the canonical form of the account number (see NormalizeAccountNumber),
+// verifying its check digits: the GIRO check digits of the Bankszerv (the first 8 digits)
+// and of the rest, and the IBAN check digits if it is given as an IBAN.
+//
+// The check digit mismatches are reported with ErrCheckDigit.
+func ValidateAccountNumber(s string) (string, error) {
+	t, err := NormalizeAccountNumber(s)
+	if err != nil {
+		return "", err
+	}
+	if c := compactAccountNumber(s); isIBAN(c) && ibanRemainder(c) != 1 {
+		return t, fmt.Errorf("%q: IBAN: %w", s, ErrCheckDigit)
+	}
+	for _, part := range []string{t[:8], t[8:]} {
+		if want, _ := ComputeCheckDigit(part[:len(part)-1]); part[len(part)-1] != want {
+			return t, fmt.Errorf("%q: %s, wanted %q as the last digit: %w", s, part, want, ErrCheckDigit)
+		}
+	}
+	return t, nil
+}
+
+// IBAN returns the Hungarian IBAN of the account number, in groups of 4 characters.
+func IBAN(s string) (string, error) {
+	t, err := NormalizeAccountNumber(s)
+	if err != nil {
+		return "", err
+	}
+	if len(t) == 16 {
+		t += "00000000"
+	}
+	iban := fmt.Sprintf("HU%02d%s", 98-ibanRemainder("HU00"+t), t)
+	var buf strings.Builder
+	for i := 0; i < len(iban); i += 4 {
+		if i != 0 {
+			buf.WriteByte(' ')
+		}
+		buf.WriteString(iban[i:min(i+4, len(iban))])
+	}
+	return buf.String(), nil
+}
+
+// ibanRemainder returns the ISO 7064 mod 97 remainder of the IBAN (1 for a valid one).
+func ibanRemainder(iban string) int {
+	var rem int
+	for _, r := range strings.ToUpper(iban[4:] + iban[:4]) {
+		if 'A' <= r && r <= 'Z' {
+			rem = (rem*100 + int(r-'A'+10)) % 97
+		} else {
+			rem = (rem*10 + int(r-'0')) % 97
+		}
+	}
+	return rem
+}
+
+// FormatAccountNumber returns the account number in the usual 8-8 or 8-8-8 digit groups,
+// or s unchanged if it is not a valid account number (see NormalizeAccountNumber).
+func FormatAccountNumber(s string) string {
+	t, err := NormalizeAccountNumber(s)
+	if err != nil {
+		return s
+	}
+	if len(t) == 16 {
+		return t[:8] + "-" + t[8:]
+	}
+	return t[:8] + "-" + t[8:16] + "-" + t[16:]
+}
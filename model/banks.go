@@ -0,0 +1,89 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import "strings"
+
+// Bank is a credit institution owning a 3-digit GIRO bank code.
+type Bank struct {
+	// Code is the 3-digit bank code, the first 3 digits of the Bankszerv.
+	Code string
+	// Name is the legal name of the institution.
+	Name string
+	// BIC is the head-office BIC, if known.
+	BIC string `json:",omitempty"`
+}
+
+// banks is the registry of the bank codes, after the register of credit institutions of the MNB.
+//
+// The codes of the institutions merged into another one (such as Budapest Bank, MKB and Takarékbank into MBH Bank)
+// are listed with the legal successor, and the BIC still routed to the code.
+// The codes of the former savings cooperatives (5xx-7xx) are not listed.
+var banks = map[string]Bank{}
+
+func init() {
+	for _, b := range []Bank{
+		{"100", "Magyar Államkincstár", "HUSTHUHB"},
+		{"101", "MBH Bank Nyrt.", "BUDAHUHB"},
+		{"102", "Kereskedelmi és Hitelbank Zrt.", "OKHBHUHB"},
+		{"103", "MBH Bank Nyrt.", "MKKBHUHB"},
+		{"104", "Kereskedelmi és Hitelbank Zrt.", "OKHBHUHB"},
+		{"107", "CIB Bank Zrt.", "CIBHHUHB"},
+		{"108", "Citibank Europe plc Magyarországi Fióktelepe", "CITIHUHX"},
+		{"109", "UniCredit Bank Hungary Zrt.", "BACXHUHB"},
+		{"111", "CIB Bank Zrt.", "CIBHHUHB"},
+		{"116", "Erste Bank Hungary Zrt.", "GIBAHUHB"},
+		{"117", "OTP Bank Nyrt.", "OTPVHUHB"},
+		{"119", "Erste Bank Hungary Zrt.", "GIBAHUHB"},
+		{"120", "Raiffeisen Bank Zrt.", "UBRTHUHB"},
+		{"121", "Gránit Bank Zrt.", "GNBAHUHB"},
+		{"125", "Erste Bank Hungary Zrt.", "GIBAHUHB"},
+		{"131", "BNP Paribas Magyarországi Fióktelepe", "BNPAHUHX"},
+		{"137", "ING Bank N.V. Magyarországi Fióktelepe", "INGBHUHB"},
+		{"142", "Commerzbank Zrt.", "COBAHUHX"},
+		{"144", "KELER Központi Értéktár Zrt.", "KELRHUHB"},
+		{"146", "Magyar Fejlesztési Bank Zrt.", ""},
+		{"148", "Magyar Export-Import Bank Zrt.", ""},
+		{"162", "MagNet Magyar Közösségi Bank Zrt.", "HBWEHUHB"},
+		{"163", "Deutsche Bank AG Magyarországi Fióktelepe", "DEUTHUHB"},
+		{"167", "Magyar Cetelem Bank Zrt.", ""},
+		{"170", "OTP Bank Nyrt.", "OTPVHUHB"},
+		{"171", "UniCredit Jelzálogbank Zrt.", ""},
+		{"178", "BNP Paribas Magyarországi Fióktelepe", "BNPAHUHX"},
+		{"182", "MBH Bank Nyrt.", "TAKBHUHB"},
+		{"183", "BNP Paribas Magyarországi Fióktelepe", "BNPAHUHX"},
+		{"190", "Magyar Nemzeti Bank", "MANEHUHH"},
+		{"197", "Bank of China Limited Magyarországi Fióktelepe", ""},
+		{"880", "Fundamenta-Lakáskassza Zrt.", ""},
+		{"881", "OTP Lakástakarékpénztár Zrt.", ""},
+		{"882", "Fundamenta-Lakáskassza Zrt.", ""},
+		{"883", "Fundamenta-Lakáskassza Zrt.", ""},
+		{"884", "OTP Jelzálogbank Zrt.", ""},
+		{"888", "K&H Jelzálogbank Zrt.", ""},
+	} {
+		banks[b.Code] = b
+	}
+}
+
+// LookupBank returns the Bank of the bank code: the first 3 digits of code,
+// which may be a Bankszerv or an account number, too.
+func LookupBank(code string) (Bank, bool) {
+	code = compactAccountNumber(code)
+	if len(code) < 3 {
+		return Bank{}, false
+	}
+	b, ok := banks[code[:3]]
+	return b, ok
+}
+
+// BankName returns the legal name of the institution of the bank code (see LookupBank),
+// or "" if it is unknown.
+func BankName(code string) string {
+	b, _ := LookupBank(code)
+	return b.Name
+}
+
+// BankName returns the legal name of the institution of the branch (see the package function BankName).
+func (h Hitelezo) BankName() string { return BankName(strings.TrimSpace(h.Bankszerv)) }
@@ -0,0 +1,94 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// countyRanges maps the postal code ranges to the counties (megye), by the first code of each range.
+//
+// The ranges follow the post offices, so a few villages near the county borders
+// are attributed to the neighbouring county.
+var countyRanges = []struct {
+	from   int
+	county string
+}{
+	{1000, "Budapest"},
+	{2000, "Pest"},
+	{2400, "Fejér"},
+	{2500, "Komárom-Esztergom"},
+	{2600, "Pest"},
+	{2650, "Nógrád"},
+	{2700, "Pest"},
+	{2800, "Komárom-Esztergom"},
+	{3000, "Heves"},
+	{3060, "Nógrád"},
+	{3200, "Heves"},
+	{3400, "Borsod-Abaúj-Zemplén"},
+	{4000, "Hajdú-Bihar"},
+	{4300, "Szabolcs-Szatmár-Bereg"},
+	{5000, "Jász-Nagykun-Szolnok"},
+	{5500, "Békés"},
+	{6000, "Bács-Kiskun"},
+	{6600, "Csongrád-Csanád"},
+	{7000, "Fejér"},
+	{7100, "Tolna"},
+	{7300, "Baranya"},
+	{7400, "Somogy"},
+	{7600, "Baranya"},
+	{8000, "Fejér"},
+	{8200, "Veszprém"},
+	{8360, "Zala"},
+	{8400, "Veszprém"},
+	{8600, "Somogy"},
+	{8800, "Zala"},
+	{9000, "Győr-Moson-Sopron"},
+	{9500, "Vas"},
+	{10000, ""},
+}
+
+// Counties are the names of the counties (megye) returned by County, and Budapest.
+var Counties = func() []string {
+	seen := make(map[string]bool, len(countyRanges))
+	var counties []string
+	for _, r := range countyRanges {
+		if r.county != "" && !seen[r.county] {
+			seen[r.county] = true
+			counties = append(counties, r.county)
+		}
+	}
+	sort.Strings(counties)
+	return counties
+}()
+
+// County returns the county (megye) of the branch by its postal code,
+// "Budapest" for the capital, or "" if the postal code is not a standard 4-digit one.
+func (h Hitelezo) County() string {
+	if len(h.Irszam) != 4 || !IsDigits(h.Irszam) {
+		return ""
+	}
+	code, _ := strconv.Atoi(h.Irszam)
+	i := sort.Search(len(countyRanges), func(i int) bool { return countyRanges[i].from > code })
+	if i == 0 {
+		return ""
+	}
+	return countyRanges[i-1].county
+}
+
+// City returns the settlement of the branch: the part of Cim before the first comma
+// (or its first word), and "Budapest" for the Budapest postal codes.
+func (h Hitelezo) City() string {
+	if len(h.Irszam) == 4 && h.Irszam[0] == '1' && IsDigits(h.Irszam) {
+		return "Budapest"
+	}
+	city, _, found := strings.Cut(h.Cim, ",")
+	if !found {
+		city, _, _ = strings.Cut(h.Cim, " ")
+	}
+	return strings.TrimSpace(city)
+}
@@ -0,0 +1,24 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"go/build"
+	"strings"
+	"testing"
+)
+
+// TestStdlibOnly checks that the package does not pull the dependencies of the parsers.
+func TestStdlibOnly(t *testing.T) {
+	pkg, err := build.ImportDir(".", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, imp := range pkg.Imports {
+		if first, _, _ := strings.Cut(imp, "/"); strings.Contains(first, ".") {
+			t.Errorf("imports %q, wanted the standard library only", imp)
+		}
+	}
+}
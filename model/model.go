@@ -0,0 +1,37 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package model is the record of the bank branches, and the validation of the account numbers,
+// without the dependencies of the fetching and the parsing of the lists (see package giro).
+package model
+
+import "fmt"
+
+// Hitelezo is the record of a branch.
+//
+// 10002003	Magyar Államkincstár. értékp.-pénztár	1139	Budapest, Váci út 71.
+type Hitelezo struct {
+	Bankszerv, BIC, Nev, Irszam, Cim string
+	// NonStdIrszam is set when Irszam is not of an accepted form (see giro.WithIrszamLengths).
+	NonStdIrszam bool `json:",omitempty"`
+	// SearchKey is the folded Nev and Cim (see giro.NormalizeFold and giro.Fold), if asked for.
+	SearchKey string `json:",omitempty"`
+}
+
+func (h Hitelezo) String() string {
+	return fmt.Sprintf("%s=%q (%s) %s", h.Bankszerv, h.Nev, h.Irszam, h.Cim)
+}
+
+// IsDigits reports whether s is not empty and consists of ASCII digits only.
+func IsDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if !('0' <= s[i] && s[i] <= '9') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,31 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBanks(t *testing.T) {
+	for code, b := range banks {
+		if code != b.Code || len(code) != 3 || b.Name == "" || (b.BIC != "" && len(b.BIC) != 8 && len(b.BIC) != 11) {
+			t.Errorf("%s: bad entry %+v", code, b)
+		}
+	}
+}
+
+func TestAccount(t *testing.T) {
+	h := Hitelezo{Bankszerv: "11773016", Irszam: "1051", Cim: "Budapest, Nádor u. 16."}
+	if !h.Valid() || h.BankName() != "OTP Bank Nyrt." || h.County() != "Budapest" || h.City() != "Budapest" {
+		t.Errorf("got %t %q %q %q", h.Valid(), h.BankName(), h.County(), h.City())
+	}
+	if iban, err := IBAN("11773016-11111018"); err != nil || iban != "HU42 1177 3016 1111 1018 0000 0000" {
+		t.Errorf("got %q, %+v", iban, err)
+	}
+	if _, err := ValidateAccountNumber("11773016-11111019"); !errors.Is(err, ErrCheckDigit) {
+		t.Errorf("got %+v, wanted ErrCheckDigit", err)
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Normalization is the set of normalizations applied to the string fields of the parsed records.
+type Normalization uint8
+
+const (
+	// NormalizeNFC composes the characters (the files mix NFC and NFD).
+	NormalizeNFC = Normalization(1 << iota)
+	// NormalizeSpace replaces the runs of whitespace (including the non-breaking spaces) by a single space.
+	NormalizeSpace
+	// NormalizeZeroWidth removes the zero-width characters and the soft hyphens.
+	NormalizeZeroWidth
+	// NormalizeFold sets SearchKey to the lowercase Nev and Cim, without the diacritics.
+	NormalizeFold
+)
+
+// DefaultNormalization is the default normalization of the parsed records.
+const DefaultNormalization = NormalizeNFC | NormalizeSpace | NormalizeZeroWidth
+
+// WithNormalization sets the normalizations applied to the string fields of the records
+// (default is DefaultNormalization, zero turns them off).
+func WithNormalization(n Normalization) Option {
+	return func(o *options) { o.normalization = n }
+}
+
+// normalize applies the normalizations (except NormalizeFold) to the string fields of rec.
+func (n Normalization) normalize(rec *Hitelezo) {
+	if n&^NormalizeFold == 0 {
+		return
+	}
+	for _, p := range []*string{&rec.Bankszerv, &rec.BIC, &rec.Nev, &rec.Irszam, &rec.Cim} {
+		*p = n.normalizeString(*p)
+	}
+}
+
+// normalizeString applies the normalizations (except NormalizeFold) to s.
+func (n Normalization) normalizeString(s string) string {
+	if n&NormalizeZeroWidth != 0 && strings.IndexFunc(s, isZeroWidth) >= 0 {
+		s = strings.Map(func(r rune) rune {
+			if isZeroWidth(r) {
+				return -1
+			}
+			return r
+		}, s)
+	}
+	if n&NormalizeNFC != 0 && !norm.NFC.IsNormalString(s) {
+		s = norm.NFC.String(s)
+	}
+	if n&NormalizeSpace != 0 {
+		s = collapseSpace(s)
+	}
+	return s
+}
+
+// isZeroWidth reports whether r is a zero-width character or a soft hyphen.
+func isZeroWidth(r rune) bool {
+	switch r {
+	case '\u200b', '\u200c', '\u200d', '\u2060', '\ufeff', '\u00ad':
+		return true
+	}
+	return false
+}
+
+// collapseSpace trims s and replaces the runs of whitespace inside by a single space.
+func collapseSpace(s string) string {
+	var prev rune
+	clean := true
+	for _, r := range s {
+		if unicode.IsSpace(r) && (r != ' ' || prev == ' ') {
+			clean = false
+			break
+		}
+		prev = r
+	}
+	if clean && s == strings.TrimSpace(s) {
+		return s
+	}
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// Fold returns s in lowercase, without the diacritics and the repeated whitespace,
+// to be used as a search key ("Győr, Fő tér" is "gyor, fo ter").
+func Fold(s string) string {
+	var buf strings.Builder
+	buf.Grow(len(s))
+	for _, r := range norm.NFD.String(s) {
+		if !unicode.Is(unicode.Mn, r) {
+			buf.WriteRune(unicode.ToLower(r))
+		}
+	}
+	return collapseSpace(NormalizeZeroWidth.normalizeString(buf.String()))
+}
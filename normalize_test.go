@@ -0,0 +1,41 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestNormalization(t *testing.T) {
+	// NFD "ő", non-breaking space, zero-width space
+	const nev = "Gyo\u030br\u00a0 Fi\u00f3k\u200b"
+	in := "<table><tr><td>10002003</td><td>" + nev + "</td><td>9021</td><td>Gy\u0151r,\u00a0 F\u0151 t\u00e9r 1.</td></tr></table>"
+	ctx := context.Background()
+	for _, tc := range []struct {
+		Name          string
+		Norm          Normalization
+		Nev, Cim, Key string
+	}{
+		{Name: "default", Norm: DefaultNormalization, Nev: "Győr Fiók", Cim: "Győr, Fő tér 1."},
+		{Name: "fold", Norm: DefaultNormalization | NormalizeFold,
+			Nev: "Győr Fiók", Cim: "Győr, Fő tér 1.", Key: "gyor fiok gyor, fo ter 1."},
+		{Name: "none", Norm: 0, Nev: nev, Cim: "Gy\u0151r,\u00a0 F\u0151 t\u00e9r 1."},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			hs, err := ParseHTML(ctx, strings.NewReader(in), WithNormalization(tc.Norm))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(hs) != 1 {
+				t.Fatalf("got %d records, wanted 1", len(hs))
+			}
+			if h := hs[0]; h.Nev != tc.Nev || h.Cim != tc.Cim || h.SearchKey != tc.Key {
+				t.Errorf("got %q, %q, %q; wanted %q, %q, %q", h.Nev, h.Cim, h.SearchKey, tc.Nev, tc.Cim, tc.Key)
+			}
+		})
+	}
+}
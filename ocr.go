@@ -0,0 +1,18 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+// PDFOCR recognizes the text of the scanned PDFs (without a text layer)
+// with pdftoppm and tesseract, which must be installed with the language pack
+// (see WithOCRLanguage). It is slow, thus not in DefaultPDFStrategies.
+const PDFOCR = PDFStrategy("ocr")
+
+// DefaultOCRLanguage is the default tesseract language.
+const DefaultOCRLanguage = "hun"
+
+// WithOCRLanguage sets the tesseract language(s) of PDFOCR, such as "hun+eng".
+func WithOCRLanguage(lang string) Option {
+	return func(o *options) { o.ocrLanguage = lang }
+}
@@ -0,0 +1,52 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !giro_noexec && !js && !wasip1
+
+package giro
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestOCR(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip(err)
+	}
+	// fake pdftoppm and tesseract
+	dir := t.TempDir()
+	for name, script := range map[string]string{
+		"pdftoppm": `for last; do :; done
+touch "$last-1.png" "$last-2.png"
+`,
+		"tesseract": `test "$4" = hun+eng || exit 1
+case "$1" in
+*-1.png) echo ' 10002003      Magyar Államkincstár                1139    Budapest, Váci út 71.';;
+*-2.png) echo ' 11773016      OTP Bank Nyrt.                      1051    Budapest, Nádor u. 16.';;
+esac
+`,
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"+script), 0700); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	var src Source
+	var origins []RowOrigin
+	hs, err := ParsePDF(context.Background(), bytes.NewReader([]byte("%PDF-1.4\n")),
+		WithPDFStrategies(PDFOCR), WithOCRLanguage("hun+eng"), WithSource(&src), withOrigins(&origins))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hs) != 2 || hs[1].Nev != "OTP Bank Nyrt." || src.Backend != "tesseract" ||
+		len(origins) != 2 || origins[1].Page != 2 {
+		t.Errorf("got %+v from %+v, origins %+v", hs, src, origins)
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/UNO-SOFT/filecache"
+	"github.com/UNO-SOFT/giro/model"
+	"github.com/UNO-SOFT/zlog/v2"
+	"github.com/rogpeppe/retry"
+)
+
+// Option configures the parsing and downloading functions.
+type Option func(*options)
+
+type options struct {
+	report                        *Report
+	source                        *Source
+	events                        func(Event)
+	fallback                      bool
+	origins                       *[]RowOrigin
+	origin                        RowOrigin
+	progress                      func(read, total int64)
+	retry                         retry.Strategy
+	irszamLengths                 []int
+	translit                      map[rune]string
+	limits                        map[string]int
+	abbreviations                 []Abbreviation
+	sheetPerBank                  bool
+	displayName                   DisplayName
+	tracer                        Tracer
+	log                           *slog.Logger
+	execTimeout                   time.Duration
+	execMaxOutput                 int64
+	execEnv                       []string
+	parallelPages                 int
+	memoryBudget                  int
+	duplicates                    DuplicatePolicy
+	cacheDir                      string
+	changes                       *Changes
+	archive                       *Archive
+	httpClient                    *http.Client
+	upstreams                     []Upstream
+	header                        http.Header
+	java                          string
+	jvmArgs                       []string
+	tabulaJSON                    bool
+	tabulaHints                   TabulaHints
+	tabulaJarURL, tabulaJarSHA256 string
+	tabulaArgs                    []string
+	container                     *TabulaContainer
+	pdfStrategies                 []PDFStrategy
+	pdfDisabled                   []PDFStrategy
+	errorPolicy                   ErrorPolicy
+	ocrLanguage                   string
+	sheet                         sheetSelector
+	// expectedSHA256 is the hex-encoded SHA-256 hash DownloadToFile verifies
+	expectedSHA256 string
+	// probeConcurrency is the number of concurrent probes in SearchXLSURL
+	probeConcurrency int
+	// pages is the page range of the PDF to parse, all if zero
+	pages pdfPages
+	// excludeTechnical drops the technical participants in filter
+	excludeTechnical bool
+	// normalization is applied to the string fields in check
+	normalization Normalization
+	// columns maps the folded header names to the fields, see WithColumns
+	columns map[string]string
+	// formats are the formats Parse accepts, all if empty
+	formats []Format
+	// fetched is the download time of the records, set by WithFetched
+	fetched time.Time
+	// upstream is the name of the Upstream being parsed, for the snapshot of WithChanges
+	upstream string
+}
+
+func newOptions(opts []Option) *options {
+	o := options{irszamLengths: []int{4}, retry: DefaultRetry, memoryBudget: DefaultMemoryBudget,
+		probeConcurrency: DefaultProbeConcurrency, normalization: DefaultNormalization}
+	for _, f := range opts {
+		f(&o)
+	}
+	return &o
+}
+
+// WithCacheDir sets the directory of the persistent cache
+// (default is "giro" under os.UserCacheDir).
+func WithCacheDir(dir string) Option {
+	return func(o *options) { o.cacheDir = dir }
+}
+
+// openCache opens the persistent cache, creating its directory if needed.
+func (o *options) openCache() (*filecache.Cache, error) {
+	dir := o.cacheDir
+	if dir == "" {
+		ucd, err := os.UserCacheDir()
+		if err != nil {
+			return nil, err
+		}
+		dir = filepath.Join(ucd, "giro")
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, err
+	}
+	return filecache.Open(dir)
+}
+
+// WithLogger sets the logger (default is the logger of the context, zlog.SFromContext).
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) { o.log = logger }
+}
+
+// logger returns the logger of o, or of ctx.
+func (o *options) logger(ctx context.Context) *slog.Logger {
+	if o.log != nil {
+		return o.log
+	}
+	return zlog.SFromContext(ctx)
+}
+
+// context returns ctx carrying the logger set by WithLogger (if any),
+// for the functions logging by their context.
+func (o *options) context(ctx context.Context) context.Context {
+	if o.log != nil {
+		return zlog.NewSContext(ctx, o.log)
+	}
+	return ctx
+}
+
+// DefaultMemoryBudget is the default size up to which the parsed files are kept in memory.
+const DefaultMemoryBudget = 1 << 20
+
+// WithMemoryBudget sets the size up to which the parsed files are kept in memory
+// (default is DefaultMemoryBudget); larger files are spilled to a temporary file.
+func WithMemoryBudget(size int) Option {
+	return func(o *options) { o.memoryBudget = size }
+}
+
+// WithIrszamLengths sets the accepted postal code lengths (default is 4, the Hungarian one).
+//
+// A leading postal code of such length is split from the address,
+// if the postal code column is empty.
+// Records with a postal code of any other form are kept, with NonStdIrszam set.
+func WithIrszamLengths(lengths ...int) Option {
+	return func(o *options) { o.irszamLengths = lengths }
+}
+
+// validIrszam reports whether s is an all-digit postal code of an accepted length.
+func (o *options) validIrszam(s string) bool {
+	var lengthOK bool
+	for _, n := range o.irszamLengths {
+		if lengthOK = len(s) == n; lengthOK {
+			break
+		}
+	}
+	if !lengthOK {
+		return false
+	}
+	return model.IsDigits(s)
+}
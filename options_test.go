@@ -0,0 +1,70 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestIrszamLengths(t *testing.T) {
+	for _, tc := range []struct {
+		Name   string
+		Opts   []Option
+		In     Hitelezo
+		Irszam string
+		NonStd bool
+	}{
+		{Name: "hu", In: Hitelezo{Bankszerv: "10002003", Cim: "1139 Budapest, Váci út 71."}, Irszam: "1139"},
+		{Name: "foreign", In: Hitelezo{Bankszerv: "10002003", Cim: "123 Valahol"}},
+		{Name: "foreign3", Opts: []Option{WithIrszamLengths(3, 4)},
+			In: Hitelezo{Bankszerv: "10002003", Cim: "123 Valahol"}, Irszam: "123"},
+		{Name: "given", In: Hitelezo{Bankszerv: "10002003", Irszam: "A-1010", Cim: "Wien"},
+			Irszam: "A-1010", NonStd: true},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			records := newOptions(tc.Opts).checkAppend(nil, tc.In)
+			if len(records) != 1 {
+				t.Fatalf("got %d records, wanted 1", len(records))
+			}
+			if got := records[0]; got.Irszam != tc.Irszam || got.NonStdIrszam != tc.NonStd {
+				t.Errorf("got %+v, wanted Irszam=%q NonStdIrszam=%t", got, tc.Irszam, tc.NonStd)
+			}
+		})
+	}
+}
+
+func TestWithLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	var xlsx bytes.Buffer
+	if err := WriteXLSX(&xlsx, leadingZeros); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Parse(context.Background(), &xlsx, WithLogger(logger)); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "msg=ParseXLSX") {
+		t.Errorf("ParseXLSX not logged to the given logger: %s", buf.String())
+	}
+}
+
+func TestMemoryBudget(t *testing.T) {
+	var xlsx bytes.Buffer
+	if err := WriteXLSX(&xlsx, leadingZeros); err != nil {
+		t.Fatal(err)
+	}
+	// a tiny budget makes the file spill to disk
+	hs, err := Parse(context.Background(), &xlsx, WithMemoryBudget(16))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hs) != len(leadingZeros) {
+		t.Errorf("got %d records, wanted %d", len(hs), len(leadingZeros))
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"context"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// RowOrigin is the location of a record in the parsed file.
+type RowOrigin struct {
+	// Sheet is the name of the worksheet, for spreadsheets.
+	Sheet string `json:",omitempty"`
+	// Page is the 1-based page number, for PDFs, if known.
+	Page int `json:",omitempty"`
+	// Row is the 1-based row number in the sheet, table, or page.
+	Row int `json:",omitempty"`
+}
+
+// Record is a Hitelezo with the location it was parsed from.
+type Record struct {
+	Hitelezo
+	Origin RowOrigin
+}
+
+// ParseRecords is like Parse, but returns the location of each record in the parsed file, too.
+func ParseRecords(ctx context.Context, r io.Reader, opts ...Option) ([]Record, error) {
+	var origins []RowOrigin
+	hs, err := Parse(ctx, r, append(slices.Clip(opts), withOrigins(&origins))...)
+	records := make([]Record, len(hs))
+	for i, h := range hs {
+		records[i].Hitelezo = h
+		if len(origins) == len(hs) {
+			records[i].Origin = origins[i]
+		}
+	}
+	return records, err
+}
+
+// ParseXLSXRecordsFunc is like ParseXLSXFunc, but calls sink with the location of each record, too.
+func ParseXLSXRecordsFunc(ctx context.Context, r io.Reader, sink func(Record) error, opts ...Option) error {
+	return parseXLSXFunc(ctx, "ParseXLSXRecordsFunc", r, sink, opts)
+}
+
+// String returns the location as "sheet \"Sheet1\" row 12", "page 3 row 12" or "row 12".
+func (ro RowOrigin) String() string {
+	var parts []string
+	if ro.Sheet != "" {
+		parts = append(parts, "sheet "+strconv.Quote(ro.Sheet))
+	}
+	if ro.Page != 0 {
+		parts = append(parts, "page "+strconv.Itoa(ro.Page))
+	}
+	if ro.Row != 0 {
+		parts = append(parts, "row "+strconv.Itoa(ro.Row))
+	}
+	return strings.Join(parts, " ")
+}
+
+// withOrigins collects the origins of the returned records into origins.
+func withOrigins(origins *[]RowOrigin) Option {
+	return func(o *options) { o.origins = origins }
+}
+
+// resetOrigins is called at the start of each parser, as a failed parser
+// may be followed by another one.
+func (o *options) resetOrigins() {
+	if o.origins != nil {
+		*o.origins = (*o.origins)[:0]
+	}
+}
+
+// keep reports whether h is kept by filter.
+func (o *options) keep(h Hitelezo) bool {
+	return h.Bankszerv != "" && h.Nev != "" && (h.Irszam != "" || h.Cim != "") &&
+		!(o.excludeTechnical && IsTechnical(h))
+}
+
+// filter removes the records without name or address,
+// and the technical participants if asked for (see WithTechnical).
+func (o *options) filter(hs []Hitelezo) []Hitelezo {
+	var origins []RowOrigin
+	if o.origins != nil && len(*o.origins) == len(hs) {
+		origins = *o.origins
+	}
+	j := 0
+	for i, h := range hs {
+		if !o.keep(h) {
+			continue
+		}
+		hs[j] = h
+		if origins != nil {
+			origins[j] = origins[i]
+		}
+		j++
+	}
+	if origins != nil {
+		*o.origins = origins[:j]
+	}
+	return hs[:j]
+}
@@ -0,0 +1,84 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseRecords(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	hs := append(append([]Hitelezo(nil), leadingZeros...), Hitelezo{Bankszerv: "10002003"}) // no name: filtered
+	hs = append(hs, Hitelezo{Bankszerv: "10023002", Nev: "Magyar Államkincstár", Irszam: "1117", Cim: "Budapest"})
+	if err := WriteXLSX(&buf, hs); err != nil {
+		t.Fatal(err)
+	}
+	recs, err := ParseRecords(ctx, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recs) != 3 {
+		t.Fatalf("got %d records, wanted 3", len(recs))
+	}
+	for i, want := range []int{2, 3, 5} {
+		if got := recs[i].Origin; got.Row != want || got.Sheet == "" {
+			t.Errorf("%d. got %+v, wanted row %d", i, got, want)
+		}
+	}
+
+	recs, err = ParseRecords(ctx, strings.NewReader(`<table>
+<tr><th>Bankszerv</th><th>Név</th><th>Irsz.</th><th>Cím</th></tr>
+<tr><td>10023002</td><td>Magyar Államkincstár</td><td>1117</td><td>Budapest</td></tr>
+</table>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recs) != 1 || recs[0].Origin.Row != 2 {
+		t.Errorf("got %+v", recs)
+	}
+
+	fh, err := os.Open(filepath.Join("testdata", "EHT_20210401.txt"))
+	if err != nil {
+		t.Skip(err)
+	}
+	defer fh.Close()
+	var origins []RowOrigin
+	txt, err := parseTXT(ctx, fh, newOptions([]Option{withOrigins(&origins)}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(origins) != len(txt) {
+		t.Fatalf("got %d origins for %d records", len(origins), len(txt))
+	}
+	if last := origins[len(origins)-1]; last.Page < 50 || last.Row == 0 {
+		t.Errorf("last origin: %+v", last)
+	}
+}
+
+func TestParseXLSXRecordsFunc(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteXLSX(&buf, leadingZeros); err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	if err := ParseXLSXRecordsFunc(context.Background(), bytes.NewReader(buf.Bytes()), func(rec Record) error {
+		got = append(got, rec.Bankszerv+" "+rec.Origin.String())
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(leadingZeros) || got[0] != leadingZeros[0].Bankszerv+` sheet "Sheet1" row 2` {
+		t.Errorf("got %q", got)
+	}
+	if got := (RowOrigin{Page: 3, Row: 12}).String(); got != "page 3 row 12" {
+		t.Errorf("got %q", got)
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package otelgiro adapts an OpenTelemetry tracer to giro.Tracer,
+// keeping the OpenTelemetry dependency out of package giro.
+package otelgiro
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/UNO-SOFT/giro"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Tracer returns a giro.Tracer starting the spans with t:
+//
+//	giro.Parse(ctx, nil, giro.WithTracer(otelgiro.Tracer(otel.Tracer("giro"))))
+//
+// The errors are recorded on the spans, setting their status to Error.
+func Tracer(t trace.Tracer) giro.Tracer {
+	return func(ctx context.Context, name string, attrs ...slog.Attr) (context.Context, func(error, ...slog.Attr)) {
+		ctx, span := t.Start(ctx, name, trace.WithAttributes(attributes(nil, "", attrs)...))
+		return ctx, func(err error, attrs ...slog.Attr) {
+			if len(attrs) != 0 {
+				span.SetAttributes(attributes(nil, "", attrs)...)
+			}
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.End()
+		}
+	}
+}
+
+// attributes appends the attrs to kvs, the members of the groups with the prefix of the group's name.
+func attributes(kvs []attribute.KeyValue, prefix string, attrs []slog.Attr) []attribute.KeyValue {
+	for _, a := range attrs {
+		k, v := prefix+a.Key, a.Value.Resolve()
+		switch v.Kind() {
+		case slog.KindString:
+			kvs = append(kvs, attribute.String(k, v.String()))
+		case slog.KindInt64:
+			kvs = append(kvs, attribute.Int64(k, v.Int64()))
+		case slog.KindUint64:
+			kvs = append(kvs, attribute.Int64(k, int64(v.Uint64())))
+		case slog.KindFloat64:
+			kvs = append(kvs, attribute.Float64(k, v.Float64()))
+		case slog.KindBool:
+			kvs = append(kvs, attribute.Bool(k, v.Bool()))
+		case slog.KindGroup:
+			if a.Key != "" {
+				k += "."
+			}
+			kvs = attributes(kvs, k, v.Group())
+		default:
+			kvs = append(kvs, attribute.String(k, fmt.Sprint(v.Any())))
+		}
+	}
+	return kvs
+}
@@ -0,0 +1,62 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package otelgiro_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/UNO-SOFT/giro"
+	"github.com/UNO-SOFT/giro/otelgiro"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracer(t *testing.T) {
+	rec := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec))
+	defer tp.Shutdown(context.Background())
+	tracer := otelgiro.Tracer(tp.Tracer("giro"))
+
+	const doc = `<html><body><table>
+<tr><td>10002003</td><td>Magyar Államkincstár</td><td>1139</td><td>Budapest, Váci út 71.</td></tr>
+</table></body></html>`
+	if _, err := giro.Parse(context.Background(), strings.NewReader(doc), giro.WithTracer(tracer)); err != nil {
+		t.Fatal(err)
+	}
+	spans := rec.Ended()
+	var names []string
+	for _, s := range spans {
+		names = append(names, s.Name())
+	}
+	if len(spans) != 2 || names[0] != "giro.ParseHTML" || names[1] != "giro.Parse" {
+		t.Fatalf("got spans %q", names)
+	}
+	if spans[0].Parent().SpanID() != spans[1].SpanContext().SpanID() {
+		t.Error("ParseHTML is not the child of Parse")
+	}
+	want := map[attribute.Key]attribute.Value{"format": attribute.StringValue("html"), "records": attribute.Int64Value(1)}
+	for _, kv := range spans[0].Attributes() {
+		if w, ok := want[kv.Key]; ok && w == kv.Value {
+			delete(want, kv.Key)
+		}
+	}
+	if len(want) != 0 {
+		t.Errorf("missing attributes %v of %v", want, spans[0].Attributes())
+	}
+
+	_, end := tracer(context.Background(), "giro.failing", slog.Group("req", slog.String("url", "http://example.com")))
+	end(errors.New("boom"))
+	s := rec.Ended()[2]
+	if s.Status().Code != codes.Error || s.Status().Description != "boom" || len(s.Events()) != 1 ||
+		len(s.Attributes()) != 1 || s.Attributes()[0] != attribute.String("req.url", "http://example.com") {
+		t.Errorf("got status %v, events %v, attributes %v", s.Status(), s.Events(), s.Attributes())
+	}
+}
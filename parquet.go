@@ -0,0 +1,214 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// The Parquet file is written with a minimal encoder: one row group, one data page per column,
+// PLAIN encoding and zstd compression, the metadata in the Thrift compact protocol
+// (see https://github.com/apache/parquet-format).
+const (
+	parquetMagic = "PAR1"
+	// the Type, ConvertedType, FieldRepetitionType, Encoding, CompressionCodec and PageType values used
+	parquetByteArray = 6
+	parquetUTF8      = 0
+	parquetRequired  = 0
+	parquetPlain     = 0
+	parquetRLE       = 3
+	parquetZstd      = 6
+	parquetDataPage  = 0
+)
+
+// WriteParquet writes the records as a Parquet file, to be queried with DuckDB or Spark.
+//
+// The columns are those of WriteCSV: all required STRING (UTF-8) columns,
+// thus codes with leading zeros (03000007) stay text.
+// The strings are in NFC (the accented letters composed, as "á" typed), the invalid UTF-8 replaced,
+// so the Hungarian names compare and group as expected.
+func WriteParquet(w io.Writer, hs []Hitelezo, opts ...Option) error {
+	o := newOptions(opts)
+	columns := make([][]byte, len(exportHeader))
+	var a [4]byte
+	for _, h := range hs {
+		for i, s := range o.exportRow(h) {
+			s = NormalizeNFC.normalizeString(strings.ToValidUTF8(s, "\uFFFD"))
+			binary.LittleEndian.PutUint32(a[:], uint32(len(s)))
+			columns[i] = append(append(columns[i], a[:]...), s...)
+		}
+	}
+	zw, err := zstd.NewWriter(nil)
+	if err != nil {
+		return err
+	}
+	defer zw.Close()
+
+	bw := bufio.NewWriter(w)
+	cw := &countingWriter{w: bw}
+	io.WriteString(cw, parquetMagic)
+	var meta thriftWriter
+	meta.fieldI32(1, 1) // version
+	meta.fieldList(2, thriftStruct, len(exportHeader)+1)
+	meta.beginStruct() // the root of the schema
+	meta.fieldBinary(4, "schema")
+	meta.fieldI32(5, int32(len(exportHeader)))
+	meta.endStruct()
+	for _, name := range exportHeader {
+		meta.beginStruct()
+		meta.fieldI32(1, parquetByteArray)
+		meta.fieldI32(3, parquetRequired)
+		meta.fieldBinary(4, name)
+		meta.fieldI32(6, parquetUTF8)
+		meta.fieldStruct(10) // LogicalType
+		meta.fieldStruct(1)  // STRING
+		meta.endStruct()
+		meta.endStruct()
+		meta.endStruct()
+	}
+	meta.fieldI64(3, int64(len(hs)))
+	if len(hs) == 0 {
+		meta.fieldList(4, thriftStruct, 0)
+	} else {
+		meta.fieldList(4, thriftStruct, 1)
+		meta.beginStruct() // RowGroup
+		meta.fieldList(1, thriftStruct, len(columns))
+		var total int64
+		for i, values := range columns {
+			compressed := zw.EncodeAll(values, nil)
+			var page thriftWriter
+			page.fieldI32(1, parquetDataPage)
+			page.fieldI32(2, int32(len(values)))
+			page.fieldI32(3, int32(len(compressed)))
+			page.fieldStruct(5) // DataPageHeader
+			page.fieldI32(1, int32(len(hs)))
+			page.fieldI32(2, parquetPlain)
+			page.fieldI32(3, parquetRLE)
+			page.fieldI32(4, parquetRLE)
+			page.endStruct()
+			page.endStruct()
+
+			offset := cw.n
+			cw.Write(page.buf)
+			cw.Write(compressed)
+			size := int64(len(page.buf) + len(values))
+			total += size
+
+			meta.beginStruct() // ColumnChunk
+			meta.fieldI64(2, offset)
+			meta.fieldStruct(3) // ColumnMetaData
+			meta.fieldI32(1, parquetByteArray)
+			meta.fieldList(2, thriftI32, 1)
+			meta.i32(parquetPlain)
+			meta.fieldList(3, thriftBinary, 1)
+			meta.binary(exportHeader[i])
+			meta.fieldI32(4, parquetZstd)
+			meta.fieldI64(5, int64(len(hs)))
+			meta.fieldI64(6, size)
+			meta.fieldI64(7, int64(len(page.buf)+len(compressed)))
+			meta.fieldI64(9, offset)
+			meta.endStruct()
+			meta.endStruct()
+		}
+		meta.fieldI64(2, total)
+		meta.fieldI64(3, int64(len(hs)))
+		meta.endStruct()
+	}
+	meta.fieldBinary(6, "giro")
+	meta.endStruct()
+
+	cw.Write(meta.buf)
+	binary.LittleEndian.PutUint32(a[:], uint32(len(meta.buf)))
+	cw.Write(a[:])
+	io.WriteString(cw, parquetMagic)
+	if cw.err != nil {
+		return cw.err
+	}
+	return bw.Flush()
+}
+
+// countingWriter counts the bytes written, and keeps the first error.
+type countingWriter struct {
+	w   io.Writer
+	n   int64
+	err error
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	if cw.err != nil {
+		return 0, cw.err
+	}
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	cw.err = err
+	return n, err
+}
+
+// The types of the Thrift compact protocol.
+const (
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftBinary = 8
+	thriftList   = 9
+	thriftStruct = 12
+)
+
+// thriftWriter writes the Thrift compact protocol,
+// the fields of the structs in ascending order of their ids.
+type thriftWriter struct {
+	buf []byte
+	// last is the id of the last field of the current struct, lasts of the enclosing ones
+	last  int16
+	lasts []int16
+}
+
+func (t *thriftWriter) uvarint(n uint64) { t.buf = binary.AppendUvarint(t.buf, n) }
+
+func (t *thriftWriter) field(id int16, typ byte) {
+	if delta := id - t.last; 0 < delta && delta <= 15 {
+		t.buf = append(t.buf, byte(delta)<<4|typ)
+	} else {
+		t.buf = append(t.buf, typ)
+		t.uvarint(uint64(uint16((id << 1) ^ (id >> 15))))
+	}
+	t.last = id
+}
+
+func (t *thriftWriter) i32(n int32)     { t.uvarint(uint64(uint32((n << 1) ^ (n >> 31)))) }
+func (t *thriftWriter) i64(n int64)     { t.uvarint(uint64((n << 1) ^ (n >> 63))) }
+func (t *thriftWriter) binary(s string) { t.uvarint(uint64(len(s))); t.buf = append(t.buf, s...) }
+
+func (t *thriftWriter) fieldI32(id int16, n int32)     { t.field(id, thriftI32); t.i32(n) }
+func (t *thriftWriter) fieldI64(id int16, n int64)     { t.field(id, thriftI64); t.i64(n) }
+func (t *thriftWriter) fieldBinary(id int16, s string) { t.field(id, thriftBinary); t.binary(s) }
+
+// fieldList starts a list field of n elements, to be written next.
+func (t *thriftWriter) fieldList(id int16, elem byte, n int) {
+	t.field(id, thriftList)
+	if n < 15 {
+		t.buf = append(t.buf, byte(n)<<4|elem)
+	} else {
+		t.buf = append(t.buf, 0xf0|elem)
+		t.uvarint(uint64(n))
+	}
+}
+
+// fieldStruct starts a struct field, to be ended by endStruct.
+func (t *thriftWriter) fieldStruct(id int16) { t.field(id, thriftStruct); t.beginStruct() }
+
+// beginStruct starts a struct (such as a list element), to be ended by endStruct.
+func (t *thriftWriter) beginStruct() { t.lasts = append(t.lasts, t.last); t.last = 0 }
+
+func (t *thriftWriter) endStruct() {
+	t.buf = append(t.buf, 0)
+	if n := len(t.lasts); n != 0 {
+		t.last, t.lasts = t.lasts[n-1], t.lasts[:n-1]
+	}
+}
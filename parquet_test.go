@@ -0,0 +1,190 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+func TestWriteParquet(t *testing.T) {
+	hs := []Hitelezo{
+		{Bankszerv: "03000007", Nev: "Próba Bank", Irszam: "1139", Cim: "Budapest, Váci út 71."},
+		// the decomposed "ő" is written composed
+		{Bankszerv: "11773016", BIC: "OTPVHUHB", Nev: "OTP Bank", Irszam: "1051", Cim: "Szo\u030bke u. 1."},
+	}
+	var buf bytes.Buffer
+	if err := WriteParquet(&buf, hs); err != nil {
+		t.Fatal(err)
+	}
+	b := buf.Bytes()
+	if !bytes.HasPrefix(b, []byte(parquetMagic)) || !bytes.HasSuffix(b, []byte(parquetMagic)) {
+		t.Fatalf("no %s magic", parquetMagic)
+	}
+	n := int(binary.LittleEndian.Uint32(b[len(b)-8:]))
+	meta, rest, err := readThriftStruct(b[len(b)-8-n : len(b)-8])
+	if err != nil || len(rest) != 0 {
+		t.Fatalf("metadata: %+v (%d bytes left)", err, len(rest))
+	}
+	if meta[1] != int64(1) || meta[3] != int64(len(hs)) {
+		t.Errorf("got version %v, %v rows", meta[1], meta[3])
+	}
+	var names []string
+	for _, elt := range meta[2].([]any)[1:] {
+		se := elt.(map[int16]any)
+		if se[1] != int64(parquetByteArray) || se[3] != int64(parquetRequired) || se[6] != int64(parquetUTF8) {
+			t.Errorf("got schema element %v", se)
+		}
+		names = append(names, string(se[4].([]byte)))
+	}
+	if !slices.Equal(names, exportHeader) {
+		t.Errorf("got columns %q, wanted %q", names, exportHeader)
+	}
+
+	zr, err := zstd.NewReader(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer zr.Close()
+	rg := meta[4].([]any)[0].(map[int16]any)
+	got := make([]Hitelezo, len(hs))
+	for i, cc := range rg[1].([]any) {
+		cmd := cc.(map[int16]any)[3].(map[int16]any)
+		if cmd[4] != int64(parquetZstd) || cmd[5] != int64(len(hs)) {
+			t.Errorf("%d. got column metadata %v", i, cmd)
+		}
+		off := cmd[9].(int64)
+		page, rest, err := readThriftStruct(b[off:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		values, err := zr.DecodeAll(rest[:page[3].(int64)], nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if int64(len(values)) != page[2].(int64) || page[5].(map[int16]any)[1] != int64(len(hs)) {
+			t.Errorf("%d. got page header %v", i, page)
+		}
+		for j := range got {
+			n := binary.LittleEndian.Uint32(values)
+			s := string(values[4 : 4+n])
+			values = values[4+n:]
+			*[]*string{&got[j].Bankszerv, &got[j].BIC, &got[j].Nev, &got[j].Irszam, &got[j].Cim}[i] = s
+		}
+	}
+	hs[1].Cim = "Szőke u. 1."
+	if !slices.Equal(got, hs) {
+		t.Errorf("got %+v, wanted %+v", got, hs)
+	}
+
+	buf.Reset()
+	if err = WriteParquet(&buf, nil); err != nil {
+		t.Fatal(err)
+	}
+	b = buf.Bytes()
+	n = int(binary.LittleEndian.Uint32(b[len(b)-8:]))
+	if meta, _, err = readThriftStruct(b[len(b)-8-n : len(b)-8]); err != nil || meta[3] != int64(0) || len(meta[4].([]any)) != 0 {
+		t.Errorf("empty: got %v, %+v", meta, err)
+	}
+}
+
+// parquetGolden are the records of testdata/records.parquet.
+var parquetGolden = []Hitelezo{
+	{Bankszerv: "03000007", Nev: "Próba Bank", Irszam: "1139", Cim: "Budapest, Váci út 71."},
+	{Bankszerv: "10002003", Nev: "Magyar Államkincstár", Irszam: "1139", Cim: "Budapest, Váci út 71."},
+	{Bankszerv: "11773016", BIC: "OTPVHUHB", Nev: "OTP Bank", Irszam: "1051", Cim: "Budapest, Nádor u. 16."},
+	{Bankszerv: "11773023", BIC: "OTPVHUHB", Nev: "OTP Bank Fiók", Irszam: "4025", Cim: "Debrecen, Hatvan u. 2."},
+}
+
+// TestWriteParquetGolden compares the output with testdata/records.parquet,
+// which was read back by github.com/xitongsys/parquet-go (v1.6.2),
+// giving the parquetGolden records, the UTF8 STRING columns and the ZSTD codec.
+func TestWriteParquetGolden(t *testing.T) {
+	want, err := os.ReadFile("testdata/records.parquet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err = WriteParquet(&buf, parquetGolden); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Errorf("got %d bytes differing from testdata/records.parquet (%d bytes)", buf.Len(), len(want))
+	}
+}
+
+// readThriftStruct reads a struct of the Thrift compact protocol: the fields by id,
+// the integers as int64, the binaries as []byte, the lists as []any and the structs as map[int16]any.
+func readThriftStruct(b []byte) (map[int16]any, []byte, error) {
+	m := make(map[int16]any)
+	var last int16
+	for {
+		if len(b) == 0 {
+			return m, b, fmt.Errorf("unexpected end of struct")
+		}
+		h := b[0]
+		b = b[1:]
+		if h == 0 {
+			return m, b, nil
+		}
+		if delta := int16(h >> 4); delta != 0 {
+			last += delta
+		} else {
+			u, n := binary.Uvarint(b)
+			b = b[n:]
+			last = int16(u>>1) ^ -int16(u&1)
+		}
+		var err error
+		if m[last], b, err = readThriftValue(b, h&0x0f); err != nil {
+			return m, b, fmt.Errorf("field %d: %w", last, err)
+		}
+	}
+}
+
+func readThriftValue(b []byte, typ byte) (any, []byte, error) {
+	switch typ {
+	case 1, 2:
+		return typ == 1, b, nil
+	case 4, 5, 6:
+		u, n := binary.Uvarint(b)
+		if n <= 0 {
+			return nil, b, fmt.Errorf("bad varint")
+		}
+		return int64(u>>1) ^ -int64(u&1), b[n:], nil
+	case thriftBinary:
+		u, n := binary.Uvarint(b)
+		if n <= 0 || uint64(len(b)-n) < u {
+			return nil, b, fmt.Errorf("bad binary")
+		}
+		return b[n : n+int(u)], b[n+int(u):], nil
+	case thriftList:
+		size, elem := int(b[0]>>4), b[0]&0x0f
+		b = b[1:]
+		if size == 15 {
+			u, n := binary.Uvarint(b)
+			size, b = int(u), b[n:]
+		}
+		list := make([]any, 0, size)
+		for range size {
+			var v any
+			var err error
+			if v, b, err = readThriftValue(b, elem); err != nil {
+				return list, b, err
+			}
+			list = append(list, v)
+		}
+		return list, b, nil
+	case thriftStruct:
+		m, b, err := readThriftStruct(b)
+		return m, b, err
+	}
+	return nil, b, fmt.Errorf("unknown type %d", typ)
+}
@@ -0,0 +1,84 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+)
+
+// ErrFormatDisabled is returned by Parse for a format not enabled by WithFormats.
+var ErrFormatDisabled = errors.New("format not enabled")
+
+// WithFormats restricts the formats Parse accepts (default is all of them).
+func WithFormats(formats ...Format) Option {
+	return func(o *options) { o.formats = formats }
+}
+
+// checkFormat returns ErrFormatDisabled if format is not enabled by WithFormats.
+func (o *options) checkFormat(format Format) error {
+	if len(o.formats) == 0 || slices.Contains(o.formats, format) {
+		return nil
+	}
+	return fmt.Errorf("%s: %w", format, ErrFormatDisabled)
+}
+
+// Parser is a reusable configuration of the package functions:
+// its methods call them with its options, followed by the options of the call.
+//
+// The zero Parser uses the defaults. It is safe for concurrent use.
+type Parser struct {
+	opts []Option
+}
+
+// NewParser returns a Parser with the options.
+func NewParser(opts ...Option) *Parser {
+	return &Parser{opts: slices.Clone(opts)}
+}
+
+// With returns a copy of p with the options appended.
+func (p *Parser) With(opts ...Option) *Parser {
+	return &Parser{opts: p.options(opts)}
+}
+
+// options returns the options of p followed by opts,
+// without spare capacity, as the callers may append to it concurrently.
+func (p *Parser) options(opts []Option) []Option {
+	return slices.Clip(append(slices.Clip(p.opts), opts...))
+}
+
+// Parse calls Parse with the options of p.
+func (p *Parser) Parse(ctx context.Context, r io.Reader, opts ...Option) ([]Hitelezo, error) {
+	return Parse(ctx, r, p.options(opts)...)
+}
+
+// ParseRecords calls ParseRecords with the options of p.
+func (p *Parser) ParseRecords(ctx context.Context, r io.Reader, opts ...Option) ([]Record, error) {
+	return ParseRecords(ctx, r, p.options(opts)...)
+}
+
+// Download calls DownloadFile with the options of p.
+func (p *Parser) Download(ctx context.Context, dlURL string, opts ...Option) (string, io.ReadCloser, error) {
+	return DownloadFile(ctx, dlURL, p.options(opts)...)
+}
+
+// Search calls SearchCandidates with the options of p.
+func (p *Parser) Search(ctx context.Context, searchURL, pattern string, opts ...Option) ([]Candidate, error) {
+	return SearchCandidates(ctx, searchURL, pattern, p.options(opts)...)
+}
+
+// Refresh calls d.Refresh with the options of p.
+func (p *Parser) Refresh(ctx context.Context, d *Directory, opts ...Option) error {
+	return d.Refresh(ctx, p.options(opts)...)
+}
+
+// Refresher returns a Refresher of d using the options of p.
+func (p *Parser) Refresher(d *Directory, interval time.Duration) *Refresher {
+	return &Refresher{Directory: d, Interval: interval, Options: p.opts}
+}
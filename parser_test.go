@@ -0,0 +1,99 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestParser(t *testing.T) {
+	wb := excelize.NewFile()
+	for i, row := range [][]string{
+		{"Azon.", "Hitelintézet", "Postacím", "Helység"},
+		{"10002003", "Magyar Államkincstár", "1139", "Budapest, Váci út 71."},
+	} {
+		if err := wb.SetSheetRow("Sheet1", "A"+string(rune('1'+i)), &row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var buf bytes.Buffer
+	if err := wb.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	xlsx := buf.Bytes()
+
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write(xlsx)
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	p := NewParser(WithHTTPClient(srv.Client()), WithColumns(map[string]string{
+		"azon.": FieldBankszerv, "HITELINTEZET": FieldNev, "Postacím": FieldIrszam, "Helység": FieldCim,
+	}))
+	_, rc, err := p.Download(ctx, srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hs, err := p.Parse(ctx, rc)
+	rc.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Hitelezo{Bankszerv: "10002003", Nev: "Magyar Államkincstár", Irszam: "1139", Cim: "Budapest, Váci út 71."}
+	if len(hs) != 1 || hs[0] != want {
+		t.Errorf("got %+v, wanted %+v", hs, want)
+	}
+	if requests != 1 {
+		t.Errorf("got %d requests, wanted 1", requests)
+	}
+
+	if _, err = p.With(WithFormats(FormatPDF, FormatHTML)).Parse(ctx, bytes.NewReader(xlsx)); !errors.Is(err, ErrFormatDisabled) {
+		t.Errorf("got %+v, wanted ErrFormatDisabled", err)
+	}
+	if _, err = p.Parse(ctx, bytes.NewReader(xlsx), WithFormats(FormatXLSX)); err != nil {
+		t.Errorf("WithFormats(FormatXLSX): %+v", err)
+	}
+}
+
+// TestParserConcurrent checks that the concurrent calls do not append into the same options
+// (run with -race): NewParser may leave spare capacity, which ParseRecords would write into.
+func TestParserConcurrent(t *testing.T) {
+	wb := excelize.NewFile()
+	row := []string{"10002003", "Magyar Államkincstár", "1139", "Budapest, Váci út 71."}
+	if err := wb.SetSheetRow("Sheet1", "A1", &row); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := wb.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	p := NewParser(WithFormats(FormatXLSX), WithTechnical(true), WithDuplicates(KeepFirst),
+		WithNormalization(DefaultNormalization), WithErrorPolicy(BestEffort))
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				if records, err := p.ParseRecords(context.Background(), bytes.NewReader(buf.Bytes())); err != nil || len(records) != 1 {
+					t.Errorf("got %+v, %+v", records, err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
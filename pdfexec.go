@@ -0,0 +1,684 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !giro_noexec && !js && !wasip1
+
+package giro
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/UNO-SOFT/filecache"
+	"github.com/UNO-SOFT/zlog/v2"
+)
+
+// execSupported reports whether the external commands (tabula, pdftotext, tesseract) can be run:
+// not with the giro_noexec build tag, and not on js or wasip1 (see pdfexec_none.go).
+const execSupported = true
+
+// limitedCmd is an external command with the limits of WithExecLimits.
+type limitedCmd struct {
+	*exec.Cmd
+	ctx       context.Context
+	cancel    context.CancelFunc
+	maxOutput int64
+	overflow  bool
+}
+
+// execWaitDelay is the time to wait for the output of a command after it has been killed.
+const execWaitDelay = 5 * time.Second
+
+// command returns the command name with args, limited by WithExecLimits, with the environment of WithExecEnv.
+//
+// Its Wait must be called to release its resources.
+func (o *options) command(ctx context.Context, name string, args ...string) *limitedCmd {
+	var cancel context.CancelFunc
+	if o.execTimeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, o.execTimeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Env = o.execEnviron()
+	// do not wait for the children of a killed command (such as of the JVM) holding its output open
+	cmd.WaitDelay = execWaitDelay
+	return &limitedCmd{
+		Cmd: cmd,
+		ctx: ctx, cancel: cancel, maxOutput: o.execMaxOutput,
+	}
+}
+
+// StdoutPipe returns the output of the command, which errs with ErrOutputLimit
+// (and kills the command) when reaching the output limit.
+func (c *limitedCmd) StdoutPipe() (io.Reader, error) {
+	pr, err := c.Cmd.StdoutPipe()
+	if err != nil || c.maxOutput <= 0 {
+		return pr, err
+	}
+	return &limitedReader{r: pr, c: c, n: c.maxOutput}, nil
+}
+
+// Start starts the command.
+func (c *limitedCmd) Start() error {
+	err := c.Cmd.Start()
+	if err != nil {
+		c.cancel()
+	}
+	return err
+}
+
+// Wait waits for the command, returning ErrExecTimeout or ErrOutputLimit
+// if it has been killed for exceeding the limits.
+func (c *limitedCmd) Wait() error {
+	err := c.Cmd.Wait()
+	timedOut := errors.Is(c.ctx.Err(), context.DeadlineExceeded)
+	c.cancel()
+	switch {
+	case c.overflow:
+		return fmt.Errorf("%v: %w (%d bytes)", c.Args, ErrOutputLimit, c.maxOutput)
+	case err != nil && timedOut:
+		return fmt.Errorf("%v: %w", c.Args, ErrExecTimeout)
+	case err != nil:
+		return fmt.Errorf("%v: %w", c.Args, err)
+	}
+	return nil
+}
+
+type limitedReader struct {
+	r io.Reader
+	c *limitedCmd
+	n int64
+}
+
+func (lr *limitedReader) Read(p []byte) (int, error) {
+	if lr.n <= 0 {
+		lr.c.overflow = true
+		lr.c.cancel()
+		return 0, ErrOutputLimit
+	}
+	if int64(len(p)) > lr.n {
+		p = p[:lr.n]
+	}
+	n, err := lr.r.Read(p)
+	lr.n -= int64(n)
+	return n, err
+}
+
+// lookJava returns the path of the Java executable, or ErrJavaNotFound.
+func (o *options) lookJava() (string, error) {
+	java := o.java
+	if java == "" {
+		java = "java"
+	}
+	path, err := exec.LookPath(java)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w: %w", java, ErrJavaNotFound, err)
+	}
+	return path, nil
+}
+
+type limitedWriter struct {
+	w io.Writer
+	c *limitedCmd
+	n int64
+}
+
+func (lw *limitedWriter) Write(p []byte) (int, error) {
+	if int64(len(p)) > lw.n {
+		lw.c.overflow = true
+		lw.c.cancel()
+		return 0, ErrOutputLimit
+	}
+	lw.n -= int64(len(p))
+	return lw.w.Write(p)
+}
+
+// tabulaRunner tells how to run tabula: with the local java, or in a container.
+type tabulaRunner struct {
+	java, runtime, image string
+	jvmArgs              []string
+}
+
+// tabulaRunner returns the local Java runner, or the container runner if there is no Java,
+// or ErrJavaNotFound.
+func (o *options) tabulaRunner() (tabulaRunner, error) {
+	java, err := o.lookJava()
+	if err == nil || o.container == nil {
+		return tabulaRunner{java: java, jvmArgs: o.jvmArgs}, err
+	}
+	if o.container.Image == "" {
+		return tabulaRunner{}, errors.Join(err, errors.New("no TabulaContainer.Image given"))
+	}
+	runtime := o.container.Runtime
+	if runtime == "" {
+		for _, name := range []string{"docker", "podman"} {
+			if _, lookErr := exec.LookPath(name); lookErr == nil {
+				runtime = name
+				break
+			}
+		}
+		if runtime == "" {
+			return tabulaRunner{}, errors.Join(err, errors.New("no docker or podman found"))
+		}
+	}
+	runtime, lookErr := exec.LookPath(runtime)
+	if lookErr != nil {
+		return tabulaRunner{}, errors.Join(err, lookErr)
+	}
+	return tabulaRunner{runtime: runtime, image: o.container.Image, jvmArgs: o.jvmArgs}, nil
+}
+
+// command returns the command line running tabula with args,
+// jarFn and pdfFn being in dir.
+func (tr tabulaRunner) command(dir, jarFn, pdfFn string, args ...string) (string, []string) {
+	if tr.runtime == "" {
+		argv := append(append(tr.jvmArgs[:len(tr.jvmArgs):len(tr.jvmArgs)], "-jar", jarFn), args...)
+		return tr.java, append(argv, pdfFn)
+	}
+	const work = "/work"
+	argv := append([]string{"run", "--rm", "--network=none", "-v", dir + ":" + work + ":ro", tr.image, "java"},
+		tr.jvmArgs...)
+	argv = append(append(argv, "-jar", work+"/"+filepath.Base(jarFn)), args...)
+	return tr.runtime, append(argv, work+"/"+filepath.Base(pdfFn))
+}
+
+func parsePDFTabula(ctx context.Context, r io.Reader, o *options) ([]Hitelezo, error) {
+	logger := zlog.SFromContext(ctx)
+	logger.Info("ParsePDF tabula")
+	o.source.setBackend("tabula")
+	o.resetOrigins()
+	if o.tabulaJSON {
+		return parseTabulaTables(ctx, r, o)
+	}
+	var hit []Hitelezo
+	err := tabulaRows(ctx, r, o, func(row []string) error {
+		o.origin.Row++
+		if len(hit) == 0 {
+			o.source.setTitleDate(strings.Join(row, " "))
+		}
+		var rec Hitelezo
+		// the short rows have the missing trailing cells empty
+		defaultMapping.apply(&rec, row)
+		hit = o.checkAppend(hit, rec)
+		return nil
+	})
+	return hit, err
+}
+
+// parseTabulaTables parses the JSON output of tabula (see WithTabulaJSON): the header rows are recognized
+// and skipped, and the rows without a Bankszerv continue the multi-line names and addresses of the previous record.
+func parseTabulaTables(ctx context.Context, r io.Reader, o *options) ([]Hitelezo, error) {
+	var hit []Hitelezo
+	hdr := o.newHeaderScanner()
+	var pending Hitelezo
+	var pendingOrigin RowOrigin
+	flush := func() {
+		if pending.Bankszerv != "" {
+			o.origin = pendingOrigin
+			hit = o.checkAppend(hit, pending)
+			pending = Hitelezo{}
+		}
+	}
+	var title bool
+	err := tabulaTables(ctx, r, o, func(t tabulaTable) error {
+		// a record does not continue in the next table
+		flush()
+		row := make([]string, 0, len(defaultMapping))
+		for i, cells := range t.Data {
+			row = row[:0]
+			empty := true
+			for _, c := range cells {
+				text := strings.Join(strings.Fields(c.Text), " ")
+				row = append(row, text)
+				empty = empty && text == ""
+			}
+			if empty {
+				continue
+			}
+			if !title {
+				o.source.setTitleDate(strings.Join(row, " "))
+				title = true
+			}
+			var rec Hitelezo
+			cdv, ok := hdr.record(&rec, row)
+			switch {
+			case !ok || isHitelezoHeader(row, o.columns): // the title and the header rows, repeated on each page
+				flush()
+			case rec.Bankszerv == "" && pending.Bankszerv != "":
+				for _, field := range []func(*Hitelezo) *string{fieldNev, fieldIrszam, fieldCim} {
+					if v := *field(&rec); v != "" {
+						p := field(&pending)
+						*p = strings.TrimSpace(*p + " " + v)
+					}
+				}
+			case looksLikeRecord([]string{rec.Bankszerv}):
+				flush()
+				if hdr.cdvCol >= 0 {
+					o.applyCDV(&rec, cdv)
+				}
+				pending, pendingOrigin = rec, RowOrigin{Page: t.Page, Row: i + 1}
+			default: // footers
+				flush()
+			}
+		}
+		return ctx.Err()
+	})
+	flush()
+	return hit, err
+}
+
+// tabulaRows extracts the tables from the PDF with tabula, calling fn with each row.
+func tabulaRows(ctx context.Context, r io.Reader, o *options, fn func(row []string) error) error {
+	var fnErr error
+	err := runTabula(ctx, r, o, "CSV", func(pr io.Reader) error {
+		cr := csv.NewReader(pr)
+		cr.FieldsPerRecord = -1
+		for {
+			row, err := cr.Read()
+			if err != nil {
+				if errors.Is(err, io.EOF) {
+					return nil
+				}
+				return fmt.Errorf("read csv: %w", err)
+			}
+			if fnErr = fn(row); fnErr != nil {
+				return fnErr
+			}
+		}
+	})
+	if fnErr != nil {
+		return fnErr
+	}
+	return err
+}
+
+// tabulaTable is a table of the JSON output of tabula.
+type tabulaTable struct {
+	// Page is the 1-based page number (0 if unknown).
+	Page int            `json:"page_number"`
+	Data [][]tabulaCell `json:"data"`
+}
+
+// tabulaCell is a cell of a tabulaTable, with its coordinates in points.
+type tabulaCell struct {
+	Top    float64 `json:"top"`
+	Left   float64 `json:"left"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+	Text   string  `json:"text"`
+}
+
+// tabulaTables extracts the tables from the PDF with tabula in JSON, calling fn with each table.
+func tabulaTables(ctx context.Context, r io.Reader, o *options, fn func(tabulaTable) error) error {
+	var fnErr error
+	err := runTabula(ctx, r, o, "JSON", func(pr io.Reader) error {
+		dec := json.NewDecoder(pr)
+		if _, err := dec.Token(); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("read json: %w", err)
+		}
+		for dec.More() {
+			var t tabulaTable
+			if err := dec.Decode(&t); err != nil {
+				return fmt.Errorf("read json: %w", err)
+			}
+			if fnErr = fn(t); fnErr != nil {
+				return fnErr
+			}
+		}
+		return nil
+	})
+	if fnErr != nil {
+		return fnErr
+	}
+	return err
+}
+
+// tabulaJarKey is the cache key of the tabula jar.
+func tabulaJarKey(jarURL, hash string) filecache.ActionID {
+	return filecache.NewActionID([]byte("tabula.jar\x00" + jarURL + "\x00" + hash))
+}
+
+// openTabulaJar returns the tabula jar: the local file, or the download cached in cache,
+// downloading it on the first use. Both are verified against the hash (see WithTabulaJar).
+func (o *options) openTabulaJar(ctx context.Context, cache *filecache.Cache) (io.ReadCloser, error) {
+	jarURL, hash := o.tabulaJar()
+	if fn, ok := localJar(jarURL); ok {
+		b, err := os.ReadFile(fn)
+		if err != nil {
+			return nil, err
+		}
+		if hash != "" {
+			if err = checkSHA256(fn, b, hash); err != nil {
+				return nil, err
+			}
+		}
+		return io.NopCloser(bytes.NewReader(b)), nil
+	}
+	if hash == "" {
+		return nil, fmt.Errorf("%s: the SHA-256 hash of the tabula jar is not set", jarURL)
+	}
+	key := tabulaJarKey(jarURL, hash)
+	if fn, _, _ := cache.GetFile(key); fn != "" {
+		if fh, err := os.Open(fn); err == nil {
+			return fh, nil
+		}
+	}
+	req, err := http.NewRequest("GET", jarURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", jarURL, err)
+	}
+	resp, err := o.do(ctx, o.client(), req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("%s: %s", resp.Request.URL, resp.Status)
+	}
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", jarURL, err)
+	}
+	if err = checkSHA256(jarURL, b, hash); err != nil {
+		return nil, err
+	}
+	if _, _, err = cache.Put(key, bytes.NewReader(b)); err != nil {
+		return nil, err
+	}
+	return io.NopCloser(bytes.NewReader(b)), nil
+}
+
+// runTabula runs tabula on the PDF with the output format ("CSV" or "JSON"), calling read with its output.
+func runTabula(ctx context.Context, r io.Reader, o *options, format string, read func(io.Reader) error) (err error) {
+	logger := zlog.SFromContext(ctx)
+	if err = o.tabulaHints.Validate(); err != nil {
+		return err
+	}
+	runner, err := o.tabulaRunner()
+	if err != nil {
+		return err
+	}
+	dir, err := os.MkdirTemp("", "giro-*")
+	if err != nil {
+		return fmt.Errorf("create temp dir: %w", err)
+	}
+	defer os.RemoveAll(dir)
+	cache, err := o.openCache()
+	if err != nil {
+		return err
+	}
+	rc, err := o.openTabulaJar(ctx, cache)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	jarFn := filepath.Join(dir, "tabula.jar")
+	if fh, err := os.OpenFile(jarFn, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0400); err != nil {
+		return fmt.Errorf("write jar file: %w", err)
+	} else if _, err = io.Copy(fh, rc); err != nil {
+		fh.Close()
+		return err
+	} else if err = fh.Close(); err != nil {
+		return err
+	}
+	pdfFh, err := os.Create(filepath.Join(dir, "x.pdf"))
+	if err != nil {
+		return fmt.Errorf("create temp pdf: %w", err)
+	}
+	defer pdfFh.Close()
+	if _, err = io.Copy(pdfFh, r); err != nil {
+		return fmt.Errorf("write temp pdf: %w", err)
+	}
+	if _, err = pdfFh.Seek(0, 0); err != nil {
+		return fmt.Errorf("seek %q: %w", pdfFh.Name(), err)
+	}
+	ctx, end := startSpan(ctx, "exec.java")
+	defer func() { end(err) }()
+	name, args := runner.command(dir, jarFn, pdfFh.Name(),
+		append(append(o.tabulaHints.args(o.pages), "-f", format), o.tabulaArgs...)...)
+	cmd := o.command(ctx, name, args...)
+	cmd.Stdin = pdfFh
+	cmd.Stderr = os.Stderr
+	pr, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("create stdout pipe: %w", err)
+	}
+	logger.Debug("start", "args", cmd.Args)
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("start %v: %w", cmd.Args, err)
+	}
+	if err = read(pr); err != nil {
+		// the rest of the output is drained, for the command not to block on writing it
+		_, _ = io.Copy(io.Discard, pr)
+		// the failure of the command is the cause
+		if waitErr := cmd.Wait(); waitErr != nil {
+			return waitErr
+		}
+		return err
+	}
+	return cmd.Wait()
+}
+
+func parsePDFPdfToText(ctx context.Context, r io.Reader, o *options) (_ []Hitelezo, err error) {
+	logger := zlog.SFromContext(ctx)
+	logger.Info("ParsePDF pdftotext")
+	o.source.setBackend("pdftotext")
+	ctx, end := startSpan(ctx, "exec.pdftotext")
+	defer func() { end(err) }()
+	cmd := o.command(ctx, "pdftotext", append(o.pages.pdftotext(), "-", "-")...)
+	cmd.Stdin = r
+	pr, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("%v: %w", cmd.Args, err)
+	}
+	hit, err := parseTXT(ctx, pr, o)
+	if waitErr := cmd.Wait(); waitErr != nil {
+		if err == nil || errors.Is(waitErr, ErrOutputLimit) || errors.Is(waitErr, ErrExecTimeout) {
+			err = waitErr
+		}
+	}
+	return hit, err
+}
+
+func parsePDFLayout(ctx context.Context, r io.Reader, o *options) (_ []Hitelezo, err error) {
+	logger := zlog.SFromContext(ctx)
+	logger.Info("ParsePDF pdftotext -layout")
+	o.source.setBackend("pdftotext-layout")
+	ctx, end := startSpan(ctx, "exec.pdftotext")
+	defer func() { end(err) }()
+	cmd := o.command(ctx, "pdftotext", append(append(o.pages.pdftotext(), "-layout"), "-", "-")...)
+	cmd.Stdin = r
+	pr, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("%v: %w", cmd.Args, err)
+	}
+	hit, err := parseLayout(pr, o)
+	if waitErr := cmd.Wait(); waitErr != nil {
+		if err == nil || errors.Is(waitErr, ErrOutputLimit) || errors.Is(waitErr, ErrExecTimeout) {
+			err = waitErr
+		}
+	}
+	return hit, err
+}
+
+// layoutRows extracts the lines of the PDF with "pdftotext -layout",
+// calling fn with the fields of each non-empty line (see layoutFields).
+func layoutRows(ctx context.Context, r io.Reader, o *options, fn func(row []string) error) (err error) {
+	ctx, end := startSpan(ctx, "exec.pdftotext")
+	defer func() { end(err) }()
+	cmd := o.command(ctx, "pdftotext", append(append(o.pages.pdftotext(), "-layout"), "-", "-")...)
+	cmd.Stdin = r
+	pr, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("%v: %w", cmd.Args, err)
+	}
+	scanner := bufio.NewScanner(newUTF8Reader(pr))
+	o.origin = RowOrigin{Page: 1}
+	for scanner.Scan() {
+		line := scanner.Text()
+		for strings.HasPrefix(line, "\f") {
+			o.origin = RowOrigin{Page: o.origin.Page + 1}
+			line = line[1:]
+		}
+		o.origin.Row++
+		fields := layoutFields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		row := make([]string, len(fields))
+		for i, f := range fields {
+			row[i] = f.text
+		}
+		if err = fn(row); err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = scanner.Err()
+	}
+	if waitErr := cmd.Wait(); waitErr != nil {
+		if err == nil || errors.Is(waitErr, ErrOutputLimit) || errors.Is(waitErr, ErrExecTimeout) {
+			err = waitErr
+		}
+	}
+	return err
+}
+
+// parsePDFOCR renders the pages of the PDF to images with pdftoppm,
+// recognizes them with tesseract keeping the spacing,
+// and parses the text by its columns as parseLayout.
+func parsePDFOCR(ctx context.Context, r io.Reader, o *options) (_ []Hitelezo, err error) {
+	logger := zlog.SFromContext(ctx)
+	logger.Info("ParsePDF OCR")
+	o.source.setBackend("tesseract")
+	ctx, end := startSpan(ctx, "exec.tesseract")
+	defer func() { end(err) }()
+	dir, err := os.MkdirTemp("", "giro-ocr-*")
+	if err != nil {
+		return nil, fmt.Errorf("create temp dir: %w", err)
+	}
+	defer os.RemoveAll(dir)
+	pdfFn := filepath.Join(dir, "x.pdf")
+	if err = writeFile(pdfFn, r); err != nil {
+		return nil, err
+	}
+	if err = run(o.command(ctx, "pdftoppm",
+		append(o.pages.pdftotext(), "-r", "300", "-gray", "-png", pdfFn, filepath.Join(dir, "page"))...),
+	); err != nil {
+		return nil, err
+	}
+	images, err := filepath.Glob(filepath.Join(dir, "page-*.png"))
+	if err != nil {
+		return nil, err
+	}
+	// pdftoppm pads the page numbers to the same width
+	slices.Sort(images)
+	lang := o.ocrLanguage
+	if lang == "" {
+		lang = DefaultOCRLanguage
+	}
+	var buf bytes.Buffer
+	for i, img := range images {
+		if i != 0 {
+			buf.WriteByte('\f')
+		}
+		cmd := o.command(ctx, "tesseract", img, "stdout", "-l", lang, "--psm", "6",
+			"-c", "preserve_interword_spaces=1")
+		cmd.Stdout = &buf
+		if err = run(cmd); err != nil {
+			return nil, err
+		}
+	}
+	return parseLayout(&buf, o)
+}
+
+// run runs the command, which must have no StdoutPipe.
+func run(cmd *limitedCmd) error {
+	if cmd.maxOutput > 0 && cmd.Stdout != nil {
+		cmd.Stdout = &limitedWriter{w: cmd.Stdout, c: cmd, n: cmd.maxOutput}
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("%v: %w", cmd.Args, err)
+	}
+	return cmd.Wait()
+}
+
+func writeFile(fn string, r io.Reader) error {
+	fh, err := os.Create(fn)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(fh, r); err != nil {
+		fh.Close()
+		return fmt.Errorf("write %q: %w", fn, err)
+	}
+	return fh.Close()
+}
+
+// detectTools returns the tools found, with their versions.
+func (o *options) detectTools(ctx context.Context) Tools {
+	version := func(name string, args ...string) Tool {
+		t := Tool{Name: name}
+		if t.Path, t.Err = exec.LookPath(name); t.Err != nil {
+			return t
+		}
+		var buf bytes.Buffer
+		cmd := o.command(ctx, t.Path, args...)
+		cmd.Stdout, cmd.Stderr = &buf, &buf
+		// some tools (such as pdftotext -v) exit with non-zero
+		_ = run(cmd)
+		t.Version = toolVersion(buf.String())
+		return t
+	}
+	var ts Tools
+	if java, err := o.lookJava(); err != nil {
+		ts.Java = Tool{Name: "java", Err: err}
+	} else {
+		ts.Java = version(java, "-version")
+		ts.Java.Name = "java"
+	}
+	ts.Tabula = Tool{Name: "tabula"}
+	jarURL, hash := o.tabulaJar()
+	if jarURL == DefaultTabulaJarURL {
+		ts.Tabula.Version = tabulaVersion
+	}
+	if _, ts.Tabula.Err = o.tabulaRunner(); ts.Tabula.Err == nil {
+		if fn, ok := localJar(jarURL); ok {
+			ts.Tabula.Path = fn
+		} else if cache, err := o.openCache(); err == nil {
+			ts.Tabula.Path, _, _ = cache.GetFile(tabulaJarKey(jarURL, hash))
+		}
+	}
+	ts.PdfToText = version("pdftotext", "-v")
+	ts.PdfToPPM = version("pdftoppm", "-v")
+	ts.Tesseract = version("tesseract", "--version")
+	return ts
+}
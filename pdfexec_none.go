@@ -0,0 +1,52 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build giro_noexec || js || wasip1
+
+package giro
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// execSupported reports whether the external commands (tabula, pdftotext, tesseract) can be run.
+//
+// This build has no os/exec and no tabula jar, for WebAssembly and the restricted sandboxes:
+// only the spreadsheets, HTML and CSV can be parsed.
+const execSupported = false
+
+var (
+	parsePDFTabula    = unsupportedPDF(PDFTabula)
+	parsePDFPdfToText = unsupportedPDF(PDFText)
+	parsePDFLayout    = unsupportedPDF(PDFLayout)
+	parsePDFOCR       = unsupportedPDF(PDFOCR)
+)
+
+// unsupportedPDF returns a parse function of the strategy returning ErrUnsupportedInBuild.
+func unsupportedPDF(s PDFStrategy) func(context.Context, io.Reader, *options) ([]Hitelezo, error) {
+	return func(context.Context, io.Reader, *options) ([]Hitelezo, error) {
+		return nil, fmt.Errorf("%s: %w", s, ErrUnsupportedInBuild)
+	}
+}
+
+// tabulaRows returns ErrUnsupportedInBuild.
+func tabulaRows(ctx context.Context, r io.Reader, o *options, fn func(row []string) error) error {
+	return fmt.Errorf("tabula: %w", ErrUnsupportedInBuild)
+}
+
+// layoutRows returns ErrUnsupportedInBuild.
+func layoutRows(ctx context.Context, r io.Reader, o *options, fn func(row []string) error) error {
+	return fmt.Errorf("pdftotext: %w", ErrUnsupportedInBuild)
+}
+
+// detectTools returns the tools, all unavailable with ErrUnsupportedInBuild.
+func (o *options) detectTools(ctx context.Context) Tools {
+	tool := func(name string) Tool { return Tool{Name: name, Err: ErrUnsupportedInBuild} }
+	return Tools{
+		Java: tool("java"), Tabula: tool("tabula"), PdfToText: tool("pdftotext"),
+		PdfToPPM: tool("pdftoppm"), Tesseract: tool("tesseract"),
+	}
+}
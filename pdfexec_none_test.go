@@ -0,0 +1,24 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build giro_noexec || js || wasip1
+
+package giro
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParsePDFUnsupported(t *testing.T) {
+	_, err := ParsePDF(context.Background(), strings.NewReader("%PDF-1.4\n"))
+	if !errors.Is(err, ErrUnsupportedInBuild) || !errors.Is(err, ErrPDFExtraction) {
+		t.Errorf("got %+v, wanted ErrUnsupportedInBuild", err)
+	}
+	if _, err := PDFOCR.parser(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,234 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/UNO-SOFT/giro/model"
+)
+
+// PDFStrategy is a way to extract the records from a PDF.
+type PDFStrategy string
+
+const (
+	// PDFTabula extracts the tables with tabula (needs Java, see WithJava and WithTabulaContainer).
+	PDFTabula = PDFStrategy("tabula")
+	// PDFText reconstructs the columns from the plain text of pdftotext.
+	PDFText = PDFStrategy("pdftotext")
+	// PDFLayout parses the fixed column positions of the text of "pdftotext -layout".
+	PDFLayout = PDFStrategy("pdftotext-layout")
+)
+
+// DefaultPDFStrategies is the default fallback chain of ParsePDF.
+var DefaultPDFStrategies = []PDFStrategy{PDFTabula, PDFText}
+
+// WithPDFStrategies sets the strategies ParsePDF tries in order, until the first success
+// (default is DefaultPDFStrategies).
+//
+// Each attempt is recorded in the Report, with its duration and error (see WithReport).
+func WithPDFStrategies(strategies ...PDFStrategy) Option {
+	return func(o *options) { o.pdfStrategies = strategies }
+}
+
+// WithoutPDFStrategies disables the strategies, such as PDFTabula on the hosts
+// where the startup of the JVM is too slow.
+func WithoutPDFStrategies(strategies ...PDFStrategy) Option {
+	return func(o *options) { o.pdfDisabled = append(o.pdfDisabled, strategies...) }
+}
+
+// strategies returns the enabled PDF strategies in order.
+func (o *options) strategies() []PDFStrategy {
+	strategies := o.pdfStrategies
+	if len(strategies) == 0 {
+		strategies = DefaultPDFStrategies
+	}
+	return slices.DeleteFunc(slices.Clone(strategies), func(s PDFStrategy) bool {
+		return slices.Contains(o.pdfDisabled, s)
+	})
+}
+
+// ParsePDFStrategies parses the comma-separated list of strategies, such as "pdftotext,tabula",
+// for the configuration files and the command lines.
+func ParsePDFStrategies(s string) ([]PDFStrategy, error) {
+	var strategies []PDFStrategy
+	for _, name := range strings.Split(s, ",") {
+		if name = strings.TrimSpace(name); name == "" {
+			continue
+		}
+		strategy := PDFStrategy(name)
+		if _, err := strategy.parser(); err != nil {
+			return nil, err
+		}
+		strategies = append(strategies, strategy)
+	}
+	return strategies, nil
+}
+
+// PDFAttempt is the outcome of a strategy of ParsePDF.
+type PDFAttempt struct {
+	Strategy PDFStrategy
+	Duration time.Duration
+	// Records is the number of the records extracted, even on error.
+	Records int
+	Err     error
+}
+
+func (a PDFAttempt) String() string {
+	if a.Err != nil {
+		return fmt.Sprintf("%s: %d records in %s: %v", a.Strategy, a.Records, a.Duration, a.Err)
+	}
+	return fmt.Sprintf("%s: %d records in %s", a.Strategy, a.Records, a.Duration)
+}
+
+// parser returns the parse function of the strategy.
+func (s PDFStrategy) parser() (func(context.Context, io.Reader, *options) ([]Hitelezo, error), error) {
+	switch s {
+	case PDFTabula:
+		return parsePDFTabula, nil
+	case PDFText:
+		return parsePDFPdfToText, nil
+	case PDFLayout:
+		return parsePDFLayout, nil
+	case PDFOCR:
+		return parsePDFOCR, nil
+	}
+	return nil, fmt.Errorf("unknown PDF strategy %q", s)
+}
+
+// layoutField is a field of a line of "pdftotext -layout", with its starting column.
+type layoutField struct {
+	start int
+	text  string
+}
+
+// layoutFields splits the line at the runs of at least two spaces.
+func layoutFields(line string) []layoutField {
+	var fields []layoutField
+	var col, start, spaces int
+	var b strings.Builder
+	flush := func() {
+		if b.Len() != 0 {
+			fields = append(fields, layoutField{start: start, text: b.String()})
+			b.Reset()
+		}
+	}
+	for _, r := range line {
+		switch {
+		case r == ' ' || r == '\t' || r == '\u00a0':
+			spaces++
+		default:
+			if spaces >= 2 {
+				flush()
+			}
+			if b.Len() == 0 {
+				start = col
+			} else if spaces == 1 {
+				b.WriteByte(' ')
+			}
+			spaces = 0
+			b.WriteRune(r)
+		}
+		col++
+	}
+	flush()
+	return fields
+}
+
+// parseLayout parses the output of "pdftotext -layout":
+// each record starts with a line of the Bankszerv, Nev, Irszam and Cim columns,
+// and the cells wrapped onto the next lines are appended to the Nev or Cim
+// by their column position.
+func parseLayout(r io.Reader, o *options) ([]Hitelezo, error) {
+	scanner := bufio.NewScanner(newUTF8Reader(r))
+	records := make([]Hitelezo, 0, 8192)
+	o.resetOrigins()
+	o.origin = RowOrigin{Page: 1}
+	var rec Hitelezo
+	var pending bool
+	var nevStart, cimStart int
+	flush := func() {
+		if pending {
+			o.origin.Row++
+			records = o.checkAppend(records, rec)
+			pending = false
+		}
+	}
+	for scanner.Scan() {
+		line := scanner.Text()
+		for strings.HasPrefix(line, "\f") {
+			flush()
+			o.origin = RowOrigin{Page: o.origin.Page + 1}
+			line = line[1:]
+		}
+		fields := layoutFields(line)
+		if len(fields) == 0 {
+			flush()
+			continue
+		}
+		if len(records) == 0 && !pending {
+			o.source.setTitleDate(line)
+		}
+		if f := fields[0].text; len(f) == 8 && model.IsDigits(f) && len(fields) >= 3 {
+			flush()
+			rec, pending = Hitelezo{Bankszerv: f}, true
+			nevStart, cimStart = fields[1].start, utf8.RuneCountInString(line)
+			irsz := -1
+			for i := 2; i < len(fields); i++ {
+				if t := fields[i].text; len(t) <= 5 && model.IsDigits(t) {
+					irsz = i
+					break
+				}
+			}
+			var nev, cim []layoutField
+			if irsz < 0 {
+				nev, cim = fields[1:2], fields[2:]
+			} else {
+				nev, cim = fields[1:irsz], fields[irsz+1:]
+				rec.Irszam = fields[irsz].text
+			}
+			rec.Nev = joinFields(nev)
+			rec.Cim = joinFields(cim)
+			if len(cim) != 0 {
+				cimStart = cim[0].start
+			}
+			continue
+		}
+		if !pending || fields[0].start < nevStart-1 ||
+			strings.Contains(line, "nyes Egyszer") || strings.HasSuffix(line, " oldal") {
+			flush()
+			continue
+		}
+		// continuation of the wrapped cells
+		for _, f := range fields {
+			p := &rec.Nev
+			if f.start-nevStart > cimStart-f.start {
+				p = &rec.Cim
+			}
+			if *p == "" {
+				*p = f.text
+			} else {
+				*p += " " + f.text
+			}
+		}
+	}
+	flush()
+	return records, scanner.Err()
+}
+
+func joinFields(fields []layoutField) string {
+	texts := make([]string, len(fields))
+	for i, f := range fields {
+		texts[i] = f.text
+	}
+	return strings.Join(texts, " ")
+}
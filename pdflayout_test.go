@@ -0,0 +1,79 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPDFStrategies(t *testing.T) {
+	strategies, err := ParsePDFStrategies(" pdftotext-layout,tabula,, ocr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []PDFStrategy{PDFLayout, PDFTabula, PDFOCR}; !slices.Equal(strategies, want) {
+		t.Errorf("got %q, wanted %q", strategies, want)
+	}
+	if _, err = ParsePDFStrategies("tabula,pure"); err == nil {
+		t.Error("wanted error for an unknown strategy")
+	}
+
+	o := newOptions([]Option{WithoutPDFStrategies(PDFTabula)})
+	if got := o.strategies(); !slices.Equal(got, []PDFStrategy{PDFText}) {
+		t.Errorf("got %q", got)
+	}
+	o = newOptions([]Option{WithoutPDFStrategies(PDFTabula), WithPDFStrategies(strategies...)})
+	if got := o.strategies(); !slices.Equal(got, []PDFStrategy{PDFLayout, PDFOCR}) {
+		t.Errorf("got %q", got)
+	}
+	if !slices.Equal(DefaultPDFStrategies, []PDFStrategy{PDFTabula, PDFText}) {
+		t.Errorf("DefaultPDFStrategies changed: %q", DefaultPDFStrategies)
+	}
+}
+
+func TestParseLayout(t *testing.T) {
+	const txt = `          2026.01.01-től érvényes Egyszerűsített Hitelesítő Tábla
+
+ Bankszerv     Név                                 Irsz.   Cím
+ 10002003      Magyar Államkincstár                1139    Budapest, Váci út 71.
+ 10023002      Magyar Államkincstár Baranya        7621    Pécs, Rákóczi út 34.
+               Vármegyei Igazgatóság                       (bejárat a Király utcából)
+ 11773016      OTP Bank Nyrt.                      1051    Budapest, Nádor u. 16.
+
+                                                                          1. oldal
+` + "\f" + ` 12001008      Raiffeisen Bank Zrt.                1133    Budapest, Váci út 116-118.
+`
+	var src Source
+	var origins []RowOrigin
+	o := newOptions([]Option{WithSource(&src), withOrigins(&origins)})
+	hs, err := parseLayout(strings.NewReader(txt), o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Hitelezo{
+		{Bankszerv: "10002003", Nev: "Magyar Államkincstár", Irszam: "1139", Cim: "Budapest, Váci út 71."},
+		{Bankszerv: "10023002", Nev: "Magyar Államkincstár Baranya Vármegyei Igazgatóság", Irszam: "7621",
+			Cim: "Pécs, Rákóczi út 34. (bejárat a Király utcából)"},
+		{Bankszerv: "11773016", Nev: "OTP Bank Nyrt.", Irszam: "1051", Cim: "Budapest, Nádor u. 16."},
+		{Bankszerv: "12001008", Nev: "Raiffeisen Bank Zrt.", Irszam: "1133", Cim: "Budapest, Váci út 116-118."},
+	}
+	if len(hs) != len(want) {
+		t.Fatalf("got %d records: %+v", len(hs), hs)
+	}
+	for i, h := range hs {
+		if h != want[i] {
+			t.Errorf("%d. got %+v, wanted %+v", i, h, want[i])
+		}
+	}
+	if len(origins) != 4 || origins[2] != (RowOrigin{Page: 1, Row: 3}) || origins[3] != (RowOrigin{Page: 2, Row: 1}) {
+		t.Errorf("got origins %+v", origins)
+	}
+	if !src.EffectiveDate.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("got source %+v", src)
+	}
+}
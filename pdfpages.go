@@ -0,0 +1,116 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"regexp"
+	"runtime"
+	"strconv"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// WithParallelPages makes ParsePDF split the PDF into chunks of pagesPerChunk pages,
+// and extract them concurrently (at most GOMAXPROCS at a time),
+// merging the records in page order.
+//
+// The page count is read from the page objects of the PDF;
+// if it is not found (compressed object streams), the PDF is parsed in one pass.
+func WithParallelPages(pagesPerChunk int) Option {
+	return func(o *options) { o.parallelPages = pagesPerChunk }
+}
+
+var rPDFPage = regexp.MustCompile(`/Type\s*/Page\b`)
+
+// pdfPageCount returns the number of page objects in the PDF, reading it line by line.
+func pdfPageCount(r io.Reader) (int, error) {
+	br := bufio.NewReaderSize(r, 64<<10)
+	var n int
+	for {
+		line, err := br.ReadSlice('\n')
+		n += len(rPDFPage.FindAllIndex(line, -1))
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return n, nil
+			} else if !errors.Is(err, bufio.ErrBufferFull) {
+				return n, err
+			}
+		}
+	}
+}
+
+// pdfPages is a page range of the PDF, 1-based, inclusive.
+type pdfPages struct{ First, Last int }
+
+// tabula returns the range as the tabula --pages argument ("all" for the zero range).
+func (p pdfPages) tabula() string {
+	if p.First == 0 {
+		return "all"
+	}
+	return strconv.Itoa(p.First) + "-" + strconv.Itoa(p.Last)
+}
+
+// pdftotext returns the range as pdftotext arguments.
+func (p pdfPages) pdftotext() []string {
+	if p.First == 0 {
+		return nil
+	}
+	return []string{"-f", strconv.Itoa(p.First), "-l", strconv.Itoa(p.Last)}
+}
+
+// parsePDFChunks parses the pages of the PDF by chunks, concurrently, with parse.
+func (o *options) parsePDFChunks(ctx context.Context, pdf *io.SectionReader, pages int,
+	parse func(context.Context, io.Reader, *options) ([]Hitelezo, error),
+) ([]Hitelezo, error) {
+	type chunk struct {
+		opts    options
+		origins []RowOrigin
+		records []Hitelezo
+	}
+	chunks := make([]chunk, 0, (pages+o.parallelPages-1)/o.parallelPages)
+	for first := 1; first <= pages; first += o.parallelPages {
+		c := chunk{opts: *o}
+		c.opts.pages = pdfPages{First: first, Last: min(first+o.parallelPages-1, pages)}
+		c.opts.parallelPages = 0
+		if first != 1 { // only the first page has the title
+			c.opts.source = nil
+		}
+		chunks = append(chunks, c)
+	}
+	grp, grpCtx := errgroup.WithContext(ctx)
+	grp.SetLimit(runtime.GOMAXPROCS(0))
+	for i := range chunks {
+		c := &chunks[i]
+		if o.origins != nil {
+			c.opts.origins = &c.origins
+		}
+		grp.Go(func() error {
+			var err error
+			c.records, err = parse(grpCtx, io.NewSectionReader(pdf, 0, pdf.Size()), &c.opts)
+			for j := range c.origins {
+				if c.origins[j].Page != 0 {
+					c.origins[j].Page += c.opts.pages.First - 1
+				}
+			}
+			return err
+		})
+	}
+	if err := grp.Wait(); err != nil {
+		return nil, err
+	}
+	var records []Hitelezo
+	o.resetOrigins()
+	for _, c := range chunks {
+		records = append(records, c.records...)
+		if o.origins != nil {
+			*o.origins = append(*o.origins, c.origins...)
+		}
+	}
+	return records, nil
+}
@@ -0,0 +1,55 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPDFChunks(t *testing.T) {
+	pdf := strings.NewReader("%PDF-1.4\n1 0 obj << /Type /Pages /Count 5 >>\n" +
+		strings.Repeat("<< /Type /Page /Parent 1 0 R >>\n", 5))
+	if n, err := pdfPageCount(pdf); err != nil || n != 5 {
+		t.Fatalf("got %d pages (%+v), wanted 5", n, err)
+	}
+
+	var origins []RowOrigin
+	o := newOptions([]Option{WithParallelPages(2), withOrigins(&origins)})
+	// a fake parser, returning a record for each page, slower for the earlier pages
+	parse := func(ctx context.Context, r io.Reader, o *options) ([]Hitelezo, error) {
+		time.Sleep(time.Duration(5-o.pages.First) * time.Millisecond)
+		var hs []Hitelezo
+		for p := o.pages.First; p <= o.pages.Last; p++ {
+			o.origin = RowOrigin{Page: p - o.pages.First + 1, Row: 1}
+			hs = o.checkAppend(hs, Hitelezo{Bankszerv: fmt.Sprintf("1000000%d", p), Nev: "x", Cim: "y"})
+		}
+		return hs, nil
+	}
+	hs, err := o.parsePDFChunks(context.Background(), io.NewSectionReader(pdf, 0, pdf.Size()), 5, parse)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, h := range hs {
+		got = append(got, h.Bankszerv)
+	}
+	if want := []string{"10000001", "10000002", "10000003", "10000004", "10000005"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+	for i, or := range origins {
+		if or.Page != i+1 {
+			t.Errorf("%d. got page %d, wanted %d", i, or.Page, i+1)
+		}
+	}
+	if (pdfPages{First: 3, Last: 4}).tabula() != "3-4" || (pdfPages{}).tabula() != "all" {
+		t.Error("tabula page range")
+	}
+}
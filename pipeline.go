@@ -0,0 +1,73 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import "strings"
+
+// Filter returns the records of hs for which keep returns true, in a new slice.
+func Filter(hs []Hitelezo, keep func(Hitelezo) bool) []Hitelezo {
+	out := make([]Hitelezo, 0, len(hs))
+	for _, h := range hs {
+		if keep(h) {
+			out = append(out, h)
+		}
+	}
+	return out
+}
+
+// Map returns the records of hs transformed by f, in a new slice.
+func Map(hs []Hitelezo, f func(Hitelezo) Hitelezo) []Hitelezo {
+	out := make([]Hitelezo, len(hs))
+	for i, h := range hs {
+		out[i] = f(h)
+	}
+	return out
+}
+
+// Step is a stage of a Pipeline.
+type Step func([]Hitelezo) []Hitelezo
+
+// Filtering returns a Step applying Filter with keep.
+func Filtering(keep func(Hitelezo) bool) Step {
+	return func(hs []Hitelezo) []Hitelezo { return Filter(hs, keep) }
+}
+
+// Mapping returns a Step applying Map with f.
+func Mapping(f func(Hitelezo) Hitelezo) Step {
+	return func(hs []Hitelezo) []Hitelezo { return Map(hs, f) }
+}
+
+// Pipeline is a sequence of Steps, applied in order.
+//
+//	giro.Pipeline{
+//		giro.Filtering(giro.Not(giro.OfBank(giro.TreasuryBankkod))),
+//		giro.Mapping(func(h giro.Hitelezo) giro.Hitelezo { h.Nev = strings.ToUpper(h.Nev); return h }),
+//	}.Apply(hs)
+type Pipeline []Step
+
+// Apply returns hs after all the Steps of p. hs is not modified.
+func (p Pipeline) Apply(hs []Hitelezo) []Hitelezo {
+	if len(p) == 0 {
+		return append([]Hitelezo(nil), hs...)
+	}
+	for _, step := range p {
+		hs = step(hs)
+	}
+	return hs
+}
+
+// TreasuryBankkod is the bank code of the Hungarian State Treasury (Magyar Államkincstár).
+const TreasuryBankkod = "100"
+
+// OfBank returns a predicate matching the records of the bank with the given
+// (3-digit) bank code - or any prefix of the Bankszerv.
+func OfBank(bankkod string) func(Hitelezo) bool {
+	return func(h Hitelezo) bool { return strings.HasPrefix(h.Bankszerv, bankkod) }
+}
+
+// Not returns the negation of the predicate.
+func Not(pred func(Hitelezo) bool) func(Hitelezo) bool {
+	return func(h Hitelezo) bool { return !pred(h) }
+}
@@ -0,0 +1,33 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPipeline(t *testing.T) {
+	hs := []Hitelezo{
+		{Bankszerv: "10002003", Nev: "Magyar Államkincstár"},
+		{Bankszerv: "11773016", Nev: "OTP Bank"},
+		{Bankszerv: "11773023", Nev: "OTP Bank Fiók"},
+		{Bankszerv: "12001008", Nev: "Raiffeisen Bank"},
+	}
+	got := Pipeline{
+		Filtering(Not(OfBank(TreasuryBankkod))),
+		Filtering(OfBank("117")),
+		Mapping(func(h Hitelezo) Hitelezo { h.Nev = strings.ToUpper(h.Nev); return h }),
+	}.Apply(hs)
+	if len(got) != 2 || got[0].Nev != "OTP BANK" || got[1].Bankszerv != "11773023" {
+		t.Errorf("got %+v", got)
+	}
+	if hs[1].Nev != "OTP Bank" {
+		t.Errorf("input modified: %+v", hs)
+	}
+	if got := (Pipeline{}).Apply(hs); len(got) != len(hs) || &got[0] == &hs[0] {
+		t.Errorf("empty pipeline: got %+v", got)
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// DefaultProbeConcurrency is the default number of concurrent probes of SearchXLSURL.
+const DefaultProbeConcurrency = 8
+
+// WithProbeConcurrency sets the number of the candidate links SearchXLSURL probes concurrently,
+// which is also the limit of its connections per host.
+func WithProbeConcurrency(n int) Option {
+	return func(o *options) {
+		if n > 0 {
+			o.probeConcurrency = n
+		}
+	}
+}
+
+// probeTransports are the shared transports of the probes, by concurrency,
+// to reuse their connections across SearchXLSURL calls.
+var probeTransports sync.Map
+
+// probeTransport returns the transport of the probes: the one set by WithTLSConfig,
+// or a shared clone of http.DefaultTransport with the connections per host limited to probeConcurrency.
+func (o *options) probeTransport() http.RoundTripper {
+	if o.httpClient != nil {
+		return o.httpClient.Transport
+	}
+	if tr, ok := probeTransports.Load(o.probeConcurrency); ok {
+		return tr.(*http.Transport)
+	}
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	tr.MaxConnsPerHost = o.probeConcurrency
+	tr.MaxIdleConnsPerHost = o.probeConcurrency
+	actual, _ := probeTransports.LoadOrStore(o.probeConcurrency, tr)
+	return actual.(*http.Transport)
+}
+
+// probe returns the redirect Location of the URL (empty if not redirected),
+// requested by HEAD, or by GET if the server does not support HEAD.
+//
+// client must not follow the redirects.
+func (o *options) probe(ctx context.Context, client *http.Client, URL string) (string, error) {
+	for _, method := range []string{"HEAD", "GET"} {
+		req, err := http.NewRequest(method, URL, nil)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", URL, err)
+		}
+		resp, err := o.do(ctx, client, req)
+		if err != nil {
+			return "", err
+		}
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<10))
+		resp.Body.Close()
+		switch {
+		case resp.StatusCode == http.StatusMethodNotAllowed || resp.StatusCode == http.StatusNotImplemented:
+			continue
+		case 300 <= resp.StatusCode && resp.StatusCode < 400:
+			return resp.Header.Get("Location"), nil
+		default:
+			return "", nil
+		}
+	}
+	return "", nil
+}
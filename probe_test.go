@@ -0,0 +1,158 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rogpeppe/retry"
+)
+
+func TestSearchProbe(t *testing.T) {
+	var mu sync.Mutex
+	methods := make(map[string]int)
+	var active, maxActive int
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/dokumentumok":
+			for i := range 10 {
+				fmt.Fprintf(w, `<a href="%s/documents/%d">doc</a>`, srv.URL, i)
+			}
+			fmt.Fprintf(w, `<a href="%s/documents/nohead">EHT</a>`, srv.URL)
+			return
+		case "/documents/nohead":
+			if r.Method == "HEAD" {
+				http.Error(w, "no HEAD", http.StatusMethodNotAllowed)
+				return
+			}
+			http.Redirect(w, r, "/files/EHT_20260101.pdf", http.StatusFound)
+			return
+		}
+		mu.Lock()
+		methods[r.Method]++
+		active++
+		maxActive = max(maxActive, active)
+		mu.Unlock()
+		time.Sleep(5 * time.Millisecond)
+		mu.Lock()
+		active--
+		mu.Unlock()
+		http.Redirect(w, r, "/files/other.pdf", http.StatusFound)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	got, err := SearchXLSURL(ctx, srv.URL+"/dokumentumok", DefaultPattern, WithProbeConcurrency(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != srv.URL+"/files/EHT_20260101.pdf" {
+		t.Errorf("got %q", got)
+	}
+	if methods["GET"] != 0 || methods["HEAD"] != 10 || maxActive > 2 {
+		t.Errorf("got %v requests, %d concurrent", methods, maxActive)
+	}
+}
+
+func TestSearchCandidates(t *testing.T) {
+	files := []string{"EHT_20260201.pdf", "AVT_15_01_2026.xlsx", "EHT_2025-12-01.xls", "other.pdf"}
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/dokumentumok" {
+			for i := range files {
+				fmt.Fprintf(w, `<a href="%s/documents/%d">doc</a>`, srv.URL, i)
+			}
+			return
+		}
+		var i int
+		fmt.Sscanf(r.URL.Path, "/documents/%d", &i)
+		http.Redirect(w, r, "/files/"+files[i], http.StatusFound)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	got, err := SearchCandidates(ctx, srv.URL+"/dokumentumok", DefaultPattern)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Candidate{
+		{URL: srv.URL + "/files/EHT_2025-12-01.xls", Filename: "EHT_2025-12-01.xls", Kind: "EHT",
+			Date: time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)},
+		{URL: srv.URL + "/files/AVT_15_01_2026.xlsx", Filename: "AVT_15_01_2026.xlsx", Kind: "AVT",
+			Date: time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)},
+		{URL: srv.URL + "/files/EHT_20260201.pdf", Filename: "EHT_20260201.pdf", Kind: "EHT",
+			Date: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %+v", got)
+	}
+	for i, c := range got {
+		if c != want[i] {
+			t.Errorf("%d. got %+v, wanted %+v", i, c, want[i])
+		}
+	}
+
+	// a newer AVT publication is not the EHT list
+	files = []string{"EHT_20260201.pdf", "AVT_15_03_2026.xlsx", "other.pdf"}
+	u, err := SearchXLSURL(ctx, srv.URL+"/dokumentumok", DefaultPattern)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := srv.URL + "/files/EHT_20260201.pdf"; u != want {
+		t.Errorf("got %q, wanted %q", u, want)
+	}
+}
+
+func TestSearchCandidatesErrors(t *testing.T) {
+	const n = 1100 // more than the old bounded channel
+	dead := httptest.NewServer(http.NotFoundHandler())
+	dead.Close()
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/dokumentumok" {
+			for i := range n {
+				fmt.Fprintf(w, `<a href="%s/documents/%d">doc</a>`, srv.URL, i)
+			}
+			for i := range 3 {
+				fmt.Fprintf(w, `<a href="%s/documents/%d">dead</a>`, dead.URL, i)
+			}
+			return
+		}
+		var i int
+		fmt.Sscanf(r.URL.Path, "/documents/%d", &i)
+		http.Redirect(w, r, fmt.Sprintf("/files/EHT_%08d.pdf", 20000101+i), http.StatusFound)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	noRetry := WithRetry(retry.Strategy{MaxCount: 1})
+	got, err := SearchCandidates(ctx, srv.URL+"/dokumentumok", `^EHT_`, noRetry, WithProbeConcurrency(16))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != n {
+		t.Errorf("got %d candidates, wanted %d", len(got), n)
+	}
+
+	_, err = SearchCandidates(ctx, srv.URL+"/dokumentumok", `^nothing$`, noRetry)
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("got %+v, wanted ErrNotFound", err)
+	}
+	if strings.Count(err.Error(), `Head "`+dead.URL) != 3 {
+		t.Errorf("got %+v, wanted the 3 failed candidates", err)
+	}
+}
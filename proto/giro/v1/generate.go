@@ -0,0 +1,9 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package girov1 is the protobuf schema of the records, and the gRPC Directory service
+// with its generated client (see server.GRPC for the server).
+package girov1
+
+//go:generate protoc -I ../.. --go_out=../.. --go_opt=paths=source_relative --go-grpc_out=../.. --go-grpc_opt=paths=source_relative giro/v1/giro.proto
@@ -0,0 +1,563 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: giro/v1/giro.proto
+
+package girov1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Hitelezo is a branch of a bank (giro.Hitelezo).
+type Hitelezo struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// bankszerv is the 8-digit branch code, as text (leading zeros are significant).
+	Bankszerv     string `protobuf:"bytes,1,opt,name=bankszerv,proto3" json:"bankszerv,omitempty"`
+	Bic           string `protobuf:"bytes,2,opt,name=bic,proto3" json:"bic,omitempty"`
+	Nev           string `protobuf:"bytes,3,opt,name=nev,proto3" json:"nev,omitempty"`
+	Irszam        string `protobuf:"bytes,4,opt,name=irszam,proto3" json:"irszam,omitempty"`
+	Cim           string `protobuf:"bytes,5,opt,name=cim,proto3" json:"cim,omitempty"`
+	NonStdIrszam  bool   `protobuf:"varint,6,opt,name=non_std_irszam,json=nonStdIrszam,proto3" json:"non_std_irszam,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Hitelezo) Reset() {
+	*x = Hitelezo{}
+	mi := &file_giro_v1_giro_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Hitelezo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Hitelezo) ProtoMessage() {}
+
+func (x *Hitelezo) ProtoReflect() protoreflect.Message {
+	mi := &file_giro_v1_giro_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Hitelezo.ProtoReflect.Descriptor instead.
+func (*Hitelezo) Descriptor() ([]byte, []int) {
+	return file_giro_v1_giro_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Hitelezo) GetBankszerv() string {
+	if x != nil {
+		return x.Bankszerv
+	}
+	return ""
+}
+
+func (x *Hitelezo) GetBic() string {
+	if x != nil {
+		return x.Bic
+	}
+	return ""
+}
+
+func (x *Hitelezo) GetNev() string {
+	if x != nil {
+		return x.Nev
+	}
+	return ""
+}
+
+func (x *Hitelezo) GetIrszam() string {
+	if x != nil {
+		return x.Irszam
+	}
+	return ""
+}
+
+func (x *Hitelezo) GetCim() string {
+	if x != nil {
+		return x.Cim
+	}
+	return ""
+}
+
+func (x *Hitelezo) GetNonStdIrszam() bool {
+	if x != nil {
+		return x.NonStdIrszam
+	}
+	return false
+}
+
+// Snapshot is a complete version of the list.
+type Snapshot struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Records []*Hitelezo            `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+	// sha256 is the hex SHA-256 of the source file.
+	Sha256 string `protobuf:"bytes,2,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	// effective_date is the effective date of the list, as YYYY-MM-DD.
+	EffectiveDate string `protobuf:"bytes,3,opt,name=effective_date,json=effectiveDate,proto3" json:"effective_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Snapshot) Reset() {
+	*x = Snapshot{}
+	mi := &file_giro_v1_giro_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Snapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Snapshot) ProtoMessage() {}
+
+func (x *Snapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_giro_v1_giro_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Snapshot.ProtoReflect.Descriptor instead.
+func (*Snapshot) Descriptor() ([]byte, []int) {
+	return file_giro_v1_giro_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Snapshot) GetRecords() []*Hitelezo {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+func (x *Snapshot) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *Snapshot) GetEffectiveDate() string {
+	if x != nil {
+		return x.EffectiveDate
+	}
+	return ""
+}
+
+type LookupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bankszerv     string                 `protobuf:"bytes,1,opt,name=bankszerv,proto3" json:"bankszerv,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LookupRequest) Reset() {
+	*x = LookupRequest{}
+	mi := &file_giro_v1_giro_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LookupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookupRequest) ProtoMessage() {}
+
+func (x *LookupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_giro_v1_giro_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookupRequest.ProtoReflect.Descriptor instead.
+func (*LookupRequest) Descriptor() ([]byte, []int) {
+	return file_giro_v1_giro_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *LookupRequest) GetBankszerv() string {
+	if x != nil {
+		return x.Bankszerv
+	}
+	return ""
+}
+
+type SearchRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// query is matched against the name and the address.
+	Query         string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Limit         int32  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchRequest) Reset() {
+	*x = SearchRequest{}
+	mi := &file_giro_v1_giro_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchRequest) ProtoMessage() {}
+
+func (x *SearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_giro_v1_giro_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchRequest.ProtoReflect.Descriptor instead.
+func (*SearchRequest) Descriptor() ([]byte, []int) {
+	return file_giro_v1_giro_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SearchRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type SearchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Records       []*Hitelezo            `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchResponse) Reset() {
+	*x = SearchResponse{}
+	mi := &file_giro_v1_giro_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResponse) ProtoMessage() {}
+
+func (x *SearchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_giro_v1_giro_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResponse.ProtoReflect.Descriptor instead.
+func (*SearchResponse) Descriptor() ([]byte, []int) {
+	return file_giro_v1_giro_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SearchResponse) GetRecords() []*Hitelezo {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+type StreamAllRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamAllRequest) Reset() {
+	*x = StreamAllRequest{}
+	mi := &file_giro_v1_giro_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamAllRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamAllRequest) ProtoMessage() {}
+
+func (x *StreamAllRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_giro_v1_giro_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamAllRequest.ProtoReflect.Descriptor instead.
+func (*StreamAllRequest) Descriptor() ([]byte, []int) {
+	return file_giro_v1_giro_proto_rawDescGZIP(), []int{5}
+}
+
+type SubscribeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeRequest) Reset() {
+	*x = SubscribeRequest{}
+	mi := &file_giro_v1_giro_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeRequest) ProtoMessage() {}
+
+func (x *SubscribeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_giro_v1_giro_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeRequest) Descriptor() ([]byte, []int) {
+	return file_giro_v1_giro_proto_rawDescGZIP(), []int{6}
+}
+
+// Change is a change of the list, sent when a new version is loaded.
+type Change struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Added   []*Hitelezo            `protobuf:"bytes,1,rep,name=added,proto3" json:"added,omitempty"`
+	Removed []*Hitelezo            `protobuf:"bytes,2,rep,name=removed,proto3" json:"removed,omitempty"`
+	// modified are the new versions of the modified records.
+	Modified []*Hitelezo `protobuf:"bytes,3,rep,name=modified,proto3" json:"modified,omitempty"`
+	// hash is the new hash of the list (giro.Directory.Hash).
+	Hash          string `protobuf:"bytes,4,opt,name=hash,proto3" json:"hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Change) Reset() {
+	*x = Change{}
+	mi := &file_giro_v1_giro_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Change) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Change) ProtoMessage() {}
+
+func (x *Change) ProtoReflect() protoreflect.Message {
+	mi := &file_giro_v1_giro_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Change.ProtoReflect.Descriptor instead.
+func (*Change) Descriptor() ([]byte, []int) {
+	return file_giro_v1_giro_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Change) GetAdded() []*Hitelezo {
+	if x != nil {
+		return x.Added
+	}
+	return nil
+}
+
+func (x *Change) GetRemoved() []*Hitelezo {
+	if x != nil {
+		return x.Removed
+	}
+	return nil
+}
+
+func (x *Change) GetModified() []*Hitelezo {
+	if x != nil {
+		return x.Modified
+	}
+	return nil
+}
+
+func (x *Change) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+var File_giro_v1_giro_proto protoreflect.FileDescriptor
+
+const file_giro_v1_giro_proto_rawDesc = "" +
+	"\n" +
+	"\x12giro/v1/giro.proto\x12\agiro.v1\"\x9c\x01\n" +
+	"\bHitelezo\x12\x1c\n" +
+	"\tbankszerv\x18\x01 \x01(\tR\tbankszerv\x12\x10\n" +
+	"\x03bic\x18\x02 \x01(\tR\x03bic\x12\x10\n" +
+	"\x03nev\x18\x03 \x01(\tR\x03nev\x12\x16\n" +
+	"\x06irszam\x18\x04 \x01(\tR\x06irszam\x12\x10\n" +
+	"\x03cim\x18\x05 \x01(\tR\x03cim\x12$\n" +
+	"\x0enon_std_irszam\x18\x06 \x01(\bR\fnonStdIrszam\"v\n" +
+	"\bSnapshot\x12+\n" +
+	"\arecords\x18\x01 \x03(\v2\x11.giro.v1.HitelezoR\arecords\x12\x16\n" +
+	"\x06sha256\x18\x02 \x01(\tR\x06sha256\x12%\n" +
+	"\x0eeffective_date\x18\x03 \x01(\tR\reffectiveDate\"-\n" +
+	"\rLookupRequest\x12\x1c\n" +
+	"\tbankszerv\x18\x01 \x01(\tR\tbankszerv\";\n" +
+	"\rSearchRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"=\n" +
+	"\x0eSearchResponse\x12+\n" +
+	"\arecords\x18\x01 \x03(\v2\x11.giro.v1.HitelezoR\arecords\"\x12\n" +
+	"\x10StreamAllRequest\"\x12\n" +
+	"\x10SubscribeRequest\"\xa1\x01\n" +
+	"\x06Change\x12'\n" +
+	"\x05added\x18\x01 \x03(\v2\x11.giro.v1.HitelezoR\x05added\x12+\n" +
+	"\aremoved\x18\x02 \x03(\v2\x11.giro.v1.HitelezoR\aremoved\x12-\n" +
+	"\bmodified\x18\x03 \x03(\v2\x11.giro.v1.HitelezoR\bmodified\x12\x12\n" +
+	"\x04hash\x18\x04 \x01(\tR\x04hash2\xf3\x01\n" +
+	"\tDirectory\x123\n" +
+	"\x06Lookup\x12\x16.giro.v1.LookupRequest\x1a\x11.giro.v1.Hitelezo\x129\n" +
+	"\x06Search\x12\x16.giro.v1.SearchRequest\x1a\x17.giro.v1.SearchResponse\x12;\n" +
+	"\tStreamAll\x12\x19.giro.v1.StreamAllRequest\x1a\x11.giro.v1.Hitelezo0\x01\x129\n" +
+	"\tSubscribe\x12\x19.giro.v1.SubscribeRequest\x1a\x0f.giro.v1.Change0\x01B/Z-github.com/UNO-SOFT/giro/proto/giro/v1;girov1b\x06proto3"
+
+var (
+	file_giro_v1_giro_proto_rawDescOnce sync.Once
+	file_giro_v1_giro_proto_rawDescData []byte
+)
+
+func file_giro_v1_giro_proto_rawDescGZIP() []byte {
+	file_giro_v1_giro_proto_rawDescOnce.Do(func() {
+		file_giro_v1_giro_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_giro_v1_giro_proto_rawDesc), len(file_giro_v1_giro_proto_rawDesc)))
+	})
+	return file_giro_v1_giro_proto_rawDescData
+}
+
+var file_giro_v1_giro_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_giro_v1_giro_proto_goTypes = []any{
+	(*Hitelezo)(nil),         // 0: giro.v1.Hitelezo
+	(*Snapshot)(nil),         // 1: giro.v1.Snapshot
+	(*LookupRequest)(nil),    // 2: giro.v1.LookupRequest
+	(*SearchRequest)(nil),    // 3: giro.v1.SearchRequest
+	(*SearchResponse)(nil),   // 4: giro.v1.SearchResponse
+	(*StreamAllRequest)(nil), // 5: giro.v1.StreamAllRequest
+	(*SubscribeRequest)(nil), // 6: giro.v1.SubscribeRequest
+	(*Change)(nil),           // 7: giro.v1.Change
+}
+var file_giro_v1_giro_proto_depIdxs = []int32{
+	0, // 0: giro.v1.Snapshot.records:type_name -> giro.v1.Hitelezo
+	0, // 1: giro.v1.SearchResponse.records:type_name -> giro.v1.Hitelezo
+	0, // 2: giro.v1.Change.added:type_name -> giro.v1.Hitelezo
+	0, // 3: giro.v1.Change.removed:type_name -> giro.v1.Hitelezo
+	0, // 4: giro.v1.Change.modified:type_name -> giro.v1.Hitelezo
+	2, // 5: giro.v1.Directory.Lookup:input_type -> giro.v1.LookupRequest
+	3, // 6: giro.v1.Directory.Search:input_type -> giro.v1.SearchRequest
+	5, // 7: giro.v1.Directory.StreamAll:input_type -> giro.v1.StreamAllRequest
+	6, // 8: giro.v1.Directory.Subscribe:input_type -> giro.v1.SubscribeRequest
+	0, // 9: giro.v1.Directory.Lookup:output_type -> giro.v1.Hitelezo
+	4, // 10: giro.v1.Directory.Search:output_type -> giro.v1.SearchResponse
+	0, // 11: giro.v1.Directory.StreamAll:output_type -> giro.v1.Hitelezo
+	7, // 12: giro.v1.Directory.Subscribe:output_type -> giro.v1.Change
+	9, // [9:13] is the sub-list for method output_type
+	5, // [5:9] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_giro_v1_giro_proto_init() }
+func file_giro_v1_giro_proto_init() {
+	if File_giro_v1_giro_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_giro_v1_giro_proto_rawDesc), len(file_giro_v1_giro_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_giro_v1_giro_proto_goTypes,
+		DependencyIndexes: file_giro_v1_giro_proto_depIdxs,
+		MessageInfos:      file_giro_v1_giro_proto_msgTypes,
+	}.Build()
+	File_giro_v1_giro_proto = out.File
+	file_giro_v1_giro_proto_goTypes = nil
+	file_giro_v1_giro_proto_depIdxs = nil
+}
@@ -0,0 +1,171 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrClosed is returned by the methods of a closed Refresher.
+var ErrClosed = errors.New("closed")
+
+// Refresher refreshes a Directory periodically.
+type Refresher struct {
+	Directory *Directory
+	// Fetch returns the fresh records - Parse(ctx, nil, Options...) if nil,
+	// and fills src with their provenance (say, passing WithSource(src) to Parse).
+	Fetch func(ctx context.Context, src *Source) ([]Hitelezo, error)
+	// Options are passed to Parse.
+	Options []Option
+	// Interval is the time between two refreshes, must be positive.
+	Interval time.Duration
+	// Webhook is notified when a refresh changes the Directory, if not nil.
+	//
+	// Notification errors are logged, the Directory is refreshed nevertheless.
+	Webhook *Webhook
+	// Store keeps the snapshot of each successful refresh, if not nil,
+	// by the EffectiveDate of the list (or the day of the refresh, if unknown).
+	//
+	// Store errors are logged, the Directory is refreshed nevertheless.
+	Store Store
+
+	initOnce  sync.Once
+	trigger   chan chan error
+	running   atomic.Bool
+	closeOnce sync.Once
+	closed    chan struct{}
+	// inFlight is read-locked by each refresh, and locked by Close to wait for them.
+	inFlight sync.RWMutex
+}
+
+func (r *Refresher) init() {
+	r.initOnce.Do(func() {
+		r.trigger = make(chan chan error)
+		r.closed = make(chan struct{})
+	})
+}
+
+// Run refreshes the Directory every Interval, or when triggered by TriggerRefresh,
+// until ctx is canceled (returning its error) or Close is called (returning nil).
+//
+// Refresh errors are logged, the Directory is left intact on errors.
+func (r *Refresher) Run(ctx context.Context) error {
+	r.init()
+	if !r.running.CompareAndSwap(false, true) {
+		panic("Refresher.Run called twice")
+	}
+	defer r.running.Store(false)
+	logger := newOptions(r.Options).logger(ctx)
+	ticker := time.NewTicker(r.Interval)
+	defer ticker.Stop()
+	for {
+		var resCh chan error
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-r.closed:
+			return nil
+		case <-ticker.C:
+		case resCh = <-r.trigger:
+		}
+		err := r.refresh(ctx)
+		if err != nil {
+			logger.Error("refresh", "error", err)
+		}
+		if resCh != nil {
+			resCh <- err
+			ticker.Reset(r.Interval)
+		}
+	}
+}
+
+// TriggerRefresh forces an immediate refresh, and returns its result.
+//
+// If Run is not running, the refresh is executed by TriggerRefresh itself.
+func (r *Refresher) TriggerRefresh(ctx context.Context) error {
+	r.init()
+	if !r.running.Load() {
+		return r.refresh(ctx)
+	}
+	resCh := make(chan error, 1)
+	select {
+	case r.trigger <- resCh:
+	case <-r.closed:
+		return ErrClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case err := <-resCh:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close stops Run, and waits for the in-flight refreshes to finish, or ctx to be done.
+//
+// The subsequent refreshes return ErrClosed.
+func (r *Refresher) Close(ctx context.Context) error {
+	r.init()
+	r.closeOnce.Do(func() { close(r.closed) })
+	done := make(chan struct{})
+	go func() {
+		r.inFlight.Lock()
+		r.inFlight.Unlock()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (r *Refresher) refresh(ctx context.Context) error {
+	r.inFlight.RLock()
+	defer r.inFlight.RUnlock()
+	select {
+	case <-r.closed:
+		return ErrClosed
+	default:
+	}
+	var src Source
+	var hs []Hitelezo
+	var err error
+	if r.Fetch == nil {
+		hs, err = Parse(ctx, nil, append(slices.Clip(r.Options), WithSource(&src))...)
+	} else {
+		hs, err = r.Fetch(ctx, &src)
+	}
+	if err != nil {
+		return err
+	}
+	o := newOptions(r.Options)
+	var old []Hitelezo
+	if r.Webhook != nil {
+		old = r.Directory.All()
+	}
+	r.Directory.swap(hs, src.EffectiveDate, time.Now(), o)
+	if r.Store != nil {
+		if err := r.Store.PutSnapshot(ctx, storeDate(src, time.Now()), hs); err != nil {
+			o.logger(ctx).Error("store", "error", err)
+		}
+	}
+	if r.Webhook != nil {
+		if c := Diff(old, hs); !c.Empty() {
+			if err := r.Webhook.Notify(ctx, c.Summarize(src.EffectiveDate, len(hs)), r.Options...); err != nil {
+				o.logger(ctx).Error("webhook", "url", r.Webhook.URL, "error", err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRefresherTrigger(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	var n atomic.Int32
+	r := Refresher{
+		Directory: NewDirectory(nil),
+		Interval:  time.Hour,
+		Fetch: func(_ context.Context, src *Source) ([]Hitelezo, error) {
+			n.Add(1)
+			src.EffectiveDate = time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+			return []Hitelezo{{Bankszerv: "10002003"}}, nil
+		},
+	}
+	done := make(chan error, 1)
+	go func() { done <- r.Run(ctx) }()
+	for !r.running.Load() {
+		time.Sleep(time.Millisecond)
+	}
+	if err := r.TriggerRefresh(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if n.Load() != 1 || r.Directory.Len() != 1 {
+		t.Errorf("got %d fetches, %d records", n.Load(), r.Directory.Len())
+	}
+	if d := r.Directory.EffectiveDate(); d.Day() != 2 || time.Since(r.Directory.FetchedAt()) > time.Minute {
+		t.Errorf("got effective date %s, fetched at %s", d, r.Directory.FetchedAt())
+	}
+	cancel()
+	<-done
+}
+
+func TestRefresherClose(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	started, release := make(chan struct{}), make(chan struct{})
+	var finished atomic.Bool
+	r := Refresher{
+		Directory: NewDirectory(nil),
+		Interval:  time.Hour,
+		Fetch: func(context.Context, *Source) ([]Hitelezo, error) {
+			close(started)
+			<-release
+			finished.Store(true)
+			return []Hitelezo{{Bankszerv: "10002003"}}, nil
+		},
+	}
+	done := make(chan error, 1)
+	go func() { done <- r.Run(ctx) }()
+	go func() { _ = r.TriggerRefresh(ctx) }()
+	<-started
+
+	shortCtx, shortCancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer shortCancel()
+	if err := r.Close(shortCtx); err == nil {
+		t.Error("Close returned before the in-flight refresh finished")
+	}
+	close(release)
+	if err := r.Close(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if !finished.Load() || r.Directory.Len() != 1 {
+		t.Errorf("in-flight refresh has not finished: %d records", r.Directory.Len())
+	}
+	if err := <-done; err != nil {
+		t.Errorf("Run: %+v", err)
+	}
+	if err := r.TriggerRefresh(ctx); !errors.Is(err, ErrClosed) {
+		t.Errorf("TriggerRefresh after Close: got %+v, wanted ErrClosed", err)
+	}
+}
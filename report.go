@@ -0,0 +1,41 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import "sync"
+
+// Report collects the non-fatal findings of parsing.
+//
+// Pass it to the parsing functions with WithReport.
+type Report struct {
+	mu sync.Mutex
+	// Warnings are the problems found in the data, which did not stop the parsing.
+	Warnings []error
+	// PDFAttempts are the strategies tried by ParsePDF, in order.
+	PDFAttempts []PDFAttempt
+}
+
+// WithReport sets the Report to collect the non-fatal findings into.
+func WithReport(rep *Report) Option {
+	return func(o *options) { o.report = rep }
+}
+
+func (rep *Report) warn(err error) {
+	if rep == nil {
+		return
+	}
+	rep.mu.Lock()
+	rep.Warnings = append(rep.Warnings, err)
+	rep.mu.Unlock()
+}
+
+func (rep *Report) attempt(a PDFAttempt) {
+	if rep == nil {
+		return
+	}
+	rep.mu.Lock()
+	rep.PDFAttempts = append(rep.PDFAttempts, a)
+	rep.mu.Unlock()
+}
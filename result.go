@@ -0,0 +1,45 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"context"
+	"io"
+	"slices"
+)
+
+// Result is the result of parsing, the same for every format.
+type Result struct {
+	// Records are the parsed records with their location.
+	// On error, they are the records parsed before the error.
+	Records []Record
+	// Warnings are the non-fatal findings (see Report).
+	Warnings []error
+	// PDFAttempts are the strategies tried for a PDF (see Report).
+	PDFAttempts []PDFAttempt
+	// Source is the metadata of the parsed file, with the format and the backend.
+	Source Source
+}
+
+// Hitelezok returns the records without their location.
+func (res Result) Hitelezok() []Hitelezo {
+	hs := make([]Hitelezo, len(res.Records))
+	for i, r := range res.Records {
+		hs[i] = r.Hitelezo
+	}
+	return hs
+}
+
+// ParseResult parses r as Parse does, returning the records, the warnings
+// and the metadata in a Result - for every format the same way.
+//
+// WithReport and WithSource are not needed, the Result contains their data.
+func ParseResult(ctx context.Context, r io.Reader, opts ...Option) (Result, error) {
+	var rep Report
+	var res Result
+	records, err := ParseRecords(ctx, r, append(slices.Clip(opts), WithReport(&rep), WithSource(&res.Source))...)
+	res.Records, res.Warnings, res.PDFAttempts = records, rep.Warnings, rep.PDFAttempts
+	return res, err
+}
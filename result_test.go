@@ -0,0 +1,58 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseResult(t *testing.T) {
+	ctx := context.Background()
+	var xlsx bytes.Buffer
+	if err := WriteXLSX(&xlsx, leadingZeros); err != nil {
+		t.Fatal(err)
+	}
+	var html strings.Builder
+	html.WriteString("<table>\n<tr><th>Bankszerv</th><th>Név</th><th>Irsz</th><th>Cím</th></tr>\n")
+	for _, h := range leadingZeros {
+		fmt.Fprintf(&html, "<tr><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>\n", h.Bankszerv, h.Nev, h.Irszam, h.Cim)
+	}
+	html.WriteString("</table>")
+
+	for _, tc := range []struct {
+		Name    string
+		Data    []byte
+		Format  Format
+		Backend string
+	}{
+		{"xlsx", xlsx.Bytes(), FormatXLSX, "excelize"},
+		{"html", []byte(html.String()), FormatHTML, "html"},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			res, err := ParseResult(ctx, bytes.NewReader(tc.Data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.Source.Format != tc.Format || res.Source.Backend != tc.Backend || res.Source.SHA256 == "" {
+				t.Errorf("got source %+v", res.Source)
+			}
+			if len(res.Records) != 2 || res.Records[0].Origin.Row == 0 {
+				t.Errorf("got records %+v", res.Records)
+			}
+			got := res.Hitelezok()
+			for i := range got {
+				got[i].BIC = leadingZeros[i].BIC
+			}
+			if !reflect.DeepEqual(got, leadingZeros) {
+				t.Errorf("got %+v", got)
+			}
+		})
+	}
+}
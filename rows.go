@@ -0,0 +1,219 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/UNO-SOFT/zlog/v2"
+	"github.com/extrame/xls"
+	"github.com/xuri/excelize/v2"
+)
+
+// ExtractRows calls fn with each row of the table of r, with its location,
+// in the format detected from its contents, as Parse: from a ZIP archive,
+// the first spreadsheet, PDF or HTML (see DefaultPattern) is read.
+//
+// The rows are not mapped to records, so any tabular publication can be read
+// (see package table): the cells are returned as they are, without cleanup.
+// The sheet is selected by WithSheet, WithSheetPattern or WithSheetAutoDetect,
+// and the PDF is extracted with the tabula and pdftotext strategies of WithPDFStrategies,
+// trying the next one if one fails.
+func ExtractRows(ctx context.Context, r io.Reader, fn func(row []string, origin RowOrigin) error, opts ...Option) (err error) {
+	o := newOptions(opts)
+	ctx, end := o.span(ctx, "ExtractRows")
+	defer func() { end(err) }()
+	sr, err := o.sectionReader(r)
+	if err != nil {
+		return err
+	}
+	return o.tableRows(ctx, sr, func(row []string) error { return fn(row, o.origin) })
+}
+
+// tableRows calls fn with each row of the table of sr, in the detected format,
+// keeping o.origin up to date.
+func (o *options) tableRows(ctx context.Context, sr *io.SectionReader, fn func(row []string) error) error {
+	format := DetectFormat(sr)
+	if format == FormatZIP {
+		var err error
+		if sr, err = o.openZIP(sr); err != nil {
+			return err
+		}
+		format = DetectFormat(sr)
+	}
+	if err := o.checkFormat(format); err != nil {
+		return err
+	}
+	zlog.SFromContext(ctx).Debug("tableRows", "format", format)
+	o.origin = RowOrigin{}
+	switch format {
+	case FormatPDF:
+		return inStage(ErrPDFExtraction, o.pdfRows(ctx, sr, fn))
+	case FormatHTML:
+		return inStage(ErrHTML, htmlRows(ctx, io.NewSectionReader(sr, 0, sr.Size()), o, fn))
+	case FormatXLSX:
+		return inStage(ErrSpreadsheet, o.xlsxRows(ctx, io.NewSectionReader(sr, 0, sr.Size()), fn))
+	case FormatXLS:
+		return inStage(ErrSpreadsheet, o.xlsRows(ctx, io.NewSectionReader(sr, 0, sr.Size()), fn))
+	case FormatCSV:
+		return csvRows(ctx, newUTF8Reader(io.NewSectionReader(sr, 0, sr.Size())), o, fn)
+	case "", FormatZIP:
+		return ErrFormatUnknown
+	}
+	return fmt.Errorf("%s is not supported: %w", format, ErrFormatUnknown)
+}
+
+// xlsxRows calls fn with each row of the selected sheet of the XLSX (see WithSheet).
+func (o *options) xlsxRows(ctx context.Context, r io.Reader, fn func(row []string) error) error {
+	wb, err := excelize.OpenReader(r, excelize.Options{UnzipXMLSizeLimit: streamXMLSizeLimit})
+	if err != nil {
+		return err
+	}
+	defer wb.Close()
+	sheet, err := o.selectSheet(wb)
+	if err != nil {
+		return err
+	}
+	rows, err := wb.Rows(sheet)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	o.origin = RowOrigin{Sheet: sheet}
+	for rows.Next() {
+		o.origin.Row++
+		row, err := rows.Columns()
+		if err != nil {
+			return fmt.Errorf("%s: %w", o.origin, err)
+		}
+		if err = fn(row); err != nil {
+			return err
+		}
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+	}
+	return rows.Error()
+}
+
+// xlsRows calls fn with each row of the first sheet of the XLS,
+// or reads it as XLSX if it cannot be opened as XLS (as ParseXLS).
+func (o *options) xlsRows(ctx context.Context, r io.ReadSeeker, fn func(row []string) error) error {
+	wb, err := xls.OpenReader(r, "utf8")
+	if err != nil {
+		zlog.SFromContext(ctx).Warn("xls open", "error", err)
+		if _, err = r.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		return o.xlsxRows(ctx, r, fn)
+	}
+	sheet := wb.GetSheet(0)
+	if sheet == nil {
+		return fmt.Errorf("this XLS file does not contain sheet no %d", 0)
+	}
+	o.origin = RowOrigin{Sheet: sheet.Name}
+	for n := 0; n < int(sheet.MaxRow); n++ {
+		row := sheet.Row(n)
+		if row == nil {
+			continue
+		}
+		o.origin.Row = n + 1
+		cells := make([]string, 0, row.LastCol()-row.FirstCol())
+		for j := row.FirstCol(); j < row.LastCol(); j++ {
+			cells = append(cells, fixLatin2(row.Col(j)))
+		}
+		if err := fn(cells); err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// csvRows calls fn with each row of the CSV, with any number of fields.
+func csvRows(ctx context.Context, r io.Reader, o *options, fn func(row []string) error) error {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	for {
+		row, err := cr.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		o.origin.Row++
+		if err = fn(row); err != nil {
+			return err
+		}
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+	}
+}
+
+// pdfRows calls fn with each row of the tables of the PDF, extracted by the first
+// successful strategy of WithPDFStrategies: PDFTabula, or the columns of "pdftotext -layout"
+// for PDFText and PDFLayout. PDFOCR is skipped.
+//
+// The rows of a strategy are collected before calling fn, so a failed strategy
+// does not leave its rows behind.
+func (o *options) pdfRows(ctx context.Context, sr *io.SectionReader, fn func(row []string) error) error {
+	if !execSupported {
+		return fmt.Errorf("pdfRows: %w", ErrUnsupportedInBuild)
+	}
+	type originRow struct {
+		origin RowOrigin
+		row    []string
+	}
+	logger := zlog.SFromContext(ctx)
+	var errs []error
+	var layoutTried bool
+	for _, s := range o.strategies() {
+		var extract func(context.Context, io.Reader, *options, func([]string) error) error
+		switch s {
+		case PDFTabula:
+			extract = tabulaRows
+		case PDFText, PDFLayout:
+			if layoutTried {
+				continue
+			}
+			layoutTried, extract = true, layoutRows
+		default:
+			continue
+		}
+		var rows []originRow
+		o.origin = RowOrigin{}
+		err := extract(ctx, io.NewSectionReader(sr, 0, sr.Size()), o, func(row []string) error {
+			if s == PDFTabula {
+				o.origin.Row++
+			}
+			rows = append(rows, originRow{origin: o.origin, row: row})
+			return ctx.Err()
+		})
+		logger.Info("pdfRows", "strategy", s, "rows", len(rows), "error", err)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", s, err))
+			continue
+		}
+		for _, r := range rows {
+			o.origin = r.origin
+			if err := fn(r.row); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if len(errs) == 0 {
+		return errors.New("no PDF strategy extracts rows")
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,19 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !js && !wasip1
+
+package giro
+
+import (
+	"io"
+
+	"github.com/tgulacsi/go/iohlp"
+)
+
+// sectionReader returns the contents of r as a SectionReader, kept in memory up to the
+// budget of WithMemoryBudget, and spilled to a (memory mapped) temporary file above it.
+func (o *options) sectionReader(r io.Reader) (*io.SectionReader, error) {
+	return iohlp.MakeSectionReader(r, o.memoryBudget)
+}
@@ -0,0 +1,25 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build js || wasip1
+
+package giro
+
+import (
+	"bytes"
+	"io"
+)
+
+// sectionReader returns the contents of r as a SectionReader, read into memory,
+// as there is no mmap on js and wasip1 (WithMemoryBudget is ignored).
+func (o *options) sectionReader(r io.Reader) (*io.SectionReader, error) {
+	if sr, ok := r.(*io.SectionReader); ok {
+		return sr, nil
+	}
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return io.NewSectionReader(bytes.NewReader(b), 0, int64(len(b))), nil
+}
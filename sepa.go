@@ -0,0 +1,137 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// SEPAReachability tells the SEPA schemes a bank is reachable by.
+type SEPAReachability struct {
+	SCT, SDD, SCTInst bool
+}
+
+// ParseSEPAReachability parses a SEPA reachability file (CSV, separated by comma or semicolon),
+// as published by EBA CLEARING and the other CSMs, into a map keyed by BIC.
+//
+// The columns are found by the header row: the BIC, and the SCT, SDD and SCT Inst (or Inst) flags.
+// A flag is set unless it is empty, "N", "No", "0" or "false".
+func ParseSEPAReachability(r io.Reader) (map[string]SEPAReachability, error) {
+	br := bufio.NewReader(r)
+	first, _ := br.Peek(4096)
+	cr := csv.NewReader(br)
+	if i := bytes.IndexByte(first, '\n'); i >= 0 {
+		first = first[:i]
+	}
+	if bytes.Count(first, []byte{';'}) > bytes.Count(first, []byte{','}) {
+		cr.Comma = ';'
+	}
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+
+	bicCol, sctCol, sddCol, instCol := -1, -1, -1, -1
+	m := make(map[string]SEPAReachability)
+	for {
+		row, err := cr.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return m, err
+		}
+		if bicCol < 0 {
+			for i, h := range row {
+				switch h = strings.ToUpper(strings.TrimSpace(h)); {
+				case strings.Contains(h, "BIC"):
+					bicCol = i
+				case strings.Contains(h, "INST"):
+					instCol = i
+				case strings.Contains(h, "SCT"):
+					sctCol = i
+				case strings.Contains(h, "SDD"):
+					sddCol = i
+				}
+			}
+			continue
+		}
+		get := func(i int) bool {
+			if i < 0 || i >= len(row) {
+				return false
+			}
+			switch strings.ToLower(strings.TrimSpace(row[i])) {
+			case "", "n", "no", "0", "false":
+				return false
+			}
+			return true
+		}
+		if bicCol >= len(row) {
+			continue
+		}
+		bic := strings.ToUpper(strings.TrimSpace(row[bicCol]))
+		if bic == "" {
+			continue
+		}
+		m[bic] = SEPAReachability{SCT: get(sctCol), SDD: get(sddCol), SCTInst: get(instCol)}
+	}
+	if len(m) == 0 {
+		return m, fmt.Errorf("SEPA reachability: %w", ErrNotFound)
+	}
+	return m, nil
+}
+
+// SetSEPA sets the SEPA reachability (see ParseSEPAReachability) used by SEPA.
+//
+// The reachability of an institution (the 8-character BIC) is its own entry if there is one,
+// the union of the entries of its branches (the 11-character BICs) otherwise.
+func (d *Directory) SetSEPA(reachability map[string]SEPAReachability) {
+	idx := make(map[string]SEPAReachability, len(reachability))
+	// sorted, for the same result from the keys normalized to the same BIC
+	bics := slices.Sorted(maps.Keys(reachability))
+	for _, bic := range bics {
+		idx[strings.ToUpper(strings.TrimSpace(bic))] = reachability[bic]
+	}
+	merged := make(map[string]SEPAReachability)
+	for bic, r := range idx {
+		if b8 := bic8(bic); b8 != bic {
+			// the institution is reachable if any of its branches is
+			old := merged[b8]
+			merged[b8] = SEPAReachability{SCT: old.SCT || r.SCT, SDD: old.SDD || r.SDD, SCTInst: old.SCTInst || r.SCTInst}
+		}
+	}
+	for b8, r := range merged {
+		if _, ok := idx[b8]; !ok {
+			idx[b8] = r
+		}
+	}
+	d.sepa.Store(&idx)
+}
+
+// SEPA returns the SEPA reachability of the bank of the branch of bankszerv, by its BIC.
+//
+// It reports false if the branch, its BIC, or its reachability is not known.
+func (d *Directory) SEPA(bankszerv string) (SEPAReachability, bool) {
+	idx := d.sepa.Load()
+	if idx == nil {
+		return SEPAReachability{}, false
+	}
+	h, ok := d.Lookup(bankszerv)
+	if !ok || h.BIC == "" {
+		return SEPAReachability{}, false
+	}
+	bic := strings.ToUpper(strings.TrimSpace(h.BIC))
+	if r, ok := (*idx)[bic]; ok {
+		return r, true
+	}
+	r, ok := (*idx)[bic8(bic)]
+	return r, ok
+}
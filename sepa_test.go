@@ -0,0 +1,71 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSEPA(t *testing.T) {
+	m, err := ParseSEPAReachability(strings.NewReader(`BIC;Participant name;SCT;SDD Core;SCT Inst
+OTPVHUHBXXX;OTP Bank;Y;Y;Y
+GIBAHUHB;Erste;Y;N;
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m) != 2 || m["GIBAHUHB"] != (SEPAReachability{SCT: true}) {
+		t.Fatalf("got %+v", m)
+	}
+
+	d := NewDirectory([]Hitelezo{
+		{Bankszerv: "11773016", BIC: "OTPVHUHB", Nev: "OTP Bank"},
+		{Bankszerv: "11600006", BIC: "GIBAHUHB", Nev: "Erste"},
+		{Bankszerv: "10002003", Nev: "Magyar Államkincstár"},
+	})
+	d.SetSEPA(m)
+	for bs, want := range map[string]SEPAReachability{
+		"11773016": {SCT: true, SDD: true, SCTInst: true},
+		"11600006": {SCT: true},
+	} {
+		if got, ok := d.SEPA(bs); !ok || got != want {
+			t.Errorf("%s: got %+v (%t), wanted %+v", bs, got, ok, want)
+		}
+	}
+	if got, ok := d.SEPA("10002003"); ok {
+		t.Errorf("no BIC: got %+v", got)
+	}
+}
+
+func TestSetSEPAConflicts(t *testing.T) {
+	d := NewDirectory([]Hitelezo{
+		{Bankszerv: "11773016", BIC: "OTPVHUHB", Nev: "OTP Bank"},
+		{Bankszerv: "11600006", BIC: "GIBAHUHB", Nev: "Erste"},
+		{Bankszerv: "11600013", BIC: "GIBAHUHBXXX", Nev: "Erste"},
+	})
+	m := map[string]SEPAReachability{
+		// the own entry of the institution wins over its branches
+		"OTPVHUHB":    {SCT: true},
+		"OTPVHUHBXXX": {SCT: true, SDD: true},
+		"OTPVHUHB123": {SCTInst: true},
+		// without one, the branches are united
+		"GIBAHUHBXXX": {SCT: true},
+		"GIBAHUHB123": {SCTInst: true},
+	}
+	// the map is ranged in random order
+	for range 20 {
+		d.SetSEPA(m)
+		for bs, want := range map[string]SEPAReachability{
+			"11773016": {SCT: true},
+			"11600006": {SCT: true, SCTInst: true},
+			"11600013": {SCT: true},
+		} {
+			if got, ok := d.SEPA(bs); !ok || got != want {
+				t.Fatalf("%s: got %+v (%t), wanted %+v", bs, got, ok, want)
+			}
+		}
+	}
+}
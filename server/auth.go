@@ -0,0 +1,121 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"crypto/subtle"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// apiKeyHeader is the header of the API key.
+const apiKeyHeader = "X-API-Key"
+
+// authRequired reports whether the API key is required: if there are APIKeys or APIKeyFunc.
+func (s *Server) authRequired() bool { return len(s.APIKeys) != 0 || s.APIKeyFunc != nil }
+
+// validKey reports whether key is one of the APIKeys, or accepted by APIKeyFunc.
+func (s *Server) validKey(key string) bool {
+	if key == "" {
+		return false
+	}
+	var ok bool
+	for _, k := range s.APIKeys {
+		// no early return, to not leak which key matched
+		if subtle.ConstantTimeCompare([]byte(key), []byte(k)) == 1 {
+			ok = true
+		}
+	}
+	return ok || (s.APIKeyFunc != nil && s.APIKeyFunc(key))
+}
+
+// admit checks the API key and the rate limit of the request,
+// writing the error response and returning false if it is refused.
+func (s *Server) admit(w http.ResponseWriter, r *http.Request) bool {
+	host, _, _ := net.SplitHostPort(r.RemoteAddr)
+	ok, wait := s.check(r.Header.Get(apiKeyHeader), host)
+	if !ok {
+		w.Header().Set("WWW-Authenticate", `APIKey realm="giro", header="`+apiKeyHeader+`"`)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return false
+	}
+	if wait > 0 {
+		w.Header().Set("Retry-After", retryAfter(wait))
+		http.Error(w, "too many requests", http.StatusTooManyRequests)
+		return false
+	}
+	return true
+}
+
+// check reports whether the API key is valid (or not required),
+// and the time to wait if the rate limit of the key (or the client address, without authentication) is exceeded.
+func (s *Server) check(key, addr string) (bool, time.Duration) {
+	if s.authRequired() {
+		if !s.validKey(key) {
+			return false, 0
+		}
+	} else {
+		// without authentication, the clients are limited by their address
+		key = addr
+	}
+	if s.RateLimit <= 0 {
+		return true, 0
+	}
+	return true, s.limiter.allow(key, s.RateLimit, s.RateBurst, time.Now())
+}
+
+// retryAfter returns the value of the Retry-After header: the wait in whole seconds.
+func retryAfter(wait time.Duration) string { return strconv.Itoa(int(math.Ceil(wait.Seconds()))) }
+
+// limiter is a token-bucket rate limiter per key.
+type limiter struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	calls   int
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// allow takes a token from the bucket of key (refilled with rate tokens per second, up to burst),
+// returning 0 if there was one, or the time until the next one.
+func (l *limiter) allow(key string, rate float64, burst int, now time.Time) time.Duration {
+	burst = max(burst, 1)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.buckets == nil {
+		l.buckets = make(map[string]*bucket)
+	}
+	if l.calls++; l.calls%1024 == 0 {
+		l.sweep(rate, burst, now)
+	}
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(burst), last: now}
+		l.buckets[key] = b
+	}
+	b.tokens = min(float64(burst), b.tokens+now.Sub(b.last).Seconds()*rate)
+	b.last = now
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / rate * float64(time.Second))
+}
+
+// sweep forgets the buckets refilled since, as they are the same as new ones.
+func (l *limiter) sweep(rate float64, burst int, now time.Time) {
+	for k, b := range l.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*rate >= float64(burst) {
+			delete(l.buckets, k)
+		}
+	}
+}
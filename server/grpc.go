@@ -0,0 +1,181 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"github.com/UNO-SOFT/giro"
+	girov1 "github.com/UNO-SOFT/giro/proto/giro/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultSearchLimit is the number of the records returned by Search without a limit.
+const DefaultSearchLimit = 100
+
+// GRPC serves the records of Directory as the giro.v1.Directory gRPC service:
+//
+//	gs := grpc.NewServer(grpc.UnaryInterceptor(srv.UnaryInterceptor()), grpc.StreamInterceptor(srv.StreamInterceptor()))
+//	girov1.RegisterDirectoryServer(gs, &server.GRPC{Directory: d})
+//
+// The clients are created by girov1.NewDirectoryClient.
+// The API keys and the rate limit are checked by the interceptors of Server (see Server.UnaryInterceptor).
+type GRPC struct {
+	girov1.UnimplementedDirectoryServer
+
+	Directory *giro.Directory
+	// DisplayName, if set, replaces the Nev of the served records.
+	DisplayName giro.DisplayName
+}
+
+var _ girov1.DirectoryServer = (*GRPC)(nil)
+
+// Lookup returns the record of the bankszerv, or a NotFound error.
+func (s *GRPC) Lookup(ctx context.Context, req *girov1.LookupRequest) (*girov1.Hitelezo, error) {
+	h, ok := s.Directory.Lookup(req.GetBankszerv())
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "%q not found", req.GetBankszerv())
+	}
+	return s.toProto(h), nil
+}
+
+// Search returns the records whose name or address contains the query,
+// ignoring the case and the diacritics (see giro.Fold).
+func (s *GRPC) Search(ctx context.Context, req *girov1.SearchRequest) (*girov1.SearchResponse, error) {
+	q := giro.Fold(strings.TrimSpace(req.GetQuery()))
+	if q == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty query")
+	}
+	limit := int(req.GetLimit())
+	if limit <= 0 {
+		limit = DefaultSearchLimit
+	}
+	var resp girov1.SearchResponse
+	for _, h := range s.Directory.All() {
+		if strings.Contains(giro.Fold(h.Nev), q) || strings.Contains(giro.Fold(h.Cim), q) {
+			if resp.Records = append(resp.Records, s.toProto(h)); len(resp.Records) == limit {
+				break
+			}
+		}
+	}
+	return &resp, ctx.Err()
+}
+
+// StreamAll sends all the records.
+func (s *GRPC) StreamAll(req *girov1.StreamAllRequest, stream grpc.ServerStreamingServer[girov1.Hitelezo]) error {
+	for _, h := range s.Directory.All() {
+		if err := stream.Send(s.toProto(h)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Subscribe sends a Change after each replacement of the records, until the client cancels.
+//
+// The headers are sent when the subscription is in effect.
+// As with giro.Directory.Subscribe, a client lagging behind misses the later changes,
+// but can compare the Hash with the last one seen.
+func (s *GRPC) Subscribe(req *girov1.SubscribeRequest, stream grpc.ServerStreamingServer[girov1.Change]) error {
+	ch := s.Directory.Subscribe()
+	defer s.Directory.Unsubscribe(ch)
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+	ctx := stream.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev := <-ch:
+			change := girov1.Change{Hash: ev.Hash}
+			for _, h := range ev.Changes.Added {
+				change.Added = append(change.Added, s.toProto(h))
+			}
+			for _, h := range ev.Changes.Removed {
+				change.Removed = append(change.Removed, s.toProto(h))
+			}
+			for _, m := range ev.Changes.Modified {
+				change.Modified = append(change.Modified, s.toProto(m.New))
+			}
+			if err := stream.Send(&change); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// toProto returns h, with its display name, as a girov1.Hitelezo.
+func (s *GRPC) toProto(h giro.Hitelezo) *girov1.Hitelezo {
+	if s.DisplayName != nil {
+		h.Nev = s.DisplayName(h)
+	}
+	return &girov1.Hitelezo{
+		Bankszerv: h.Bankszerv, Bic: h.BIC, Nev: h.Nev, Irszam: h.Irszam, Cim: h.Cim,
+		NonStdIrszam: h.NonStdIrszam,
+	}
+}
+
+// UnaryInterceptor returns the interceptor checking the API key and the rate limit of the unary calls
+// as for the HTTP endpoints (see APIKeys and RateLimit), sharing their limits:
+//
+//	gs := grpc.NewServer(grpc.UnaryInterceptor(srv.UnaryInterceptor()), grpc.StreamInterceptor(srv.StreamInterceptor()))
+//
+// The key is read from the x-api-key or the authorization ("Bearer <key>") metadata.
+// The refused calls fail with Unauthenticated or ResourceExhausted (with a retry-after header).
+func (s *Server) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := s.admitGRPC(ctx); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor returns the interceptor checking the API key and the rate limit of the streams,
+// as UnaryInterceptor.
+func (s *Server) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := s.admitGRPC(ss.Context()); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// admitGRPC checks the API key and the rate limit of the call, returning the status error if it is refused.
+func (s *Server) admitGRPC(ctx context.Context) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	var key string
+	if v := md.Get(strings.ToLower(apiKeyHeader)); len(v) != 0 {
+		key = v[0]
+	} else if v := md.Get("authorization"); len(v) != 0 {
+		if scheme, token, ok := strings.Cut(v[0], " "); ok && strings.EqualFold(scheme, "Bearer") {
+			key = strings.TrimSpace(token)
+		}
+	}
+	var addr string
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		addr = p.Addr.String()
+		if host, _, err := net.SplitHostPort(addr); err == nil {
+			addr = host
+		}
+	}
+	ok, wait := s.check(key, addr)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "unauthorized")
+	}
+	if wait > 0 {
+		_ = grpc.SetHeader(ctx, metadata.Pairs("retry-after", retryAfter(wait)))
+		return status.Error(codes.ResourceExhausted, "too many requests")
+	}
+	return nil
+}
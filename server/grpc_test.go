@@ -0,0 +1,144 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package server_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/UNO-SOFT/giro"
+	girov1 "github.com/UNO-SOFT/giro/proto/giro/v1"
+	"github.com/UNO-SOFT/giro/server"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestGRPC(t *testing.T) {
+	d := giro.NewDirectory([]giro.Hitelezo{
+		{Bankszerv: "10002003", Nev: "Magyar Államkincstár", Irszam: "1139", Cim: "Budapest, Váci út 71."},
+		{Bankszerv: "11773016", Nev: "OTP Bank", Irszam: "1051", Cim: "Budapest, Nádor u. 16."},
+	})
+	lis := bufconn.Listen(1 << 20)
+	gs := grpc.NewServer()
+	girov1.RegisterDirectoryServer(gs, &server.GRPC{Directory: d})
+	go gs.Serve(lis)
+	defer gs.Stop()
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	c := girov1.NewDirectoryClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if h, err := c.Lookup(ctx, &girov1.LookupRequest{Bankszerv: "10002003"}); err != nil || h.GetNev() != "Magyar Államkincstár" {
+		t.Errorf("got %v, %+v", h, err)
+	}
+	if _, err = c.Lookup(ctx, &girov1.LookupRequest{Bankszerv: "99999999"}); status.Code(err) != codes.NotFound {
+		t.Errorf("got %+v, wanted NotFound", err)
+	}
+
+	resp, err := c.Search(ctx, &girov1.SearchRequest{Query: "nador"})
+	if err != nil || len(resp.GetRecords()) != 1 || resp.GetRecords()[0].GetBankszerv() != "11773016" {
+		t.Errorf("got %v, %+v", resp, err)
+	}
+	if resp, err = c.Search(ctx, &girov1.SearchRequest{Query: "BUDAPEST", Limit: 1}); err != nil || len(resp.GetRecords()) != 1 {
+		t.Errorf("limited: got %v, %+v", resp, err)
+	}
+
+	all, err := c.StreamAll(ctx, &girov1.StreamAllRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var n int
+	for {
+		if _, err = all.Recv(); err != nil {
+			break
+		}
+		n++
+	}
+	if err != io.EOF || n != d.Len() {
+		t.Errorf("got %d records, %+v", n, err)
+	}
+
+	sub, err := c.Subscribe(ctx, &girov1.SubscribeRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = sub.Header(); err != nil { // subscribed
+		t.Fatal(err)
+	}
+	d.Swap([]giro.Hitelezo{
+		{Bankszerv: "10002003", Nev: "MÁK", Irszam: "1139", Cim: "Budapest, Váci út 71."},
+		{Bankszerv: "10023002", Nev: "Magyar Államkincstár Baranya", Irszam: "7621", Cim: "Pécs"},
+	})
+	change, err := sub.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(change.GetAdded()) != 1 || len(change.GetRemoved()) != 1 || len(change.GetModified()) != 1 ||
+		change.GetModified()[0].GetNev() != "MÁK" || change.GetHash() != d.Hash() {
+		t.Errorf("got %v", change)
+	}
+}
+
+func TestGRPCAuth(t *testing.T) {
+	d := giro.NewDirectory([]giro.Hitelezo{{Bankszerv: "10002003", Nev: "Magyar Államkincstár"}})
+	srv := &server.Server{Directory: d, APIKeys: []string{"key"}, RateLimit: 0.001, RateBurst: 2}
+	lis := bufconn.Listen(1 << 20)
+	gs := grpc.NewServer(grpc.UnaryInterceptor(srv.UnaryInterceptor()), grpc.StreamInterceptor(srv.StreamInterceptor()))
+	girov1.RegisterDirectoryServer(gs, &server.GRPC{Directory: d})
+	go gs.Serve(lis)
+	defer gs.Stop()
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	c := girov1.NewDirectoryClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	req := &girov1.LookupRequest{Bankszerv: "10002003"}
+
+	if _, err = c.Lookup(ctx, req); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("without key: got %+v, wanted Unauthenticated", err)
+	}
+	if _, err = c.Lookup(metadata.AppendToOutgoingContext(ctx, "x-api-key", "bad"), req); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("bad key: got %+v, wanted Unauthenticated", err)
+	}
+	all, err := c.StreamAll(ctx, &girov1.StreamAllRequest{})
+	if err == nil {
+		_, err = all.Recv()
+	}
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("stream without key: got %+v, wanted Unauthenticated", err)
+	}
+
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer key")
+	for i := range 2 {
+		if h, err := c.Lookup(ctx, req); err != nil || h.GetBankszerv() != "10002003" {
+			t.Errorf("%d. got %v, %+v", i, h, err)
+		}
+	}
+	var header metadata.MD
+	if _, err = c.Lookup(ctx, req, grpc.Header(&header)); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("over the limit: got %+v, wanted ResourceExhausted", err)
+	}
+	if v := header.Get("retry-after"); len(v) != 1 || v[0] == "0" {
+		t.Errorf("got retry-after %q", v)
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// health is the payload of /healthz and /readyz.
+type health struct {
+	Status  string `json:"status"`
+	Records int    `json:"records"`
+	// EffectiveDate is the date the records are effective from, if known.
+	EffectiveDate string `json:"effectiveDate,omitempty"`
+	// Loaded is the time of the last load (or swap) of the records.
+	Loaded time.Time `json:"loaded"`
+	// Fetched is the time of the last successful download of the records, if known.
+	Fetched *time.Time `json:"fetched,omitempty"`
+	// Age is the time since Fetched (or the EffectiveDate, if not fetched since the start), in seconds,
+	// zero if unknown.
+	Age    float64 `json:"age"`
+	Reason string  `json:"reason,omitempty"`
+}
+
+func (s *Server) health() health {
+	h := health{Status: "ok", Records: s.Directory.Len(), Loaded: s.Directory.LoadedAt()}
+	effective, fetched := s.Directory.EffectiveDate(), s.Directory.FetchedAt()
+	if !effective.IsZero() {
+		h.EffectiveDate = effective.Format(time.DateOnly)
+	}
+	// the swap time says nothing about the age of the data
+	since := fetched
+	if since.IsZero() {
+		since = effective
+	} else {
+		h.Fetched = &fetched
+	}
+	var age time.Duration
+	if !since.IsZero() {
+		age = time.Since(since)
+		h.Age = age.Round(time.Second).Seconds()
+	}
+	switch {
+	case h.Records == 0:
+		h.Status, h.Reason = "unavailable", "no records"
+	case s.MaxAge > 0 && since.IsZero():
+		h.Status, h.Reason = "unavailable", "age of the records unknown"
+	case s.MaxAge > 0 && age > s.MaxAge:
+		h.Status, h.Reason = "unavailable", "records older than "+s.MaxAge.String()
+	}
+	return h
+}
+
+// handleHealthz reports the liveness of the process, always with 200 OK.
+func (s *Server) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, s.health())
+}
+
+// handleReadyz reports 200 OK if there are records not older than MaxAge, 503 Service Unavailable otherwise.
+func (s *Server) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	h := s.health()
+	w.Header().Set("Content-Type", "application/json")
+	if h.Reason != "" {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_ = json.NewEncoder(w).Encode(h)
+}
@@ -0,0 +1,186 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/UNO-SOFT/giro"
+	"github.com/UNO-SOFT/zlog/v2"
+	"github.com/klauspost/compress/zstd"
+)
+
+// The media types of the representations of the records.
+const (
+	mediaJSON = "application/json"
+	mediaCSV  = "text/csv"
+	mediaXLSX = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	mediaXML  = "application/xml"
+)
+
+// representations are the media types served, in the order of preference on ties.
+var representations = []string{mediaJSON, mediaCSV, mediaXLSX, mediaXML}
+
+// etagSuffix distinguishes the ETags of the representations.
+var etagSuffix = map[string]string{mediaJSON: "json", mediaCSV: "csv", mediaXLSX: "xlsx", mediaXML: "xml"}
+
+// negotiate returns the media type of representations preferred by the Accept header,
+// or "" if none of them is acceptable.
+func negotiate(accept string) string {
+	if strings.TrimSpace(accept) == "" {
+		return mediaJSON
+	}
+	best, bestQ := "", 0.0
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(part)
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if s, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(s, 64); err != nil {
+				continue
+			}
+		}
+		if mediaType == "text/xml" {
+			mediaType = mediaXML
+		}
+		for _, rep := range representations {
+			if q > bestQ && matchMedia(mediaType, rep) {
+				best, bestQ = rep, q
+				break
+			}
+		}
+	}
+	return best
+}
+
+// matchMedia reports whether the media range (such as "text/*") matches the media type.
+func matchMedia(mediaRange, mediaType string) bool {
+	if mediaRange == "*/*" || mediaRange == mediaType {
+		return true
+	}
+	prefix, ok := strings.CutSuffix(mediaRange, "/*")
+	return ok && strings.HasPrefix(mediaType, prefix+"/")
+}
+
+// contentCodings are the content codings served, in the order of preference on ties.
+var contentCodings = []string{"zstd", "gzip"}
+
+// contentCoding returns the content coding of contentCodings preferred by the Accept-Encoding header,
+// or "" for the identity.
+func contentCoding(acceptEncoding string) string {
+	qs := make(map[string]float64)
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if coding = strings.ToLower(strings.TrimSpace(coding)); coding == "" {
+			continue
+		}
+		q := 1.0
+		if s, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			var err error
+			if q, err = strconv.ParseFloat(s, 64); err != nil {
+				continue
+			}
+		}
+		qs[coding] = q
+	}
+	best, bestQ := "", 0.0
+	for _, coding := range contentCodings {
+		q, ok := qs[coding]
+		if !ok {
+			q = qs["*"]
+		}
+		if q > bestQ {
+			best, bestQ = coding, q
+		}
+	}
+	return best
+}
+
+// xmlRecords is the root element of the XML representation.
+type xmlRecords struct {
+	XMLName xml.Name        `xml:"hitelezok"`
+	Records []giro.Hitelezo `xml:"hitelezo"`
+}
+
+// writeRecords writes the records in the representation negotiated by the request,
+// with a strong ETag derived from the hash of the Directory, answering the conditional requests.
+//
+// A single record (of a lookup) is written as an object in JSON and XML, as a one-row table otherwise.
+func (s *Server) writeRecords(w http.ResponseWriter, r *http.Request, hs []giro.Hitelezo, single bool) {
+	w.Header().Add("Vary", "Accept, Accept-Encoding")
+	mediaType := negotiate(r.Header.Get("Accept"))
+	if mediaType == "" {
+		http.Error(w, "acceptable: "+strings.Join(representations, ", "), http.StatusNotAcceptable)
+		return
+	}
+	coding := contentCoding(r.Header.Get("Accept-Encoding"))
+	// the representations differ by the media type and the encoding
+	etag := s.Directory.Hash()[:32] + "-" + etagSuffix[mediaType]
+	if coding != "" {
+		etag += "-" + coding
+	}
+	etag = strconv.Quote(etag)
+	w.Header().Set("ETag", etag)
+	if inm := r.Header.Get("If-None-Match"); inm != "" && (inm == "*" || strings.Contains(inm, etag)) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", mediaType)
+	if mediaType == mediaCSV || mediaType == mediaXML {
+		w.Header().Set("Content-Type", mediaType+"; charset=utf-8")
+	}
+	var out io.Writer = w
+	var err error
+	switch coding {
+	case "zstd":
+		var zw *zstd.Encoder
+		if zw, err = zstd.NewWriter(w); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Encoding", coding)
+		defer zw.Close()
+		out = zw
+	case "gzip":
+		w.Header().Set("Content-Encoding", coding)
+		zw := gzip.NewWriter(w)
+		defer zw.Close()
+		out = zw
+	}
+	switch mediaType {
+	case mediaCSV:
+		err = giro.WriteCSV(out, hs)
+	case mediaXLSX:
+		err = giro.WriteXLSX(out, hs)
+	case mediaXML:
+		io.WriteString(out, xml.Header)
+		if single {
+			err = xml.NewEncoder(out).Encode(struct {
+				XMLName xml.Name `xml:"hitelezo"`
+				giro.Hitelezo
+			}{Hitelezo: hs[0]})
+		} else {
+			err = xml.NewEncoder(out).Encode(xmlRecords{Records: hs})
+		}
+	default:
+		if single {
+			err = json.NewEncoder(out).Encode(hs[0])
+		} else {
+			err = json.NewEncoder(out).Encode(hs)
+		}
+	}
+	if err != nil {
+		zlog.SFromContext(r.Context()).Error("write", "media", mediaType, "error", err)
+	}
+}
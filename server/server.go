@@ -0,0 +1,167 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package server serves a giro.Directory over HTTP (see Server), and gRPC (see GRPC).
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	_ "embed"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/UNO-SOFT/giro"
+	"github.com/UNO-SOFT/zlog/v2"
+)
+
+// Server serves the records of Directory:
+//
+//	GET /hitelezo             all the records
+//	GET /hitelezo/{bankszerv} one record
+//
+// The records are served as JSON, CSV, XLSX or XML, as negotiated by the Accept header,
+// zstd- or gzip-compressed if accepted, with a strong ETag for the conditional requests.
+// The endpoints may require an API key, and be rate limited (see APIKeys and RateLimit).
+// The other endpoints serve JSON:
+//
+//	GET /capabilities         the reliability of the fields by publication (giro.Capabilities)
+//	POST /refresh             forces an immediate refresh with Refresher
+//	POST /validate            validates the account numbers (a JSON array, or text/plain lines), see giro.ValidateBatch
+//	GET /openapi.json         the OpenAPI 3 document of the API
+//	GET /healthz              the liveness, with the number and the age of the records
+//	GET /readyz               the readiness: 503 without records, or with records older than MaxAge
+type Server struct {
+	Directory *giro.Directory
+	// Refresher is used by POST /refresh, which is disabled if nil.
+	Refresher *giro.Refresher
+	// RefreshToken is the Bearer token required by POST /refresh,
+	// which is disabled if empty.
+	RefreshToken string
+	// DisplayName, if set, replaces the Nev of the served records.
+	DisplayName giro.DisplayName
+
+	// APIKeys are the keys accepted in the X-API-Key header.
+	// If there are APIKeys or APIKeyFunc, all the endpoints but /openapi.json and the probes require a key.
+	APIKeys []string
+	// APIKeyFunc reports whether the key is accepted, for the keys kept elsewhere.
+	APIKeyFunc func(key string) bool
+	// RateLimit is the number of requests per second allowed for each API key
+	// (or for each client address without authentication), unlimited if not positive.
+	RateLimit float64
+	// RateBurst is the number of requests allowed at once, above the RateLimit (at least 1).
+	RateBurst int
+
+	// MaxAge is the maximum age of the records for /readyz, unlimited if zero:
+	// the time since their last download (see giro.Directory.FetchedAt),
+	// or since their EffectiveDate if they have not been downloaded since the start.
+	MaxAge time.Duration
+	// MaxBatch is the maximum number of account numbers of a POST /validate, DefaultMaxBatch if not positive.
+	MaxBatch int
+
+	initOnce sync.Once
+	mux      *http.ServeMux
+	limiter  limiter
+}
+
+func (s *Server) init() {
+	s.initOnce.Do(func() {
+		s.mux = http.NewServeMux()
+		s.mux.HandleFunc("GET /hitelezo", s.handleAll)
+		s.mux.HandleFunc("GET /hitelezo/{bankszerv}", s.handleLookup)
+		s.mux.HandleFunc("GET /capabilities", s.handleCapabilities)
+		s.mux.HandleFunc("POST /refresh", s.handleRefresh)
+		s.mux.HandleFunc("POST /validate", s.handleValidate)
+		s.mux.HandleFunc("GET /openapi.json", handleOpenAPI)
+		s.mux.HandleFunc("GET /healthz", s.handleHealthz)
+		s.mux.HandleFunc("GET /readyz", s.handleReadyz)
+	})
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.init()
+	if !public[r.URL.Path] && !s.admit(w, r) {
+		return
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) handleAll(w http.ResponseWriter, r *http.Request) {
+	hs := s.Directory.All()
+	for i, h := range hs {
+		hs[i] = s.display(h)
+	}
+	s.writeRecords(w, r, hs, false)
+}
+
+func (s *Server) handleLookup(w http.ResponseWriter, r *http.Request) {
+	h, ok := s.Directory.Lookup(r.PathValue("bankszerv"))
+	if !ok {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	s.writeRecords(w, r, []giro.Hitelezo{s.display(h)}, true)
+}
+
+// display returns h with its display name.
+func (s *Server) display(h giro.Hitelezo) giro.Hitelezo {
+	if s.DisplayName != nil {
+		h.Nev = s.DisplayName(h)
+	}
+	return h
+}
+
+func (s *Server) handleCapabilities(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, giro.Capabilities())
+}
+
+// public are the paths served without API key and rate limit: the documentation and the probes.
+var public = map[string]bool{"/openapi.json": true, "/healthz": true, "/readyz": true}
+
+// openAPI is the OpenAPI document of the API, keep it in sync with the handlers.
+//
+//go:embed openapi.json
+var openAPI []byte
+
+func handleOpenAPI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(openAPI)
+}
+
+func (s *Server) handleRefresh(w http.ResponseWriter, r *http.Request) {
+	if s.Refresher == nil || s.RefreshToken == "" {
+		http.Error(w, "refresh is disabled", http.StatusForbidden)
+		return
+	}
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.RefreshToken)) != 1 {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="giro"`)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if err := s.Refresher.TriggerRefresh(r.Context()); err != nil {
+		zlog.SFromContext(r.Context()).Error("refresh", "error", err)
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	writeJSON(w, struct{ Records int }{s.Directory.Len()})
+}
+
+// Close closes the Refresher (if any), waiting for its in-flight refresh.
+//
+// Call it after http.Server.Shutdown, to stop the refreshes cleanly.
+func (s *Server) Close(ctx context.Context) error {
+	if s.Refresher == nil {
+		return nil
+	}
+	return s.Refresher.Close(ctx)
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,326 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package server_test
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/UNO-SOFT/giro"
+	"github.com/UNO-SOFT/giro/server"
+	"github.com/klauspost/compress/zstd"
+)
+
+func TestRefresh(t *testing.T) {
+	d := giro.NewDirectory(nil)
+	srv := &server.Server{
+		Directory: d,
+		Refresher: &giro.Refresher{Directory: d,
+			Fetch: func(context.Context, *giro.Source) ([]giro.Hitelezo, error) {
+				return []giro.Hitelezo{{Bankszerv: "10002003", Nev: "Magyar Államkincstár"}}, nil
+			},
+		},
+		RefreshToken: "secret",
+	}
+
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		srv.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		return w
+	}
+	if w := get("/hitelezo/10002003"); w.Code != http.StatusNotFound {
+		t.Errorf("got %d before refresh, wanted 404", w.Code)
+	}
+
+	for token, want := range map[string]int{"": http.StatusUnauthorized, "wrong": http.StatusUnauthorized, "secret": http.StatusOK} {
+		req := httptest.NewRequest("POST", "/refresh", nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		srv.ServeHTTP(w, req)
+		if w.Code != want {
+			t.Errorf("token=%q: got %d, wanted %d", token, w.Code, want)
+		}
+	}
+
+	w := get("/hitelezo/10002003")
+	if w.Code != http.StatusOK {
+		t.Fatalf("got %d after refresh: %s", w.Code, w.Body.String())
+	}
+	var h giro.Hitelezo
+	if err := json.NewDecoder(w.Body).Decode(&h); err != nil {
+		t.Fatal(err)
+	}
+	if h.Nev != "Magyar Államkincstár" {
+		t.Errorf("got %+v", h)
+	}
+}
+
+func TestCapabilities(t *testing.T) {
+	srv := &server.Server{Directory: giro.NewDirectory(nil)}
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, httptest.NewRequest("GET", "/capabilities", nil))
+	var m map[giro.Publication]map[string]string
+	if err := json.NewDecoder(w.Body).Decode(&m); err != nil {
+		t.Fatal(err)
+	}
+	if got := m[giro.PublicationSHT][giro.FieldBIC]; got != "authoritative" {
+		t.Errorf("got %q for SHT BIC: %v", got, m)
+	}
+}
+
+func TestNegotiation(t *testing.T) {
+	srv := &server.Server{Directory: giro.NewDirectory([]giro.Hitelezo{
+		{Bankszerv: "10002003", Nev: "Magyar Államkincstár", Irszam: "1139", Cim: "Budapest, Váci út 71."},
+	})}
+	get := func(path string, header ...string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", path, nil)
+		for i := 0; i < len(header); i += 2 {
+			req.Header.Set(header[i], header[i+1])
+		}
+		w := httptest.NewRecorder()
+		srv.ServeHTTP(w, req)
+		return w
+	}
+	for _, tc := range []struct {
+		Accept, ContentType, Prefix string
+	}{
+		{"", "application/json", `[{"Bankszerv":"10002003"`},
+		{"text/csv;q=0.9, application/xml", "application/xml; charset=utf-8", `<?xml`},
+		{"text/*", "text/csv; charset=utf-8", `"Bankszerv","BIC"`},
+		{"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", ""},
+	} {
+		w := get("/hitelezo", "Accept", tc.Accept)
+		if w.Code != http.StatusOK || w.Header().Get("Content-Type") != tc.ContentType ||
+			!strings.HasPrefix(w.Body.String(), tc.Prefix) {
+			t.Errorf("%q: got %d %q %q", tc.Accept, w.Code, w.Header().Get("Content-Type"), w.Body.String())
+		}
+	}
+	if w := get("/hitelezo/10002003", "Accept", "text/xml"); !strings.Contains(w.Body.String(), "<hitelezo><Bankszerv>10002003</Bankszerv>") {
+		t.Errorf("got %q", w.Body.String())
+	}
+	if w := get("/hitelezo", "Accept", "image/png"); w.Code != http.StatusNotAcceptable {
+		t.Errorf("got %d, wanted 406", w.Code)
+	}
+
+	w := get("/hitelezo", "Accept-Encoding", "gzip, br")
+	if w.Header().Get("Content-Encoding") != "gzip" {
+		t.Fatalf("got %q encoding", w.Header().Get("Content-Encoding"))
+	}
+	zr, err := gzip.NewReader(w.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var hs []giro.Hitelezo
+	if err = json.NewDecoder(zr).Decode(&hs); err != nil || len(hs) != 1 {
+		t.Errorf("got %+v, %+v", hs, err)
+	}
+	gzETag := w.Header().Get("ETag")
+
+	w = get("/hitelezo", "Accept-Encoding", "gzip;q=0.5, zstd")
+	if w.Header().Get("Content-Encoding") != "zstd" || w.Header().Get("ETag") == gzETag {
+		t.Fatalf("got %q encoding, ETag %q", w.Header().Get("Content-Encoding"), w.Header().Get("ETag"))
+	}
+	zd, err := zstd.NewReader(w.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer zd.Close()
+	if err = json.NewDecoder(zd).Decode(&hs); err != nil || len(hs) != 1 {
+		t.Errorf("zstd: got %+v, %+v", hs, err)
+	}
+
+	w = get("/hitelezo")
+	etag := w.Header().Get("ETag")
+	if etag == "" || etag == gzETag || strings.HasPrefix(etag, "W/") {
+		t.Errorf("got ETag %q (gzip: %q)", etag, gzETag)
+	}
+	if w = get("/hitelezo", "If-None-Match", etag); w.Code != http.StatusNotModified {
+		t.Errorf("got %d, wanted 304", w.Code)
+	}
+	if w = get("/hitelezo", "If-None-Match", etag, "Accept", "text/csv"); w.Code != http.StatusOK {
+		t.Errorf("CSV: got %d, wanted 200", w.Code)
+	}
+}
+
+func TestOpenAPI(t *testing.T) {
+	srv := &server.Server{Directory: giro.NewDirectory([]giro.Hitelezo{{Bankszerv: "10002003", Nev: "Magyar Államkincstár"}})}
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, httptest.NewRequest("GET", "/openapi.json", nil))
+	var doc struct {
+		OpenAPI string
+		Paths   map[string]map[string]any
+	}
+	if err := json.NewDecoder(w.Body).Decode(&doc); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(doc.OpenAPI, "3.") || len(doc.Paths) == 0 {
+		t.Fatalf("got %+v", doc)
+	}
+	// each documented operation is routed
+	for path, ops := range doc.Paths {
+		for method := range ops {
+			req := httptest.NewRequest(strings.ToUpper(method), strings.ReplaceAll(path, "{bankszerv}", "10002003"), nil)
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, req)
+			if w.Code == http.StatusNotFound || w.Code == http.StatusMethodNotAllowed {
+				t.Errorf("%s %s: got %d", method, path, w.Code)
+			}
+		}
+	}
+}
+
+func TestAPIKey(t *testing.T) {
+	srv := &server.Server{
+		Directory:  giro.NewDirectory([]giro.Hitelezo{{Bankszerv: "10002003", Nev: "Magyar Államkincstár"}}),
+		APIKeys:    []string{"key1"},
+		APIKeyFunc: func(key string) bool { return key == "key2" },
+		RateLimit:  0.001,
+		RateBurst:  2,
+	}
+	get := func(path, key, remoteAddr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", path, nil)
+		if key != "" {
+			req.Header.Set("X-API-Key", key)
+		}
+		req.RemoteAddr = remoteAddr
+		w := httptest.NewRecorder()
+		srv.ServeHTTP(w, req)
+		return w
+	}
+	for key, want := range map[string]int{"": http.StatusUnauthorized, "wrong": http.StatusUnauthorized, "key1": http.StatusOK, "key2": http.StatusOK} {
+		if w := get("/hitelezo/10002003", key, "192.0.2.1:1234"); w.Code != want {
+			t.Errorf("key=%q: got %d, wanted %d", key, w.Code, want)
+		}
+	}
+	if w := get("/openapi.json", "", "192.0.2.1:1234"); w.Code != http.StatusOK {
+		t.Errorf("openapi.json: got %d", w.Code)
+	}
+	// the burst of 2 is per key: key1 has one request left
+	if w := get("/hitelezo", "key1", "192.0.2.1:1234"); w.Code != http.StatusOK {
+		t.Errorf("second: got %d", w.Code)
+	}
+	w := get("/hitelezo", "key1", "192.0.2.2:1234")
+	if w.Code != http.StatusTooManyRequests || w.Header().Get("Retry-After") == "" {
+		t.Errorf("third: got %d, Retry-After=%q", w.Code, w.Header().Get("Retry-After"))
+	}
+	if w := get("/hitelezo", "key2", "192.0.2.1:1234"); w.Code != http.StatusOK {
+		t.Errorf("other key: got %d", w.Code)
+	}
+
+	// without authentication, by the client address
+	srv = &server.Server{Directory: srv.Directory, RateLimit: 0.001}
+	if w := get("/hitelezo", "", "192.0.2.1:1234"); w.Code != http.StatusOK {
+		t.Errorf("first: got %d", w.Code)
+	}
+	if w := get("/hitelezo", "", "192.0.2.1:5678"); w.Code != http.StatusTooManyRequests {
+		t.Errorf("same address: got %d", w.Code)
+	}
+	if w := get("/hitelezo", "", "192.0.2.2:1234"); w.Code != http.StatusOK {
+		t.Errorf("other address: got %d", w.Code)
+	}
+}
+
+func TestHealth(t *testing.T) {
+	srv := &server.Server{Directory: giro.NewDirectory(nil), APIKeys: []string{"key"}, MaxAge: time.Hour}
+	get := func(path string) (int, map[string]any) {
+		w := httptest.NewRecorder()
+		srv.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		var m map[string]any
+		if err := json.NewDecoder(w.Body).Decode(&m); err != nil {
+			t.Fatalf("%s: %+v", path, err)
+		}
+		return w.Code, m
+	}
+	if code, m := get("/healthz"); code != http.StatusOK || m["records"] != 0.0 {
+		t.Errorf("healthz: got %d %v", code, m)
+	}
+	if code, m := get("/readyz"); code != http.StatusServiceUnavailable || m["reason"] != "no records" {
+		t.Errorf("readyz: got %d %v", code, m)
+	}
+
+	ctx := context.Background()
+	store, err := giro.NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	hs := []giro.Hitelezo{{Bankszerv: "10002003", Nev: "Magyar Államkincstár"}}
+	if err = store.PutSnapshot(ctx, time.Date(2026, 1, 2, 0, 0, 0, 0, time.Local), hs); err != nil {
+		t.Fatal(err)
+	}
+	// the old snapshot is stale, although just loaded
+	if srv.Directory, _, err = giro.LoadStore(ctx, store); err != nil {
+		t.Fatal(err)
+	}
+	if code, m := get("/readyz"); code != http.StatusServiceUnavailable || m["records"] != 1.0 || m["effectiveDate"] != "2026-01-02" {
+		t.Errorf("old readyz: got %d %v", code, m)
+	}
+	srv.MaxAge = 0
+	if code, m := get("/readyz"); code != http.StatusOK || m["status"] != "ok" {
+		t.Errorf("unlimited readyz: got %d %v", code, m)
+	}
+
+	// a download makes it fresh, even with the old EffectiveDate
+	srv.MaxAge = time.Hour
+	srv.Directory.Swap(hs, giro.WithFetched(time.Now()))
+	if code, m := get("/readyz"); code != http.StatusOK || m["fetched"] == nil || m["effectiveDate"] != "2026-01-02" {
+		t.Errorf("fetched readyz: got %d %v", code, m)
+	}
+	srv.MaxAge = time.Nanosecond
+	time.Sleep(time.Millisecond)
+	if code, m := get("/readyz"); code != http.StatusServiceUnavailable || m["status"] != "unavailable" {
+		t.Errorf("stale readyz: got %d %v", code, m)
+	}
+
+	// nor is the age of the embedded snapshot known
+	srv.MaxAge = time.Hour
+	srv.Directory = giro.LoadEmbedded()
+	if code, m := get("/readyz"); code != http.StatusServiceUnavailable || m["reason"] != "age of the records unknown" {
+		t.Errorf("embedded readyz: got %d %v", code, m)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	srv := &server.Server{
+		Directory: giro.NewDirectory([]giro.Hitelezo{{Bankszerv: "10002003", Nev: "Magyar Államkincstár"}}),
+		MaxBatch:  2,
+	}
+	post := func(contentType, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/validate", strings.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+		w := httptest.NewRecorder()
+		srv.ServeHTTP(w, req)
+		return w
+	}
+	for _, tc := range []struct{ ContentType, Body string }{
+		{"application/json", `["10002003-00000000", "11773016-11111019"]`},
+		{"text/plain; charset=utf-8", "10002003-00000000\n\n11773016-11111019\n"},
+	} {
+		w := post(tc.ContentType, tc.Body)
+		var vs []giro.AccountValidation
+		if err := json.NewDecoder(w.Body).Decode(&vs); err != nil {
+			t.Fatalf("%s: %d %+v", tc.ContentType, w.Code, err)
+		}
+		if len(vs) != 2 || !vs[0].Valid || vs[0].Branch == nil || vs[1].Valid {
+			t.Errorf("%s: got %+v", tc.ContentType, vs)
+		}
+	}
+	if w := post("application/json", `["1", "2", "3"]`); w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("too many: got %d", w.Code)
+	}
+	if w := post("application/json", `{`); w.Code != http.StatusBadRequest {
+		t.Errorf("malformed: got %d", w.Code)
+	}
+	if w := post("text/plain", strings.Repeat("x", 1000)); w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("too big: got %d", w.Code)
+	}
+}
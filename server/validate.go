@@ -0,0 +1,68 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/UNO-SOFT/giro"
+)
+
+// DefaultMaxBatch is the default of Server.MaxBatch.
+const DefaultMaxBatch = 10_000
+
+// maxAccountLen is the maximum length of an account number (an IBAN with spaces) in the request body.
+const maxAccountLen = 64
+
+// handleValidate validates the account numbers of the request body (giro.ValidateBatch):
+// a JSON array of strings, or text/plain lines.
+func (s *Server) handleValidate(w http.ResponseWriter, r *http.Request) {
+	maxBatch := s.MaxBatch
+	if maxBatch <= 0 {
+		maxBatch = DefaultMaxBatch
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, int64(maxBatch)*maxAccountLen)
+	var inputs []string
+	var err error
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "text/plain" {
+		scanner := bufio.NewScanner(r.Body)
+		for scanner.Scan() {
+			if line := strings.TrimSpace(scanner.Text()); line != "" {
+				inputs = append(inputs, line)
+			}
+		}
+		err = scanner.Err()
+	} else {
+		err = json.NewDecoder(r.Body).Decode(&inputs)
+	}
+	if err != nil {
+		code := http.StatusBadRequest
+		if mbe := (*http.MaxBytesError)(nil); errors.As(err, &mbe) {
+			code = http.StatusRequestEntityTooLarge
+		}
+		http.Error(w, err.Error(), code)
+		return
+	}
+	if len(inputs) > maxBatch {
+		http.Error(w, fmt.Sprintf("%d account numbers, at most %d are allowed", len(inputs), maxBatch), http.StatusRequestEntityTooLarge)
+		return
+	}
+	vs := giro.ValidateBatch(s.Directory, inputs)
+	if s.DisplayName != nil {
+		for i, v := range vs {
+			if v.Branch != nil {
+				h := s.display(*v.Branch)
+				vs[i].Branch = &h
+			}
+		}
+	}
+	writeJSON(w, vs)
+}
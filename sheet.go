@@ -0,0 +1,92 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/UNO-SOFT/giro/model"
+	"github.com/xuri/excelize/v2"
+)
+
+// WithSheet makes ParseXLSX read the named sheet, instead of the first one.
+func WithSheet(name string) Option {
+	return func(o *options) { o.sheet = sheetSelector{name: name} }
+}
+
+// WithSheetPattern makes ParseXLSX read the first sheet with its name matching pattern.
+func WithSheetPattern(pattern *regexp.Regexp) Option {
+	return func(o *options) { o.sheet = sheetSelector{pattern: pattern} }
+}
+
+// WithSheetAutoDetect makes ParseXLSX read the first visible sheet
+// with a header row of the expected columns (or starting with records).
+func WithSheetAutoDetect() Option {
+	return func(o *options) { o.sheet = sheetSelector{auto: true} }
+}
+
+// sheetSelector selects the sheet to parse, the first one if zero.
+type sheetSelector struct {
+	name    string
+	pattern *regexp.Regexp
+	auto    bool
+}
+
+// headerScanRows is the number of rows scanned for the header by WithSheetAutoDetect.
+const headerScanRows = 10
+
+// selectSheet returns the name of the sheet to parse.
+func (o *options) selectSheet(wb *excelize.File) (string, error) {
+	sel := o.sheet
+	switch {
+	case sel.name != "":
+		if i, err := wb.GetSheetIndex(sel.name); err != nil || i < 0 {
+			return "", fmt.Errorf("sheet %q: %w", sel.name, ErrNotFound)
+		}
+		return sel.name, nil
+	case sel.pattern != nil:
+		for _, name := range wb.GetSheetList() {
+			if sel.pattern.MatchString(name) {
+				return name, nil
+			}
+		}
+		return "", fmt.Errorf("sheet matching %q: %w", sel.pattern, ErrNotFound)
+	case sel.auto:
+		for _, name := range wb.GetSheetList() {
+			if visible, err := wb.GetSheetVisible(name); err != nil || !visible {
+				continue
+			}
+			if ok, err := hasRecords(wb, name, o.columns); err != nil {
+				return "", err
+			} else if ok {
+				return name, nil
+			}
+		}
+		return "", fmt.Errorf("sheet with records: %w", ErrNotFound)
+	}
+	return wb.GetSheetName(0), nil
+}
+
+// hasRecords reports whether the sheet has a header row of the expected columns,
+// or a record, in its first rows.
+func hasRecords(wb *excelize.File, sheet string, columns map[string]string) (bool, error) {
+	rows, err := wb.Rows(sheet)
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+	for i := 0; i < headerScanRows && rows.Next(); i++ {
+		row, err := rows.Columns()
+		if err != nil {
+			return false, err
+		}
+		if isHitelezoHeader(row, columns) ||
+			len(row) >= 3 && len(cellToCode(row[0], 8)) == 8 && model.IsDigits(cellToCode(row[0], 8)) {
+			return true, nil
+		}
+	}
+	return false, rows.Error()
+}
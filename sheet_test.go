@@ -0,0 +1,78 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestSheetSelection(t *testing.T) {
+	wb := excelize.NewFile()
+	if err := wb.SetSheetName("Sheet1", "Meta"); err != nil {
+		t.Fatal(err)
+	}
+	for i, row := range [][]string{{"Kiadva", "2026.01.01"}, {"Verzió", "3"}} {
+		if err := wb.SetSheetRow("Meta", "A"+string(rune('1'+i)), &row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := wb.NewSheet("Bankszervek 2026"); err != nil {
+		t.Fatal(err)
+	}
+	for i, row := range [][]string{
+		{"Bankszerv", "BIC", "Név", "Irányítószám", "Cím"},
+		{"10002003", "HUSTHUHB", "Magyar Államkincstár", "1139", "Budapest, Váci út 71."},
+	} {
+		if err := wb.SetSheetRow("Bankszervek 2026", "A"+string(rune('1'+i)), &row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := wb.SetSheetVisible("Meta", false); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := wb.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	for _, tc := range []struct {
+		Name    string
+		Opt     Option
+		WantErr error
+		Want    int
+	}{
+		{Name: "first"},
+		{Name: "name", Opt: WithSheet("Bankszervek 2026"), Want: 1},
+		{Name: "missing", Opt: WithSheet("Bankszervek"), WantErr: ErrNotFound},
+		{Name: "pattern", Opt: WithSheetPattern(regexp.MustCompile(`^Bankszerv`)), Want: 1},
+		{Name: "auto", Opt: WithSheetAutoDetect(), Want: 1},
+	} {
+		var opts []Option
+		if tc.Opt != nil {
+			opts = append(opts, tc.Opt)
+		}
+		var origins []RowOrigin
+		hs, err := ParseXLSX(ctx, bytes.NewReader(buf.Bytes()), append(opts, withOrigins(&origins))...)
+		if tc.WantErr != nil {
+			if !errors.Is(err, tc.WantErr) {
+				t.Errorf("%s: got %+v, wanted %v", tc.Name, err, tc.WantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %+v", tc.Name, err)
+		}
+		if len(hs) != tc.Want || tc.Want != 0 && (hs[0].BIC != "HUSTHUHB" || origins[0].Sheet != "Bankszervek 2026") {
+			t.Errorf("%s: got %+v, origins %+v", tc.Name, hs, origins)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// ErrSnapshotFormat is returned by DecodeSnapshot for data not written by EncodeSnapshot.
+var ErrSnapshotFormat = errors.New("invalid snapshot")
+
+// The binary snapshot is the magic, the version and the compression bytes,
+// followed by the (compressed) number of records and the records:
+// the length-prefixed fields, and the flags.
+const (
+	snapshotMagic   = "GIROSNAP"
+	snapshotVersion = 1
+	// snapshotGzip is the compression of the earlier snapshots, still read,
+	// snapshotZstd is the one written.
+	snapshotGzip = 1
+	snapshotZstd = 2
+	// snapshotNonStdIrszam is the flag of Hitelezo.NonStdIrszam.
+	snapshotNonStdIrszam = 1
+)
+
+// EncodeSnapshot writes the records in a compact, compressed binary format,
+// which DecodeSnapshot reads much faster than JSON.
+func EncodeSnapshot(w io.Writer, hs []Hitelezo) error { return encodeSnapshot(w, hs, snapshotZstd) }
+
+// encodeSnapshot writes the snapshot with the compression (snapshotGzip or snapshotZstd).
+func encodeSnapshot(w io.Writer, hs []Hitelezo, compression byte) error {
+	if _, err := io.WriteString(w, snapshotMagic+string([]byte{snapshotVersion, compression})); err != nil {
+		return err
+	}
+	var zw io.WriteCloser
+	var err error
+	if compression == snapshotGzip {
+		zw, err = gzip.NewWriterLevel(w, gzip.BestSpeed)
+	} else {
+		zw, err = zstd.NewWriter(w, zstd.WithEncoderLevel(zstd.SpeedFastest))
+	}
+	if err != nil {
+		return err
+	}
+	bw := bufio.NewWriter(zw)
+	var a [binary.MaxVarintLen64]byte
+	putUvarint := func(n uint64) { bw.Write(a[:binary.PutUvarint(a[:], n)]) }
+	putUvarint(uint64(len(hs)))
+	for _, h := range hs {
+		for _, s := range []string{h.Bankszerv, h.BIC, h.Nev, h.Irszam, h.Cim, h.SearchKey} {
+			putUvarint(uint64(len(s)))
+			bw.WriteString(s)
+		}
+		var flags byte
+		if h.NonStdIrszam {
+			flags |= snapshotNonStdIrszam
+		}
+		bw.WriteByte(flags)
+	}
+	if err = bw.Flush(); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// DecodeSnapshot reads the records written by EncodeSnapshot.
+func DecodeSnapshot(r io.Reader) ([]Hitelezo, error) {
+	var head [len(snapshotMagic) + 2]byte
+	if _, err := io.ReadFull(r, head[:]); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrSnapshotFormat, err)
+	}
+	if string(head[:len(snapshotMagic)]) != snapshotMagic {
+		return nil, fmt.Errorf("%w: no %s header", ErrSnapshotFormat, snapshotMagic)
+	}
+	var zr io.ReadCloser
+	var err error
+	switch v, c := head[len(snapshotMagic)], head[len(snapshotMagic)+1]; {
+	case v != snapshotVersion:
+		return nil, fmt.Errorf("%w: version %d, compression %d", ErrSnapshotFormat, v, c)
+	case c == snapshotGzip:
+		zr, err = gzip.NewReader(r)
+	case c == snapshotZstd:
+		var zd *zstd.Decoder
+		if zd, err = zstd.NewReader(r, zstd.WithDecoderConcurrency(1)); err == nil {
+			zr = zd.IOReadCloser()
+		}
+	default:
+		return nil, fmt.Errorf("%w: version %d, compression %d", ErrSnapshotFormat, v, c)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrSnapshotFormat, err)
+	}
+	defer zr.Close()
+	br := bufio.NewReader(zr)
+	n, err := binary.ReadUvarint(br)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrSnapshotFormat, err)
+	}
+	// do not trust n with the allocation
+	hs := make([]Hitelezo, 0, min(n, 1<<16))
+	var buf []byte
+	readString := func() (string, error) {
+		n, err := binary.ReadUvarint(br)
+		if err != nil {
+			return "", err
+		}
+		if n > 1<<16 {
+			return "", fmt.Errorf("field of %d bytes", n)
+		}
+		if uint64(cap(buf)) < n {
+			buf = make([]byte, n)
+		}
+		buf = buf[:n]
+		_, err = io.ReadFull(br, buf)
+		return string(buf), err
+	}
+	for i := uint64(0); i < n; i++ {
+		var h Hitelezo
+		for _, f := range []*string{&h.Bankszerv, &h.BIC, &h.Nev, &h.Irszam, &h.Cim, &h.SearchKey} {
+			if *f, err = readString(); err != nil {
+				return hs, fmt.Errorf("%w: record %d: %w", ErrSnapshotFormat, i, err)
+			}
+		}
+		flags, err := br.ReadByte()
+		if err != nil {
+			return hs, fmt.Errorf("%w: record %d: %w", ErrSnapshotFormat, i, err)
+		}
+		h.NonStdIrszam = flags&snapshotNonStdIrszam != 0
+		hs = append(hs, h)
+	}
+	return hs, nil
+}
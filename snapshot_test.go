@@ -0,0 +1,64 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSnapshot(t *testing.T) {
+	hs := append([]Hitelezo{
+		{Bankszerv: "11773016", BIC: "OTPVHUHB", Nev: "OTP Bank", Irszam: "H-1051", NonStdIrszam: true, Cim: "Budapest", SearchKey: "otp bank budapest"},
+	}, Fallback()...)
+	var buf bytes.Buffer
+	if err := EncodeSnapshot(&buf, hs); err != nil {
+		t.Fatal(err)
+	}
+	b := buf.Bytes()
+	got, err := DecodeSnapshot(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, hs) {
+		t.Errorf("got %d records, wanted %d", len(got), len(hs))
+	}
+
+	if b[len(snapshotMagic)+1] != snapshotZstd {
+		t.Errorf("got compression %d, wanted zstd", b[len(snapshotMagic)+1])
+	}
+
+	// the gzip-compressed snapshots are still read
+	var gz bytes.Buffer
+	if err = encodeSnapshot(&gz, hs, snapshotGzip); err != nil {
+		t.Fatal(err)
+	}
+	if got, err = DecodeSnapshot(&gz); err != nil || !reflect.DeepEqual(got, hs) {
+		t.Errorf("gzip: got %d records, %+v", len(got), err)
+	}
+
+	if _, err = DecodeSnapshot(strings.NewReader("[]")); !errors.Is(err, ErrSnapshotFormat) {
+		t.Errorf("JSON: got %+v, wanted ErrSnapshotFormat", err)
+	}
+	if _, err = DecodeSnapshot(bytes.NewReader(b[:len(b)/2])); !errors.Is(err, ErrSnapshotFormat) {
+		t.Errorf("truncated: got %+v, wanted ErrSnapshotFormat", err)
+	}
+}
+
+func BenchmarkDecodeSnapshot(b *testing.B) {
+	var buf bytes.Buffer
+	if err := EncodeSnapshot(&buf, Fallback()); err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := DecodeSnapshot(bytes.NewReader(buf.Bytes())); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Format is the format of a parsed file.
+type Format string
+
+const (
+	FormatPDF  = Format("pdf")
+	FormatXLSX = Format("xlsx")
+	FormatXLS  = Format("xls")
+	FormatHTML = Format("html")
+	// FormatCSV is the format written by WriteCSV.
+	FormatCSV = Format("csv")
+	// FormatODS is the OpenDocument spreadsheet, detected but not parsed.
+	FormatODS = Format("ods")
+)
+
+// Source describes the provenance of the parsed records.
+//
+// Pass it to Parse and DownloadFile with WithSource to have it filled.
+// When parsing a local file, set Filename for the EffectiveDate detection.
+type Source struct {
+	// URL and Filename (from Content-Disposition) are filled by DownloadFile.
+	URL, Filename string
+	// SHA256 is the hex-encoded SHA-256 hash of the parsed contents.
+	SHA256        string
+	ContentLength int64
+	LastModified  time.Time
+	Format        Format
+	// Entry is the name of the parsed file in the ZIP archive, if the list is wrapped in one.
+	Entry string
+	// Backend is the extractor producing the records:
+	// "excelize", "xls", "html", "tabula", "pdftotext", "pdftotext-layout" or "tesseract".
+	Backend string
+	// Upstream is the name of the Upstream the list is downloaded from by Parse(ctx, nil).
+	Upstream string
+	// EffectiveDate is the date the list is valid from, zero if unknown.
+	// It is parsed from the file name (see EffectiveDate) or the title of the list.
+	EffectiveDate time.Time
+}
+
+// WithSource sets the Source to fill with the provenance of the records.
+func WithSource(src *Source) Option {
+	return func(o *options) { o.source = src }
+}
+
+func (src *Source) setResponse(resp *http.Response, filename string) {
+	if src == nil {
+		return
+	}
+	src.URL, src.Filename = resp.Request.URL.String(), filename
+	src.ContentLength = resp.ContentLength
+	src.LastModified, _ = http.ParseTime(resp.Header.Get("Last-Modified"))
+	if filename == "" {
+		filename = resp.Request.URL.Path
+	}
+	src.setFilenameDate(filename)
+}
+
+func (src *Source) setContent(sr *io.SectionReader) error {
+	if src == nil {
+		return nil
+	}
+	h := sha256.New()
+	if _, err := io.Copy(h, io.NewSectionReader(sr, 0, sr.Size())); err != nil {
+		return err
+	}
+	src.SHA256 = hex.EncodeToString(h.Sum(nil))
+	src.ContentLength = sr.Size()
+	src.setFilenameDate(src.Filename)
+	return nil
+}
+
+func (src *Source) setFormat(format Format) {
+	if src != nil {
+		src.Format = format
+	}
+}
+
+func (src *Source) setBackend(backend string) {
+	if src != nil {
+		src.Backend = backend
+	}
+}
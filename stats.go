@@ -0,0 +1,58 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import "strings"
+
+// Statistics are the counts describing a list of records, as returned by Stats.
+type Statistics struct {
+	Total int `json:"total"`
+	// PerBank is the number of records by BankCode.
+	PerBank map[string]int `json:"perBank"`
+	// PerCity is the number of records by City, "" for the unknown.
+	PerCity map[string]int `json:"perCity"`
+	// PerCounty is the number of records by County, "" for the unknown.
+	PerCounty map[string]int `json:"perCounty"`
+	// DistinctBICs is the number of the distinct (non-empty) BICs.
+	DistinctBICs int `json:"distinctBICs"`
+	// Missing is the number of records missing the optional field, by its name
+	// (FieldBIC, FieldIrszam, FieldCim).
+	Missing map[string]int `json:"missing"`
+	// NonStdIrszam is the number of records with a non-standard postal code.
+	NonStdIrszam int `json:"nonStdIrszam"`
+}
+
+// Stats returns the statistics of the records.
+func Stats(hs []Hitelezo) Statistics {
+	st := Statistics{
+		Total:     len(hs),
+		PerBank:   make(map[string]int),
+		PerCity:   make(map[string]int),
+		PerCounty: make(map[string]int),
+		Missing:   map[string]int{FieldBIC: 0, FieldIrszam: 0, FieldCim: 0},
+	}
+	bics := make(map[string]struct{})
+	for _, h := range hs {
+		st.PerBank[h.BankCode()]++
+		st.PerCity[h.City()]++
+		st.PerCounty[h.County()]++
+		if bic := strings.ToUpper(strings.TrimSpace(h.BIC)); bic != "" {
+			bics[bic] = struct{}{}
+		} else {
+			st.Missing[FieldBIC]++
+		}
+		if h.Irszam == "" {
+			st.Missing[FieldIrszam]++
+		}
+		if h.Cim == "" {
+			st.Missing[FieldCim]++
+		}
+		if h.NonStdIrszam {
+			st.NonStdIrszam++
+		}
+	}
+	st.DistinctBICs = len(bics)
+	return st
+}
@@ -0,0 +1,31 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import "testing"
+
+func TestStats(t *testing.T) {
+	st := Stats([]Hitelezo{
+		{Bankszerv: "10002003", BIC: "HUSTHUHB", Nev: "Magyar Államkincstár", Irszam: "1139", Cim: "Váci út 71."},
+		{Bankszerv: "11773016", BIC: "OTPVHUHB", Nev: "OTP Bank", Irszam: "9021", Cim: "Győr, Baross Gábor u. 16."},
+		{Bankszerv: "11773023", BIC: "otpvhuhb", Nev: "OTP Bank", Irszam: "2000", Cim: "Szentendre, Dumtsa Jenő u. 1."},
+		{Bankszerv: "11773030", Nev: "OTP Bank", Cim: "Vác"},
+	})
+	if st.Total != 4 || st.DistinctBICs != 2 {
+		t.Errorf("got %+v", st)
+	}
+	if st.PerBank["117"] != 3 || st.PerBank["100"] != 1 {
+		t.Errorf("PerBank: %v", st.PerBank)
+	}
+	if st.PerCity["Budapest"] != 1 || st.PerCity["Vác"] != 1 {
+		t.Errorf("PerCity: %v", st.PerCity)
+	}
+	if st.PerCounty["Pest"] != 1 || st.PerCounty[""] != 1 {
+		t.Errorf("PerCounty: %v", st.PerCounty)
+	}
+	if st.Missing[FieldBIC] != 1 || st.Missing[FieldIrszam] != 1 || st.Missing[FieldCim] != 0 {
+		t.Errorf("Missing: %v", st.Missing)
+	}
+}
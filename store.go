@@ -0,0 +1,169 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Store keeps the snapshots of the list by their effective dates,
+// for the Refresher and for the startup of the services (see LoadStore).
+//
+// FileStore is the built-in implementation, other backends (such as a database or an object store)
+// can be plugged in by implementing it. The implementations must be safe for concurrent use.
+type Store interface {
+	// PutSnapshot stores the records effective from the date, replacing the snapshot of the same date.
+	PutSnapshot(ctx context.Context, effective time.Time, hs []Hitelezo) error
+	// GetLatest returns the snapshot with the latest effective date, and the date.
+	GetLatest(ctx context.Context) ([]Hitelezo, time.Time, error)
+	// GetByDate returns the snapshot effective at the date (the latest not after it), and its effective date.
+	GetByDate(ctx context.Context, date time.Time) ([]Hitelezo, time.Time, error)
+	// ListVersions returns the effective dates of the snapshots, in ascending order.
+	ListVersions(ctx context.Context) ([]time.Time, error)
+}
+
+// FileStore is a Store in a directory, with a file for each effective date
+// in the format of EncodeSnapshot.
+type FileStore struct {
+	dir string
+}
+
+var _ Store = (*FileStore)(nil)
+
+const (
+	storePrefix = "giro-"
+	storeSuffix = ".snap"
+)
+
+// NewFileStore opens (creates) the FileStore in dir.
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, err
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+func (s *FileStore) path(date time.Time) string {
+	return filepath.Join(s.dir, storePrefix+date.Format(time.DateOnly)+storeSuffix)
+}
+
+// PutSnapshot writes the records into the file of the date, atomically.
+func (s *FileStore) PutSnapshot(ctx context.Context, effective time.Time, hs []Hitelezo) (err error) {
+	tmp, err := os.CreateTemp(s.dir, ".giro-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+	if err = EncodeSnapshot(tmp, hs); err != nil {
+		return err
+	}
+	if err = tmp.Sync(); err != nil {
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path(effective))
+}
+
+// GetLatest returns the snapshot of the latest date, or ErrNotFound.
+func (s *FileStore) GetLatest(ctx context.Context) ([]Hitelezo, time.Time, error) {
+	dates, err := s.ListVersions(ctx)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	if len(dates) == 0 {
+		return nil, time.Time{}, fmt.Errorf("%s: no snapshot: %w", s.dir, ErrNotFound)
+	}
+	return s.get(dates[len(dates)-1])
+}
+
+// GetByDate returns the snapshot of the latest date not after date, or ErrNotFound.
+func (s *FileStore) GetByDate(ctx context.Context, date time.Time) ([]Hitelezo, time.Time, error) {
+	dates, err := s.ListVersions(ctx)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	i, found := slices.BinarySearchFunc(dates, date, func(a, b time.Time) int { return a.Compare(b) })
+	if !found {
+		i--
+	}
+	if i < 0 {
+		return nil, time.Time{}, fmt.Errorf("%s: no snapshot at %s: %w", s.dir, date.Format(time.DateOnly), ErrNotFound)
+	}
+	return s.get(dates[i])
+}
+
+func (s *FileStore) get(date time.Time) ([]Hitelezo, time.Time, error) {
+	fh, err := os.Open(s.path(date))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			err = fmt.Errorf("%w: %w", ErrNotFound, err)
+		}
+		return nil, date, err
+	}
+	defer fh.Close()
+	hs, err := DecodeSnapshot(fh)
+	if err != nil {
+		return hs, date, fmt.Errorf("%s: %w", fh.Name(), err)
+	}
+	return hs, date, nil
+}
+
+// ListVersions returns the dates of the snapshot files (in UTC, as the effective dates), in ascending order.
+func (s *FileStore) ListVersions(ctx context.Context) ([]time.Time, error) {
+	des, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+	dates := make([]time.Time, 0, len(des))
+	for _, de := range des {
+		name, ok := strings.CutPrefix(de.Name(), storePrefix)
+		if !ok {
+			continue
+		}
+		if name, ok = strings.CutSuffix(name, storeSuffix); !ok {
+			continue
+		}
+		if t, err := time.ParseInLocation(time.DateOnly, name, time.UTC); err == nil {
+			dates = append(dates, t)
+		}
+	}
+	slices.SortFunc(dates, func(a, b time.Time) int { return a.Compare(b) })
+	return dates, nil
+}
+
+// LoadStore returns a Directory of the latest snapshot of the Store (effective from the returned date),
+// for starting a service without downloading or parsing the list.
+func LoadStore(ctx context.Context, s Store) (*Directory, time.Time, error) {
+	hs, date, err := s.GetLatest(ctx)
+	if err != nil {
+		return nil, date, err
+	}
+	var d Directory
+	d.replaceAt(hs, date)
+	return &d, date, nil
+}
+
+// storeDate returns the effective date of the snapshot to store: the EffectiveDate, or today (in UTC) if unknown.
+func storeDate(src Source, now time.Time) time.Time {
+	if !src.EffectiveDate.IsZero() {
+		return src.EffectiveDate
+	}
+	y, m, d := now.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
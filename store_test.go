@@ -0,0 +1,67 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestFileStore(t *testing.T) {
+	ctx := context.Background()
+	day := func(d int) time.Time { return time.Date(2026, 1, d, 0, 0, 0, 0, time.UTC) }
+	s, err := NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err = s.GetLatest(ctx); !errors.Is(err, ErrNotFound) {
+		t.Errorf("empty: got %+v, wanted ErrNotFound", err)
+	}
+	a := []Hitelezo{{Bankszerv: "11773016", Nev: "OTP Bank", Irszam: "1051", Cim: "Budapest"}}
+	b := []Hitelezo{{Bankszerv: "11773016", Nev: "OTP Bank Nyrt.", Irszam: "1051", Cim: "Budapest"}}
+	for _, put := range []struct {
+		Day int
+		Hs  []Hitelezo
+	}{{15, b}, {1, a}, {15, b}} {
+		if err := s.PutSnapshot(ctx, day(put.Day), put.Hs); err != nil {
+			t.Fatal(err)
+		}
+	}
+	dates, err := s.ListVersions(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dates) != 2 || dates[0] != day(1) || dates[1] != day(15) {
+		t.Errorf("got versions %v", dates)
+	}
+	if hs, date, err := s.GetLatest(ctx); err != nil || !date.Equal(day(15)) || hs[0].Nev != b[0].Nev {
+		t.Errorf("latest: got %+v at %v, %+v", hs, date, err)
+	}
+	if hs, date, err := s.GetByDate(ctx, day(14)); err != nil || !date.Equal(day(1)) || hs[0].Nev != a[0].Nev {
+		t.Errorf("by date: got %+v at %v, %+v", hs, date, err)
+	}
+	if _, _, err = s.GetByDate(ctx, day(1).Add(-time.Hour)); !errors.Is(err, ErrNotFound) {
+		t.Errorf("before: got %+v, wanted ErrNotFound", err)
+	}
+
+	r := Refresher{
+		Directory: NewDirectory(nil),
+		Interval:  time.Hour,
+		Fetch:     func(context.Context, *Source) ([]Hitelezo, error) { return a, nil },
+		Store:     s,
+	}
+	if err = r.TriggerRefresh(ctx); err != nil {
+		t.Fatal(err)
+	}
+	d, date, err := LoadStore(ctx, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := storeDate(Source{}, time.Now()); !date.Equal(want) || d.Len() != 1 {
+		t.Errorf("got %d records at %v, wanted %v", d.Len(), date, want)
+	}
+}
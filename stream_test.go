@@ -0,0 +1,41 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseXLSXFunc(t *testing.T) {
+	hs := append([]Hitelezo{{Bankszerv: "99900005", Nev: "", Irszam: "1054", Cim: "Budapest"}}, leadingZeros...)
+	var buf bytes.Buffer
+	if err := WriteXLSX(&buf, hs); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	var got []Hitelezo
+	if err := ParseXLSXFunc(ctx, bytes.NewReader(buf.Bytes()), func(h Hitelezo) error {
+		got = append(got, h)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, leadingZeros) { // the nameless record is filtered out
+		t.Errorf("got\n%+v\nwanted\n%+v", got, leadingZeros)
+	}
+
+	errStop := errors.New("stop")
+	var n int
+	if err := ParseXLSXFunc(ctx, bytes.NewReader(buf.Bytes()), func(Hitelezo) error {
+		n++
+		return errStop
+	}); !errors.Is(err, errStop) || n != 1 {
+		t.Errorf("got %d calls, error %+v, wanted 1 and errStop", n, err)
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package table
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/UNO-SOFT/giro"
+)
+
+// RowMapper maps the rows of a table to the records of type T.
+type RowMapper[T any] interface {
+	// Header is called with each row until it returns true,
+	// to find the header row and keep the positions of the columns.
+	Header(row Row) bool
+	// MapRow returns the record of a row after the header,
+	// and false if the row is not a record (such as a footer).
+	MapRow(row Row) (T, bool, error)
+}
+
+// Map returns the records of the table of r, mapped by m (see Extract).
+//
+// giro.ErrNotFound is returned if m finds no header row.
+func Map[T any](ctx context.Context, r io.Reader, m RowMapper[T], opts ...giro.Option) ([]T, error) {
+	var records []T
+	err := MapFunc(ctx, r, m, func(rec T) error {
+		records = append(records, rec)
+		return nil
+	}, opts...)
+	return records, err
+}
+
+// MapFunc calls sink with each record of the table of r, mapped by m.
+//
+// An error of m or sink aborts the mapping, with the location of the row.
+func MapFunc[T any](ctx context.Context, r io.Reader, m RowMapper[T], sink func(T) error, opts ...giro.Option) error {
+	var headerSeen bool
+	if err := ExtractFunc(ctx, r, func(row Row) error {
+		if !headerSeen {
+			headerSeen = m.Header(row)
+			return nil
+		}
+		rec, ok, err := m.MapRow(row)
+		if err == nil && ok {
+			err = sink(rec)
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", row.Origin, err)
+		}
+		return nil
+	}, opts...); err != nil {
+		return err
+	}
+	if !headerSeen {
+		return fmt.Errorf("header: %w", giro.ErrNotFound)
+	}
+	return nil
+}
+
+// Fetch finds the latest publication linked from searchURL with its name matching pattern
+// (see giro.SearchXLSURL), downloads it, and returns its records mapped by m.
+func Fetch[T any](ctx context.Context, searchURL, pattern string, m RowMapper[T], opts ...giro.Option) ([]T, error) {
+	u, err := giro.SearchXLSURL(ctx, searchURL, pattern, opts...)
+	if err != nil {
+		return nil, err
+	}
+	_, rc, err := giro.DownloadFile(ctx, u, opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return Map(ctx, rc, m, opts...)
+}
+
+// ColumnMapper is a RowMapper setting the fields of T by the names of the header cells.
+//
+// The cells are cleaned (see giro.CleanCell), the empty ones are skipped,
+// and the rows without any mapped cell are not records.
+type ColumnMapper[T any] struct {
+	// Columns maps the header names to the setters of the fields.
+	//
+	// The names are compared by giro.Fold; a header cell containing more of them
+	// is mapped by the longest one.
+	Columns map[string]func(rec *T, value string) error
+	// Required is the number of Columns needed in the header row, all of them if zero.
+	Required int
+
+	setters []func(*T, string) error
+}
+
+// Header implements RowMapper.
+func (m *ColumnMapper[T]) Header(row Row) bool {
+	setters := make([]func(*T, string) error, len(row.Cells))
+	var found int
+	for i, cell := range row.Cells {
+		h := giro.Fold(giro.CleanCell(cell))
+		if h == "" {
+			continue
+		}
+		var best string
+		for name, set := range m.Columns {
+			if f := giro.Fold(name); strings.Contains(h, f) && (len(f) > len(best) || len(f) == len(best) && f < best) {
+				best, setters[i] = f, set
+			}
+		}
+		if setters[i] != nil {
+			found++
+		}
+	}
+	required := m.Required
+	if required <= 0 {
+		required = len(m.Columns)
+	}
+	if found < required {
+		return false
+	}
+	m.setters = setters
+	return true
+}
+
+// MapRow implements RowMapper.
+func (m *ColumnMapper[T]) MapRow(row Row) (T, bool, error) {
+	var rec T
+	var mapped bool
+	for i, cell := range row.Cells {
+		if i >= len(m.setters) || m.setters[i] == nil {
+			continue
+		}
+		if cell = giro.CleanCell(cell); cell == "" {
+			continue
+		}
+		if err := m.setters[i](&rec, cell); err != nil {
+			return rec, false, err
+		}
+		mapped = true
+	}
+	return rec, mapped, nil
+}
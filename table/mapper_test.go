@@ -0,0 +1,61 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package table_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/UNO-SOFT/giro"
+	"github.com/UNO-SOFT/giro/table"
+)
+
+type participant struct {
+	Code  string
+	Name  string
+	VIBER bool
+	ID    int
+}
+
+func TestMap(t *testing.T) {
+	ctx := context.Background()
+	m := table.ColumnMapper[participant]{
+		Columns: map[string]func(*participant, string) error{
+			"Kód":   func(p *participant, s string) error { p.Code = giro.CellToCode(s, 3); return nil },
+			"Név":   func(p *participant, s string) error { p.Name = s; return nil },
+			"VIBER": func(p *participant, s string) error { p.VIBER = s == "X"; return nil },
+			"Belső azonosító": func(p *participant, s string) error {
+				var err error
+				p.ID, err = strconv.Atoi(s)
+				return err
+			},
+		},
+		Required: 2,
+	}
+	const data = "Résztvevők\n" +
+		"Bank kód,Bank neve,VIBER tag\n" +
+		"100,Magyar Államkincstár,X\n" +
+		"117, OTP Bank Nyrt. ,\n" +
+		",,\n"
+	got, err := table.Map(ctx, strings.NewReader(data), &m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []participant{{Code: "100", Name: "Magyar Államkincstár", VIBER: true}, {Code: "117", Name: "OTP Bank Nyrt."}}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("got %+v, wanted %+v", got, want)
+	}
+
+	if _, err := table.Map(ctx, strings.NewReader("Kód,Belső azonosító\n100,x\n"), &m); err == nil ||
+		!strings.Contains(err.Error(), "row 2") {
+		t.Errorf("got %v, wanted the error of row 2", err)
+	}
+	if _, err := table.Map(ctx, strings.NewReader("a,b\n1,2\n"), &m); !errors.Is(err, giro.ErrNotFound) {
+		t.Errorf("got %v, wanted ErrNotFound", err)
+	}
+}
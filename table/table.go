@@ -0,0 +1,41 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package table extracts the rows of the tabular publications (of GIRO, MNB)
+// with the machinery of giro.Parse: the format is sniffed from the contents
+// (XLSX, XLS, PDF, HTML, CSV, or any of them in a ZIP), the sheet is selected
+// by giro.WithSheet and the like, and the PDF strategies fall back to the next one.
+//
+// Only the mapping of the rows to records is left to the caller.
+package table
+
+import (
+	"context"
+	"io"
+
+	"github.com/UNO-SOFT/giro"
+)
+
+// Row is a row of the table, with its location in the file.
+type Row struct {
+	Cells  []string
+	Origin giro.RowOrigin
+}
+
+// Extract returns the rows of the table of r, in the format detected from its contents.
+func Extract(ctx context.Context, r io.Reader, opts ...giro.Option) ([][]string, error) {
+	var rows [][]string
+	err := ExtractFunc(ctx, r, func(row Row) error {
+		rows = append(rows, row.Cells)
+		return nil
+	}, opts...)
+	return rows, err
+}
+
+// ExtractFunc calls fn with each row of the table of r, stopping at the first error of fn.
+func ExtractFunc(ctx context.Context, r io.Reader, fn func(Row) error, opts ...giro.Option) error {
+	return giro.ExtractRows(ctx, r, func(cells []string, origin giro.RowOrigin) error {
+		return fn(Row{Cells: cells, Origin: origin})
+	}, opts...)
+}
@@ -0,0 +1,77 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package table_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/UNO-SOFT/giro"
+	"github.com/UNO-SOFT/giro/table"
+	"github.com/xuri/excelize/v2"
+)
+
+func TestExtract(t *testing.T) {
+	ctx := context.Background()
+	want := [][]string{{"Kód", "Név"}, {"100", "Magyar Államkincstár"}, {"117", "OTP Bank Nyrt."}}
+
+	wb := excelize.NewFile()
+	if _, err := wb.NewSheet("Adatok"); err != nil {
+		t.Fatal(err)
+	}
+	for i, row := range want {
+		cell, _ := excelize.CoordinatesToCellName(1, i+1)
+		if err := wb.SetSheetRow("Adatok", cell, &row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var xlsx bytes.Buffer
+	if _, err := wb.WriteTo(&xlsx); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range map[string]struct {
+		data string
+		opts []giro.Option
+	}{
+		"csv":  {data: "Kód,Név\n100,Magyar Államkincstár\n117,OTP Bank Nyrt.\n"},
+		"html": {data: "<html><body><table><tr><th>Kód<th>Név<tr><td>100<td>Magyar Államkincstár<tr><td>117<td>OTP Bank Nyrt.</table></body></html>"},
+		"xlsx": {data: xlsx.String(), opts: []giro.Option{giro.WithSheet("Adatok")}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, err := table.Extract(ctx, strings.NewReader(tc.data), tc.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("got %q, wanted %q", got, want)
+			}
+		})
+	}
+
+	t.Run("origin", func(t *testing.T) {
+		var last table.Row
+		if err := table.ExtractFunc(ctx, bytes.NewReader(xlsx.Bytes()), func(row table.Row) error {
+			last = row
+			return nil
+		}, giro.WithSheet("Adatok")); err != nil {
+			t.Fatal(err)
+		}
+		if want := (giro.RowOrigin{Sheet: "Adatok", Row: 3}); last.Origin != want {
+			t.Errorf("got %+v, wanted %+v", last.Origin, want)
+		}
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		if _, err := table.Extract(ctx, strings.NewReader("\x00\x01\x02")); !errors.Is(err, giro.ErrFormatUnknown) {
+			t.Errorf("got %v, wanted ErrFormatUnknown", err)
+		}
+	})
+
+}
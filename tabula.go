@@ -0,0 +1,148 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// tabulaVersion is the version of DefaultTabulaJarURL.
+const tabulaVersion = "1.0.5"
+
+const (
+	// DefaultTabulaJarURL is the tabula release downloaded by the tabula strategy of ParsePDF on its first use.
+	DefaultTabulaJarURL = "https://github.com/tabulapdf/tabula-java/releases/download/v" + tabulaVersion +
+		"/tabula-" + tabulaVersion + "-jar-with-dependencies.jar"
+	// DefaultTabulaJarSHA256 is the hex-encoded SHA-256 hash of DefaultTabulaJarURL.
+	DefaultTabulaJarSHA256 = "2161e3fff65939d7ce0812479cf9ca36860db5697f7fc1fa01161ed4092a0745"
+)
+
+// WithTabulaJar sets the URL of the tabula jar (default is DefaultTabulaJarURL), and its hex-encoded SHA-256 hash.
+//
+// The jar is downloaded on the first use into the cache directory (see WithCacheDir),
+// verified against the hash (ErrHashMismatch), and reused thereafter.
+// The URL may be a local path (or a file:// URL) of a jar, too, whose hash is optional.
+func WithTabulaJar(jarURL, sha256 string) Option {
+	return func(o *options) { o.tabulaJarURL, o.tabulaJarSHA256 = jarURL, strings.ToLower(sha256) }
+}
+
+// tabulaJar returns the URL and the SHA-256 hash of the tabula jar.
+func (o *options) tabulaJar() (string, string) {
+	if o.tabulaJarURL == "" {
+		return DefaultTabulaJarURL, DefaultTabulaJarSHA256
+	}
+	return o.tabulaJarURL, o.tabulaJarSHA256
+}
+
+// localJar returns the path of the jar and true if jarURL is a local path or a file:// URL.
+func localJar(jarURL string) (string, bool) {
+	if fn, ok := strings.CutPrefix(jarURL, "file://"); ok {
+		return fn, true
+	}
+	u, err := url.Parse(jarURL)
+	// a Windows drive letter is parsed as a scheme
+	return jarURL, err != nil || u.Scheme == "" || len(u.Scheme) == 1
+}
+
+// checkSHA256 returns ErrHashMismatch if the SHA-256 hash of b is not hash.
+func checkSHA256(name string, b []byte, hash string) error {
+	sum := sha256.Sum256(b)
+	if got := hex.EncodeToString(sum[:]); got != hash {
+		return fmt.Errorf("%s: got %s, wanted %s: %w", name, got, hash, ErrHashMismatch)
+	}
+	return nil
+}
+
+// TabulaHints are the extraction hints of the tabula strategy of ParsePDF,
+// to follow the changes of the layout of the PDF (such as its margins, or a cover page) by configuration.
+//
+// The coordinates are in points from the top left corner of the page,
+// or in the percents of the page size with Percent.
+type TabulaHints struct {
+	// Area is the portion of the page to analyze: top, left, bottom and right.
+	Area []float64 `json:"area,omitempty"`
+	// Columns are the X coordinates of the column boundaries.
+	// The columns are guessed from the ruling lines if not set.
+	Columns []float64 `json:"columns,omitempty"`
+	// Percent makes Area and Columns percents of the page size.
+	Percent bool `json:"percent,omitempty"`
+	// Pages are the pages to extract, such as "2-5,7" (default is "all").
+	// WithParallelPages overrides it.
+	Pages string `json:"pages,omitempty"`
+}
+
+// WithTabulaHints sets the extraction hints of the tabula strategy of ParsePDF.
+func WithTabulaHints(hints TabulaHints) Option {
+	return func(o *options) { o.tabulaHints = hints }
+}
+
+var rTabulaPages = regexp.MustCompile(`^(all|[1-9][0-9]*(-[1-9][0-9]*)?(,[1-9][0-9]*(-[1-9][0-9]*)?)*)$`)
+
+// Validate returns an error if the hints are not valid.
+func (h TabulaHints) Validate() error {
+	if len(h.Area) != 0 && len(h.Area) != 4 {
+		return fmt.Errorf("tabula area: got %d coordinates, wanted 4 (top, left, bottom, right)", len(h.Area))
+	}
+	if len(h.Area) == 4 && (h.Area[0] >= h.Area[2] || h.Area[1] >= h.Area[3]) {
+		return fmt.Errorf("tabula area %v: top and left must be less than bottom and right", h.Area)
+	}
+	for i, x := range h.Columns {
+		if x < 0 || (i != 0 && x <= h.Columns[i-1]) {
+			return fmt.Errorf("tabula columns %v: must be increasing", h.Columns)
+		}
+	}
+	if h.Percent {
+		for _, x := range append(h.Area[:len(h.Area):len(h.Area)], h.Columns...) {
+			if x < 0 || x > 100 {
+				return fmt.Errorf("tabula hints: %v is not a percent", x)
+			}
+		}
+	}
+	if h.Pages != "" && !rTabulaPages.MatchString(strings.ReplaceAll(h.Pages, " ", "")) {
+		return fmt.Errorf("tabula pages %q: wanted such as \"2-5,7\"", h.Pages)
+	}
+	return nil
+}
+
+// args returns the tabula arguments of the hints, with the page range of the chunk (if not zero).
+//
+// With Columns, the stream mode is used instead of the lattice mode, as tabula ignores them otherwise.
+func (h TabulaHints) args(chunk pdfPages) []string {
+	pages := chunk.tabula()
+	if chunk.First == 0 && h.Pages != "" {
+		pages = strings.ReplaceAll(h.Pages, " ", "")
+	}
+	mode := "-l"
+	if len(h.Columns) != 0 {
+		mode = "-t"
+	}
+	args := []string{mode, "-p", pages}
+	if len(h.Area) != 0 {
+		args = append(args, "-a", h.coordinates(h.Area))
+	}
+	if len(h.Columns) != 0 {
+		args = append(args, "-c", h.coordinates(h.Columns))
+	}
+	return args
+}
+
+// coordinates returns the coordinates comma-separated, with a % prefix if Percent.
+func (h TabulaHints) coordinates(xs []float64) string {
+	ss := make([]string, len(xs))
+	for i, x := range xs {
+		ss[i] = strconv.FormatFloat(x, 'f', -1, 64)
+	}
+	s := strings.Join(ss, ",")
+	if h.Percent {
+		s = "%" + s
+	}
+	return s
+}
@@ -0,0 +1,54 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestTabulaHints(t *testing.T) {
+	for i, tc := range []struct {
+		Hints TabulaHints
+		Chunk pdfPages
+		Want  []string
+	}{
+		{Want: []string{"-l", "-p", "all"}},
+		{Hints: TabulaHints{Pages: "2-5, 7"}, Want: []string{"-l", "-p", "2-5,7"}},
+		{Hints: TabulaHints{Pages: "2-9"}, Chunk: pdfPages{First: 3, Last: 4}, Want: []string{"-l", "-p", "3-4"}},
+		{Hints: TabulaHints{Area: []float64{72, 12.5, 790, 561}}, Want: []string{"-l", "-p", "all", "-a", "72,12.5,790,561"}},
+		{Hints: TabulaHints{Columns: []float64{10, 50.5, 80}, Percent: true},
+			Want: []string{"-t", "-p", "all", "-c", "%10,50.5,80"}},
+	} {
+		if err := tc.Hints.Validate(); err != nil {
+			t.Errorf("%d. %+v", i, err)
+		}
+		if got := tc.Hints.args(tc.Chunk); !slices.Equal(got, tc.Want) {
+			t.Errorf("%d. got %q, wanted %q", i, got, tc.Want)
+		}
+	}
+
+	for i, h := range []TabulaHints{
+		{Area: []float64{1, 2, 3}},
+		{Area: []float64{100, 0, 50, 100}},
+		{Columns: []float64{10, 5}},
+		{Columns: []float64{10, 150}, Percent: true},
+		{Pages: "2-"},
+		{Pages: "0"},
+	} {
+		if err := h.Validate(); err == nil {
+			t.Errorf("%d. %+v: wanted error", i, h)
+		}
+	}
+
+	var h TabulaHints
+	if err := json.Unmarshal([]byte(`{"area":[10,0,800,600],"pages":"2-9"}`), &h); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.Validate(); err != nil || h.Pages != "2-9" || len(h.Area) != 4 {
+		t.Errorf("got %+v: %+v", h, err)
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"strings"
+	"unicode"
+)
+
+// IsTechnical reports whether h is a technical or test participant, not a real branch,
+// by a word of its name ("teszt…", "technikai…", "test", "technical").
+func IsTechnical(h Hitelezo) bool {
+	for _, w := range strings.FieldsFunc(strings.ToLower(h.Nev), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	}) {
+		if w == "test" || w == "technical" ||
+			strings.HasPrefix(w, "teszt") || strings.HasPrefix(w, "technikai") {
+			return true
+		}
+	}
+	return false
+}
+
+// WithTechnical sets whether the technical and test participants (see IsTechnical)
+// are kept by Parse (default), thus are in the Directory refreshed with these options.
+//
+// Keep them in UAT environments, for the test transfers to validate,
+// and drop them in production.
+func WithTechnical(include bool) Option {
+	return func(o *options) { o.excludeTechnical = !include }
+}
@@ -0,0 +1,43 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestTechnical(t *testing.T) {
+	for nev, want := range map[string]bool{
+		"OTP Bank Nyrt.":                   false,
+		"Önkormányzati testület számlája":  false,
+		"Próba Bank":                       false,
+		"GIRO Zrt. tesztfiók":              true,
+		"Technikai résztvevő":              true,
+		"MNB (TEST)":                       true,
+		"Clearing technical participant 1": true,
+	} {
+		if got := IsTechnical(Hitelezo{Nev: nev}); got != want {
+			t.Errorf("%q: got %t, wanted %t", nev, got, want)
+		}
+	}
+
+	hs := append([]Hitelezo{{Bankszerv: "99900005", Nev: "GIRO tesztfiók", Irszam: "1054", Cim: "Budapest"}}, leadingZeros...)
+	var buf bytes.Buffer
+	if err := WriteXLSX(&buf, hs); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	for include, want := range map[bool]int{true: 3, false: 2} {
+		got, err := Parse(ctx, bytes.NewReader(buf.Bytes()), WithTechnical(include))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d := NewDirectory(got); d.Len() != want {
+			t.Errorf("include=%t: got %d records, wanted %d", include, d.Len(), want)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+)
+
+// ErrPinMismatch is returned when no certificate of a pinned host matches its pins.
+var ErrPinMismatch = errors.New("certificate pin mismatch")
+
+// WithTLSConfig sets the TLS configuration (CA bundle, minimum version, pinning - see PinCertificates)
+// of all the outbound requests, instead of the one of http.DefaultTransport.
+func WithTLSConfig(cfg *tls.Config) Option {
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	tr.TLSClientConfig = cfg
+	client := &http.Client{Transport: tr}
+	return func(o *options) { o.httpClient = client }
+}
+
+// WithHTTPClient sets the HTTP client of all the outbound requests (default is http.DefaultClient).
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *options) { o.httpClient = client }
+}
+
+// client returns the HTTP client of the outbound requests.
+func (o *options) client() *http.Client {
+	if o.httpClient != nil {
+		return o.httpClient
+	}
+	return http.DefaultClient
+}
+
+// Pins are the base64-encoded SHA-256 hashes of the SubjectPublicKeyInfo
+// of the accepted certificates (as in HPKP), by host name.
+type Pins map[string][]string
+
+// PinCertificates returns a copy of cfg (a new config if nil) which, after the normal verification,
+// requires a certificate of the chain of each host in pins to match one of its pins.
+//
+// The hosts are matched by the TLS server name (SNI), thus the hosts not in pins,
+// and the ones addressed by IP are not pinned.
+func PinCertificates(cfg *tls.Config, pins Pins) *tls.Config {
+	if cfg == nil {
+		cfg = &tls.Config{}
+	} else {
+		cfg = cfg.Clone()
+	}
+	verify := cfg.VerifyConnection
+	cfg.VerifyConnection = func(cs tls.ConnectionState) error {
+		if verify != nil {
+			if err := verify(cs); err != nil {
+				return err
+			}
+		}
+		hostPins, ok := pins[cs.ServerName]
+		if !ok {
+			return nil
+		}
+		for _, cert := range cs.PeerCertificates {
+			if slices.Contains(hostPins, SPKIHash(cert.RawSubjectPublicKeyInfo)) {
+				return nil
+			}
+		}
+		return fmt.Errorf("%s: %w", cs.ServerName, ErrPinMismatch)
+	}
+	return cfg
+}
+
+// SPKIHash returns the pin of the DER-encoded SubjectPublicKeyInfo (x509.Certificate.RawSubjectPublicKeyInfo).
+func SPKIHash(spki []byte) string {
+	h := sha256.Sum256(spki)
+	return base64.StdEncoding.EncodeToString(h[:])
+}
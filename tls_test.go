@@ -0,0 +1,66 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/rogpeppe/retry"
+)
+
+func TestTLSConfig(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "data")
+	}))
+	defer srv.Close()
+	// the certificate of httptest is valid for example.com, and the server name is needed for pinning
+	const host = "example.com"
+	pool := x509.NewCertPool()
+	pool.AddCert(srv.Certificate())
+	cfg := &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12, ServerName: host}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	noRetry := WithRetry(retry.Strategy{MaxCount: 1})
+	for _, tc := range []struct {
+		Name    string
+		Opts    []Option
+		WantErr error
+	}{
+		{Name: "default"},
+		{Name: "CA", Opts: []Option{WithTLSConfig(cfg)}},
+		{Name: "pinned", Opts: []Option{WithTLSConfig(PinCertificates(cfg, Pins{
+			host: {SPKIHash(srv.Certificate().RawSubjectPublicKeyInfo)},
+		}))}},
+		{Name: "other host pinned", Opts: []Option{WithTLSConfig(PinCertificates(cfg, Pins{
+			"www.giro.hu": {"AAAA"},
+		}))}},
+		{Name: "mismatch", WantErr: ErrPinMismatch, Opts: []Option{WithTLSConfig(PinCertificates(cfg, Pins{
+			host: {"AAAA"},
+		}))}},
+	} {
+		_, rc, err := DownloadFile(ctx, srv.URL, append(tc.Opts, noRetry)...)
+		if rc != nil {
+			rc.Close()
+		}
+		if tc.Name == "default" {
+			if err == nil {
+				t.Errorf("%s: wanted unknown authority error", tc.Name)
+			}
+			continue
+		}
+		if (err != nil) != (tc.WantErr != nil) || (tc.WantErr != nil && !errors.Is(err, tc.WantErr)) {
+			t.Errorf("%s: got %+v, wanted %v", tc.Name, err, tc.WantErr)
+		}
+	}
+}
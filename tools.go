@@ -0,0 +1,96 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+// Tool is an external command used by the PDFStrategies.
+type Tool struct {
+	Name string
+	// Path is the path of the executable (of the cached jar for tabula, empty until its first download).
+	Path string
+	// Version is the version reported by the tool.
+	Version string
+	// Err is the reason of the tool not being available, nil if it is.
+	Err error
+}
+
+// Available reports whether the tool can be run.
+func (t Tool) Available() bool { return t.Err == nil }
+
+// MarshalJSON returns t as an object with "available" and "error" instead of Err.
+func (t Tool) MarshalJSON() ([]byte, error) {
+	v := struct {
+		Name      string `json:"name"`
+		Available bool   `json:"available"`
+		Path      string `json:"path,omitempty"`
+		Version   string `json:"version,omitempty"`
+		Error     string `json:"error,omitempty"`
+	}{Name: t.Name, Available: t.Available(), Path: t.Path, Version: t.Version}
+	if t.Err != nil {
+		v.Error = t.Err.Error()
+	}
+	return json.Marshal(v)
+}
+
+// Tools are the external commands of the PDFStrategies, as found by DetectTools.
+type Tools struct {
+	// Java runs tabula, unless it runs in a container (see WithTabulaContainer).
+	Java Tool
+	// Tabula is the tabula jar of PDFTabula, with Java or the container runtime.
+	Tabula Tool
+	// PdfToText is pdftotext of PDFText and PDFLayout.
+	PdfToText Tool
+	// PdfToPPM and Tesseract are the commands of PDFOCR.
+	PdfToPPM, Tesseract Tool
+}
+
+// List returns the tools.
+func (ts Tools) List() []Tool {
+	return []Tool{ts.Java, ts.Tabula, ts.PdfToText, ts.PdfToPPM, ts.Tesseract}
+}
+
+// Strategies returns the PDFStrategies whose tools are available.
+func (ts Tools) Strategies() []PDFStrategy {
+	var ss []PDFStrategy
+	if ts.Tabula.Available() {
+		ss = append(ss, PDFTabula)
+	}
+	if ts.PdfToText.Available() {
+		ss = append(ss, PDFText, PDFLayout)
+	}
+	if ts.PdfToPPM.Available() && ts.Tesseract.Available() {
+		ss = append(ss, PDFOCR)
+	}
+	return ss
+}
+
+// DetectTools looks up the external commands of the PDFStrategies (with the options WithJava and
+// WithTabulaContainer), and asks their versions, so the deployments can fail fast,
+// or log which strategies will work.
+//
+// All the tools are unavailable with ErrUnsupportedInBuild when the package is built without them
+// (see the giro_noexec build tag).
+func DetectTools(ctx context.Context, opts ...Option) Tools {
+	return newOptions(opts).detectTools(ctx)
+}
+
+var rVersion = regexp.MustCompile(`[0-9]+(\.[0-9]+)+`)
+
+// toolVersion returns the version number from the output of the version flag of a tool,
+// or its first line if there is no version number in it.
+func toolVersion(out string) string {
+	out = strings.TrimSpace(out)
+	line, _, _ := strings.Cut(out, "\n")
+	if v := rVersion.FindString(line); v != "" {
+		return v
+	}
+	return strings.TrimSpace(line)
+}
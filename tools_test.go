@@ -0,0 +1,75 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !giro_noexec && !js && !wasip1
+
+package giro
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestDetectTools(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip(err)
+	}
+	dir := t.TempDir()
+	cache, err := newOptions([]Option{WithCacheDir(filepath.Join(dir, "cache"))}).openCache()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err = cache.Put(tabulaJarKey(DefaultTabulaJarURL, DefaultTabulaJarSHA256), bytes.NewReader([]byte("jar"))); err != nil {
+		t.Fatal(err)
+	}
+	for name, script := range map[string]string{
+		"java":      `echo 'openjdk version "21.0.2" 2024-01-16' >&2`,
+		"pdftotext": "echo 'pdftotext version 22.02.0' >&2\nexit 99",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"+script+"\n"), 0700); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("PATH", dir)
+
+	ts := DetectTools(context.Background(), WithCacheDir(filepath.Join(dir, "cache")))
+	if !ts.Java.Available() || ts.Java.Version != "21.0.2" {
+		t.Errorf("java: got %+v", ts.Java)
+	}
+	if !ts.Tabula.Available() || ts.Tabula.Path == "" || ts.Tabula.Version != tabulaVersion {
+		t.Errorf("tabula: got %+v", ts.Tabula)
+	}
+	if !ts.PdfToText.Available() || ts.PdfToText.Version != "22.02.0" {
+		t.Errorf("pdftotext: got %+v", ts.PdfToText)
+	}
+	if ts.Tesseract.Available() || !errors.Is(ts.Tesseract.Err, exec.ErrNotFound) {
+		t.Errorf("tesseract: got %+v", ts.Tesseract)
+	}
+	if got, want := ts.Strategies(), []PDFStrategy{PDFTabula, PDFText, PDFLayout}; !slices.Equal(got, want) {
+		t.Errorf("got strategies %q, wanted %q", got, want)
+	}
+
+	ts = DetectTools(context.Background(), WithJava(filepath.Join(dir, "nojava")))
+	if ts.Java.Available() || ts.Tabula.Available() || !errors.Is(ts.Tabula.Err, ErrJavaNotFound) {
+		t.Errorf("without java: got %+v", ts)
+	}
+}
+
+func TestToolVersion(t *testing.T) {
+	for out, want := range map[string]string{
+		"tesseract 5.3.0\n leptonica-1.82.0\n": "5.3.0",
+		"\nunknown\n":                          "unknown",
+		"":                                     "",
+	} {
+		if got := toolVersion(out); got != want {
+			t.Errorf("%q: got %q, wanted %q", out, got, want)
+		}
+	}
+}
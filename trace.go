@@ -0,0 +1,49 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Tracer starts a span named name, returning the context of the span,
+// and the function ending it with its error and result attributes.
+//
+// It is the bridge to the tracing system of the application (for OpenTelemetry, see package otelgiro),
+// without making giro depend on it.
+type Tracer func(ctx context.Context, name string, attrs ...slog.Attr) (context.Context, func(err error, attrs ...slog.Attr))
+
+// WithTracer sets the Tracer to wrap the fetches, the parsers and the external commands in spans.
+//
+// The spans are named "giro." plus the function name (giro.ParseXLSX) or the command (giro.exec.java).
+func WithTracer(t Tracer) Option {
+	return func(o *options) { o.tracer = t }
+}
+
+type tracerKey struct{}
+
+// ContextWithTracer returns a context carrying t, for the functions without options (ParseAVT, ParseAFR).
+func ContextWithTracer(ctx context.Context, t Tracer) context.Context {
+	return context.WithValue(ctx, tracerKey{}, t)
+}
+
+// startSpan starts a span with the Tracer of ctx, if any.
+func startSpan(ctx context.Context, name string, attrs ...slog.Attr) (context.Context, func(err error, attrs ...slog.Attr)) {
+	if t, ok := ctx.Value(tracerKey{}).(Tracer); ok && t != nil {
+		return t(ctx, "giro."+name, attrs...)
+	}
+	return ctx, func(error, ...slog.Attr) {}
+}
+
+// span starts a span with the Tracer of o (or of ctx), passing it in the returned context,
+// with the logger of o.
+func (o *options) span(ctx context.Context, name string, attrs ...slog.Attr) (context.Context, func(err error, attrs ...slog.Attr)) {
+	ctx = o.context(ctx)
+	if o.tracer != nil {
+		ctx = ContextWithTracer(ctx, o.tracer)
+	}
+	return startSpan(ctx, name, attrs...)
+}
@@ -0,0 +1,41 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestTracer(t *testing.T) {
+	var mu sync.Mutex
+	var spans []string
+	tracer := func(ctx context.Context, name string, attrs ...slog.Attr) (context.Context, func(error, ...slog.Attr)) {
+		return ctx, func(err error, attrs ...slog.Attr) {
+			var buf strings.Builder
+			buf.WriteString(name)
+			for _, a := range attrs {
+				buf.WriteString(" " + a.String())
+			}
+			mu.Lock()
+			spans = append(spans, buf.String())
+			mu.Unlock()
+		}
+	}
+	var buf bytes.Buffer
+	if err := WriteXLSX(&buf, leadingZeros); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Parse(context.Background(), &buf, WithTracer(tracer)); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"giro.ParseXLSX records=2", "giro.Parse records=2"}; len(spans) != 2 || spans[0] != want[0] || spans[1] != want[1] {
+		t.Errorf("got %q, wanted %q", spans, want)
+	}
+}
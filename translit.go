@@ -0,0 +1,47 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import "strings"
+
+// HungarianToASCII maps the Hungarian accented letters (and the typographic quotes and dashes)
+// to 7-bit ASCII.
+var HungarianToASCII = map[rune]string{
+	'á': "a", 'é': "e", 'í': "i", 'ó': "o", 'ö': "o", 'ő': "o", 'ú': "u", 'ü': "u", 'ű': "u",
+	'Á': "A", 'É': "E", 'Í': "I", 'Ó': "O", 'Ö': "O", 'Ő': "O", 'Ú': "U", 'Ü': "U", 'Ű': "U",
+	'ô': "o", 'õ': "o", 'û': "u", 'Ô': "O", 'Õ': "O", 'Û': "U", // Latin-1 lookalikes of ő, ű
+	'„': `"`, '”': `"`, '“': `"`, '’': "'", '–': "-", '—': "-", ' ': " ",
+}
+
+// WithTransliteration makes the exporters (WriteCSV, WriteXLSX, WriteSQL)
+// replace the runes of the text fields by the mapping (e.g. HungarianToASCII),
+// for legacy systems handling only 7-bit data.
+//
+// The records themselves are not changed.
+func WithTransliteration(mapping map[rune]string) Option {
+	return func(o *options) { o.translit = mapping }
+}
+
+// transliterate replaces the runes of s found in mapping.
+func transliterate(s string, mapping map[rune]string) string {
+	if len(mapping) == 0 {
+		return s
+	}
+	i := strings.IndexFunc(s, func(r rune) bool { _, ok := mapping[r]; return ok })
+	if i < 0 {
+		return s
+	}
+	var buf strings.Builder
+	buf.Grow(len(s))
+	buf.WriteString(s[:i])
+	for _, r := range s[i:] {
+		if t, ok := mapping[r]; ok {
+			buf.WriteString(t)
+		} else {
+			buf.WriteRune(r)
+		}
+	}
+	return buf.String()
+}
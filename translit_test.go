@@ -0,0 +1,39 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestTransliterate(t *testing.T) {
+	for s, want := range map[string]string{
+		"":                       "",
+		"Budapest":               "Budapest",
+		"Árvíztűrő tükörfúrógép": "Arvizturo tukorfurogep",
+		"ÁRVÍZTŰRŐ TÜKÖRFÚRÓGÉP": "ARVIZTURO TUKORFUROGEP",
+		"„Zéró” Bank – Győr":     `"Zero" Bank - Gyor`,
+		"Kõbányai Takarékszövetkezet, ûrhajó": "Kobanyai Takarekszovetkezet, urhajo",
+	} {
+		if got := transliterate(s, HungarianToASCII); got != want {
+			t.Errorf("%q: got %q, wanted %q", s, got, want)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, leadingZeros, WithTransliteration(HungarianToASCII)); err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range buf.String() {
+		if r > 127 {
+			t.Errorf("non-ASCII %q in %q", r, buf.String())
+		}
+	}
+	if !strings.Contains(buf.String(), "Proba") || leadingZeros[0].Nev != `Próba "Zéró" Bank` {
+		t.Errorf("got %q, records changed to %+v", buf.String(), leadingZeros[0])
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrTruncated is the error reported for a field shortened by the exporters.
+var ErrTruncated = errors.New("field truncated")
+
+// TruncatedError is reported (see WithReport) for each field
+// shortened to the limit set by WithFieldLimits.
+type TruncatedError struct {
+	Bankszerv, Field string
+	Value, Result    string
+}
+
+func (e *TruncatedError) Error() string {
+	return fmt.Sprintf("%s: %s %q truncated to %q", e.Bankszerv, e.Field, e.Value, e.Result)
+}
+func (e *TruncatedError) Unwrap() error { return ErrTruncated }
+
+// Abbreviation is a long form and its abbreviation.
+type Abbreviation struct {
+	Long, Short string
+}
+
+// DefaultAbbreviations are the usual abbreviations of the bank names and addresses.
+var DefaultAbbreviations = []Abbreviation{
+	{"Zártkörűen Működő Részvénytársaság", "Zrt."},
+	{"Nyilvánosan Működő Részvénytársaság", "Nyrt."},
+	{"Takarékszövetkezet", "Tksz."},
+	{"takarékszövetkezet", "tksz."},
+	{"Szövetkezeti Hitelintézet", "Szöv. Hit."},
+	{"Kereskedelmi", "Ker."},
+	{"kereskedelmi", "ker."},
+	{"Fiókiroda", "Fiók"},
+	{"fiókiroda", "fiók"},
+	{"Magyarország", "Mo."},
+	{"körút", "krt."},
+	{"utca", "u."},
+	{"út ", "u. "},
+	{"tér", "t."},
+}
+
+// WithFieldLimits sets the maximal length (in characters) of the exported fields,
+// by their name in the header row (Bankszerv, BIC, Nev, Irszam, Cim).
+//
+// A longer field is abbreviated by the abbreviations (DefaultAbbreviations if none is given),
+// in order, until it fits, then it is cut.
+// Each truncation is reported as a TruncatedError.
+func WithFieldLimits(limits map[string]int, abbreviations ...Abbreviation) Option {
+	if len(abbreviations) == 0 {
+		abbreviations = DefaultAbbreviations
+	}
+	return func(o *options) { o.limits, o.abbreviations = limits, abbreviations }
+}
+
+// truncateRow applies the field limits to the exported row of h.
+func (o *options) truncateRow(h Hitelezo, row []string) []string {
+	if len(o.limits) == 0 {
+		return row
+	}
+	for i, name := range exportHeader {
+		max, ok := o.limits[name]
+		if !ok || utf8.RuneCountInString(row[i]) <= max {
+			continue
+		}
+		s := o.truncate(row[i], max)
+		o.warn(&TruncatedError{Bankszerv: h.Bankszerv, Field: name, Value: row[i], Result: s})
+		row[i] = s
+	}
+	return row
+}
+
+// truncate abbreviates s, then cuts it to max characters.
+func (o *options) truncate(s string, max int) string {
+	for _, a := range o.abbreviations {
+		if utf8.RuneCountInString(s) <= max {
+			return s
+		}
+		s = strings.ReplaceAll(s, a.Long, a.Short)
+	}
+	if utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	var n int
+	for i := range s {
+		if n == max {
+			return strings.TrimSpace(s[:i])
+		}
+		n++
+	}
+	return s
+}
@@ -0,0 +1,49 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestFieldLimits(t *testing.T) {
+	hs := []Hitelezo{
+		{Bankszerv: "50400113", Nev: "Dunaföldvár és Vidéke Takarékszövetkezet Paksi Fiókiroda", Cim: "Paks, Dózsa György út 12."},
+		{Bankszerv: "50400106", Nev: "Kunszentmiklósi Takarékszövetkezet", Cim: "Kunszentmiklós"},
+		{Bankszerv: "10002003", Nev: "Magyar Államkincstár", Cim: "Budapest, Váci út 71."},
+		{Bankszerv: "19000002", Nev: "Egy Nagyon Hosszú Nevű Bank Zártkörűen Működő Részvénytársaság Központ", Cim: "Budapest"},
+	}
+	var rep Report
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, hs, WithFieldLimits(map[string]int{"Nev": 35}), WithReport(&rep)); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ReadCSV(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, want := range []string{
+		"Dunaföldvár és Vidéke Tksz. Paksi F",
+		"Kunszentmiklósi Takarékszövetkezet",
+		"Magyar Államkincstár",
+		"Egy Nagyon Hosszú Nevű Bank Zrt. Kö",
+	} {
+		if got[i].Nev != want {
+			t.Errorf("%d. got %q, wanted %q", i, got[i].Nev, want)
+		}
+	}
+	if len(rep.Warnings) != 2 {
+		t.Fatalf("got %d warnings, wanted 2: %v", len(rep.Warnings), rep.Warnings)
+	}
+	var te *TruncatedError
+	if !errors.As(rep.Warnings[0], &te) || !errors.Is(te, ErrTruncated) || te.Field != "Nev" || te.Value != hs[0].Nev {
+		t.Errorf("got %#v", rep.Warnings[0])
+	}
+	if hs[0].Nev != "Dunaföldvár és Vidéke Takarékszövetkezet Paksi Fiókiroda" {
+		t.Errorf("record changed: %q", hs[0].Nev)
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// Upstream is a place Parse(ctx, nil) downloads the list from.
+type Upstream struct {
+	// Name identifies the Upstream in Source.Upstream.
+	Name string
+	// URL of the file, or of the page to search (see SearchXLSURL) if Pattern is not empty.
+	URL, Pattern string
+}
+
+var (
+	// UpstreamMNB is the MNB list (sht.xlsx), the default Upstream.
+	UpstreamMNB = Upstream{Name: "mnb", URL: DefaultXLSXURL}
+	// UpstreamGIRO is the latest EHT/AVT file published by GIRO.
+	UpstreamGIRO = Upstream{Name: "giro", URL: DefaultURL, Pattern: DefaultPattern}
+)
+
+// DefaultUpstreams are the official upstreams: UpstreamMNB then UpstreamGIRO.
+var DefaultUpstreams = []Upstream{UpstreamMNB, UpstreamGIRO}
+
+// WithUpstreams sets the upstreams Parse(ctx, nil) tries in order (default is UpstreamMNB only),
+// returning the first successful parse.
+//
+// Use WithUpstreams(append(DefaultUpstreams, mirrors...)...) to survive the downtime of either site.
+// The winner is recorded in Source.Upstream (see WithSource).
+func WithUpstreams(upstreams ...Upstream) Option {
+	return func(o *options) { o.upstreams = upstreams }
+}
+
+// parseUpstreams downloads and parses the list from the first working upstream.
+func (o *options) parseUpstreams(ctx context.Context, opts []Option) ([]Hitelezo, error) {
+	upstreams := o.upstreams
+	if len(upstreams) == 0 {
+		upstreams = []Upstream{UpstreamMNB}
+	}
+	logger := o.logger(ctx)
+	errs := make([]error, 0, len(upstreams))
+	for _, u := range upstreams {
+		if o.source != nil {
+			*o.source = Source{}
+		}
+		hs, err := u.parse(ctx, append(slices.Clip(opts), func(o *options) { o.upstream = u.Name }))
+		if err == nil {
+			if o.source != nil {
+				o.source.Upstream = u.Name
+			}
+			return hs, nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", u.Name, err))
+		if ctx.Err() != nil {
+			break
+		}
+		logger.Warn("upstream failed", "upstream", u.Name, "url", u.URL, "error", err)
+	}
+	return o.fallbackFor(errors.Join(errs...))
+}
+
+func (u Upstream) parse(ctx context.Context, opts []Option) ([]Hitelezo, error) {
+	URL := u.URL
+	if u.Pattern != "" {
+		var err error
+		if URL, err = SearchXLSURL(ctx, u.URL, u.Pattern, opts...); err != nil {
+			return nil, err
+		}
+	}
+	_, rc, err := DownloadFile(ctx, URL, opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return Parse(ctx, rc, opts...)
+}
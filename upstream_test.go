@@ -0,0 +1,61 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/rogpeppe/retry"
+)
+
+func TestUpstreams(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteXLSX(&buf, []Hitelezo{
+		{Bankszerv: "10002003", Nev: "Magyar Államkincstár", Irszam: "1139", Cim: "Budapest, Váci út 71."},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/sht.xlsx":
+			w.Write(buf.Bytes())
+		case "/garbage.xlsx":
+			w.Write([]byte("garbage"))
+		default:
+			http.Error(w, "down", http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	noRetry := WithRetry(retry.Strategy{MaxCount: 1})
+	down := Upstream{Name: "down", URL: srv.URL + "/down"}
+	garbage := Upstream{Name: "garbage", URL: srv.URL + "/garbage.xlsx"}
+	mirror := Upstream{Name: "mirror", URL: srv.URL + "/sht.xlsx"}
+	var src Source
+	hs, err := Parse(ctx, nil, noRetry, WithSource(&src), WithUpstreams(down, garbage, mirror))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hs) != 1 || src.Upstream != "mirror" || src.URL != mirror.URL || src.Format != FormatXLSX {
+		t.Errorf("got %+v from %+v", hs, src)
+	}
+
+	if _, err = Parse(ctx, nil, noRetry, WithUpstreams(down, garbage)); err == nil {
+		t.Error("wanted error")
+	}
+	var rep Report
+	if hs, err = Parse(ctx, nil, noRetry, WithUpstreams(down), WithFallback(), WithReport(&rep)); err != nil {
+		t.Fatal(err)
+	} else if len(hs) == 0 || len(rep.Warnings) != 1 {
+		t.Errorf("got %d records, warnings %+v", len(hs), rep.Warnings)
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// AccountValidation is the result of validating an account number by ValidateBatch.
+type AccountValidation struct {
+	Input string `json:"input"`
+	// Account is the account number in the usual digit groups (see FormatAccountNumber), if it is of digits.
+	Account string `json:"account,omitempty"`
+	// IBAN is set for the valid account numbers.
+	IBAN string `json:"iban,omitempty"`
+	// Valid reports whether the check digits are right (see ValidateAccountNumber).
+	Valid bool `json:"valid"`
+	// Error is the reason of the invalidity, or of the branch not found.
+	Error string `json:"error,omitempty"`
+	// Branch is the branch of the account number, if found.
+	Branch *Hitelezo `json:"branch,omitempty"`
+}
+
+// ValidateBatch validates the account numbers (or IBANs), and looks up their branches in d (if not nil).
+//
+// An Error is reported for an invalid account number, or a valid one without a branch in d.
+func ValidateBatch(d *Directory, inputs []string) []AccountValidation {
+	vs := make([]AccountValidation, len(inputs))
+	for i, s := range inputs {
+		vs[i] = validateAccount(d, s)
+	}
+	return vs
+}
+
+func validateAccount(d *Directory, s string) AccountValidation {
+	v := AccountValidation{Input: s}
+	t, err := ValidateAccountNumber(s)
+	if t != "" {
+		v.Account = FormatAccountNumber(t)
+	}
+	if err != nil {
+		v.Error = strings.TrimPrefix(err.Error(), strconv.Quote(s)+": ")
+		return v
+	}
+	v.Valid = true
+	v.IBAN, _ = IBAN(t)
+	if d == nil {
+		return v
+	}
+	if h, ok := d.Lookup(t[:8]); ok {
+		v.Branch = &h
+	} else {
+		v.Error = fmt.Sprintf("branch %s: %v", t[:8], ErrNotFound)
+	}
+	return v
+}
@@ -0,0 +1,27 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import "testing"
+
+func TestValidateBatch(t *testing.T) {
+	d := NewDirectory([]Hitelezo{{Bankszerv: "10002003", Nev: "Magyar Államkincstár", Irszam: "1139", Cim: "Budapest"}})
+	vs := ValidateBatch(d, []string{"10002003-00000000", "11773016-11111018", "11773016-11111019", "abc"})
+	if v := vs[0]; !v.Valid || v.Error != "" || v.Branch == nil || v.IBAN == "" {
+		t.Errorf("0. got %+v", v)
+	}
+	if v := vs[1]; !v.Valid || v.Branch != nil || v.Error == "" {
+		t.Errorf("1. got %+v, wanted valid without branch", v)
+	}
+	if v := vs[2]; v.Valid || v.Account != "11773016-11111019" || v.IBAN != "" || v.Error == "" {
+		t.Errorf("2. got %+v", v)
+	}
+	if v := vs[3]; v.Valid || v.Account != "" || v.Error == "" {
+		t.Errorf("3. got %+v", v)
+	}
+	if v := ValidateBatch(nil, []string{"11773016-11111018"})[0]; !v.Valid || v.Error != "" {
+		t.Errorf("without directory: got %+v", v)
+	}
+}
@@ -0,0 +1,31 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !js && !wasip1
+
+package giro
+
+import (
+	"os"
+	"os/exec"
+	"testing"
+)
+
+// TestBuildWasm checks that the module builds for WebAssembly (see ErrUnsupportedInBuild).
+func TestBuildWasm(t *testing.T) {
+	if testing.Short() {
+		t.Skip("short")
+	}
+	goCmd, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip(err)
+	}
+	for _, goos := range []string{"js", "wasip1"} {
+		cmd := exec.Command(goCmd, "build", "-o", os.DevNull, "./...")
+		cmd.Env = append(os.Environ(), "GOOS="+goos, "GOARCH=wasm")
+		if b, err := cmd.CombinedOutput(); err != nil {
+			t.Errorf("GOOS=%s GOARCH=wasm go build ./...: %v\n%s", goos, err, b)
+		}
+	}
+}
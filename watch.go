@@ -0,0 +1,119 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"time"
+)
+
+// NewPublication is emitted by Watch (and to WithEvents) when a new list is published.
+type NewPublication struct {
+	Publication Publication
+	URL         string
+	// EffectiveDate is the date from the file name for the EHT,
+	// and the Last-Modified time of sht.xlsx.
+	EffectiveDate time.Time
+}
+
+func (NewPublication) isEvent() {}
+
+// Watch polls DefaultURL (for a new EHT/AVT file) and DefaultXLSXURL (for a changed sht.xlsx)
+// every interval, and sends the new publications on the returned channel,
+// which is closed when ctx is done.
+//
+// The publications current at the first poll are sent, too.
+// Poll errors are logged, and retried at the next interval.
+func Watch(ctx context.Context, interval time.Duration, opts ...Option) <-chan NewPublication {
+	w := watcher{giroURL: DefaultURL, mnbURL: DefaultXLSXURL, o: newOptions(opts), opts: opts}
+	ch := make(chan NewPublication, 1)
+	go func() {
+		defer close(ch)
+		w.run(ctx, interval, ch)
+	}()
+	return ch
+}
+
+type watcher struct {
+	giroURL, mnbURL string
+	o               *options
+	opts            []Option
+	// last is the last seen version of each Publication.
+	last map[Publication]string
+}
+
+func (w *watcher) run(ctx context.Context, interval time.Duration, ch chan<- NewPublication) {
+	w.last = make(map[Publication]string, 2)
+	logger := w.o.logger(ctx)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		for _, poll := range []func(context.Context) (NewPublication, string, error){w.pollGIRO, w.pollMNB} {
+			pub, version, err := poll(ctx)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				logger.Warn("watch", "error", err)
+				continue
+			}
+			if version == "" || w.last[pub.Publication] == version {
+				continue
+			}
+			w.last[pub.Publication] = version
+			w.o.emit(pub)
+			select {
+			case ch <- pub:
+			case <-ctx.Done():
+				return
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// pollGIRO returns the latest EHT/AVT file, its URL being its version.
+func (w *watcher) pollGIRO(ctx context.Context) (NewPublication, string, error) {
+	URL, err := SearchXLSURL(ctx, w.giroURL, DefaultPattern, w.opts...)
+	if err != nil {
+		return NewPublication{}, "", err
+	}
+	pub := NewPublication{Publication: PublicationEHT, URL: URL}
+	pub.EffectiveDate, _ = EffectiveDate(path.Base(URL))
+	return pub, URL, nil
+}
+
+// pollMNB returns sht.xlsx, its ETag or Last-Modified header being its version.
+func (w *watcher) pollMNB(ctx context.Context) (NewPublication, string, error) {
+	req, err := http.NewRequest("HEAD", w.mnbURL, nil)
+	if err != nil {
+		return NewPublication{}, "", fmt.Errorf("%s: %w", w.mnbURL, err)
+	}
+	resp, err := w.o.do(ctx, w.o.client(), req)
+	if err != nil {
+		return NewPublication{}, "", err
+	}
+	resp.Body.Close()
+	if resp.StatusCode > 399 {
+		return NewPublication{}, "", fmt.Errorf("%s: %s", w.mnbURL, resp.Status)
+	}
+	pub := NewPublication{Publication: PublicationSHT, URL: w.mnbURL}
+	lastModified := resp.Header.Get("Last-Modified")
+	if t, err := http.ParseTime(lastModified); err == nil {
+		pub.EffectiveDate = t
+	}
+	version := resp.Header.Get("ETag")
+	if version == "" {
+		version = lastModified
+	}
+	return pub, version, nil
+}
@@ -0,0 +1,73 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWatch(t *testing.T) {
+	var eht, etag atomic.Value
+	eht.Store("EHT_20260101.pdf")
+	etag.Store(`"1"`)
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/dokumentumok":
+			fmt.Fprintf(w, `<html><body><a href="%s/documents/eht">EHT</a></body></html>`, srv.URL)
+		case "/documents/eht":
+			http.Redirect(w, r, "/files/"+eht.Load().(string), http.StatusFound)
+		case "/sht.xlsx":
+			w.Header().Set("ETag", etag.Load().(string))
+			w.Header().Set("Last-Modified", "Thu, 01 Oct 2026 10:00:00 GMT")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	w := watcher{giroURL: srv.URL + "/dokumentumok", mnbURL: srv.URL + "/sht.xlsx", o: newOptions(nil)}
+	ch := make(chan NewPublication)
+	go func() {
+		defer close(ch)
+		w.run(ctx, 10*time.Millisecond, ch)
+	}()
+	next := func() NewPublication {
+		t.Helper()
+		select {
+		case pub := <-ch:
+			return pub
+		case <-ctx.Done():
+			t.Fatal(ctx.Err())
+		}
+		return NewPublication{}
+	}
+	if pub := next(); pub.Publication != PublicationEHT || pub.URL != srv.URL+"/files/EHT_20260101.pdf" ||
+		!pub.EffectiveDate.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("got %+v", pub)
+	}
+	if pub := next(); pub.Publication != PublicationSHT || pub.EffectiveDate.Month() != time.October {
+		t.Errorf("got %+v", pub)
+	}
+	eht.Store("EHT_20260201.pdf")
+	if pub := next(); pub.Publication != PublicationEHT || pub.URL != srv.URL+"/files/EHT_20260201.pdf" {
+		t.Errorf("got %+v", pub)
+	}
+	etag.Store(`"2"`)
+	if pub := next(); pub.Publication != PublicationSHT {
+		t.Errorf("got %+v", pub)
+	}
+	cancel()
+	for range ch {
+	}
+}
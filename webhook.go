@@ -0,0 +1,93 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// SignatureHeader is the header of the HMAC-SHA256 signature of the webhook body,
+// as "sha256=" + hex.
+const SignatureHeader = "X-Giro-Signature"
+
+// Webhook is notified with a ChangeSummary when the list changes.
+type Webhook struct {
+	// URL to POST the JSON ChangeSummary to.
+	URL string
+	// Secret is the HMAC-SHA256 key of the signature in SignatureHeader,
+	// no signature is sent if empty.
+	Secret []byte
+	// Client is the client set by WithTLSConfig (or http.DefaultClient) if nil.
+	Client *http.Client
+}
+
+// ChangeSummary is the body sent to the Webhook.
+type ChangeSummary struct {
+	// EffectiveDate is the date the new list is valid from (2006-01-02), if known.
+	EffectiveDate string `json:"effectiveDate,omitempty"`
+	Added         int    `json:"added"`
+	Removed       int    `json:"removed"`
+	Modified      int    `json:"modified"`
+	Total         int    `json:"total"`
+}
+
+// Summarize returns the ChangeSummary of c, for a new list of total records.
+func (c Changes) Summarize(effective time.Time, total int) ChangeSummary {
+	s := ChangeSummary{Added: len(c.Added), Removed: len(c.Removed), Modified: len(c.Modified), Total: total}
+	if !effective.IsZero() {
+		s.EffectiveDate = effective.Format(time.DateOnly)
+	}
+	return s
+}
+
+// Notify POSTs the summary to the webhook, retrying as set by WithRetry.
+func (w *Webhook) Notify(ctx context.Context, summary ChangeSummary, opts ...Option) error {
+	o := newOptions(opts)
+	b, err := json.Marshal(summary)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("POST", w.URL, bytes.NewReader(b))
+	if err != nil {
+		return fmt.Errorf("%s: %w", w.URL, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(w.Secret) != 0 {
+		req.Header.Set(SignatureHeader, Sign(w.Secret, b))
+	}
+	client := w.Client
+	if client == nil {
+		client = o.client()
+	}
+	resp, err := o.do(ctx, client, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode > 299 {
+		var buf bytes.Buffer
+		_, _ = io.Copy(&buf, io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("%s: %s: %s", w.URL, resp.Status, buf.String())
+	}
+	return nil
+}
+
+// Sign returns the signature of body, as sent in SignatureHeader.
+//
+// The receiver should compare it with hmac.Equal.
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
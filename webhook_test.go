@@ -0,0 +1,74 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"context"
+	"crypto/hmac"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rogpeppe/retry"
+)
+
+func TestWebhook(t *testing.T) {
+	secret := []byte("secret")
+	var n atomic.Int32
+	summaries := make(chan ChangeSummary, 4)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		if n.Add(1) == 1 {
+			http.Error(w, "busy", http.StatusServiceUnavailable)
+			return
+		}
+		if !hmac.Equal([]byte(r.Header.Get(SignatureHeader)), []byte(Sign(secret, b))) {
+			http.Error(w, "bad signature", http.StatusForbidden)
+			return
+		}
+		var s ChangeSummary
+		if err := json.Unmarshal(b, &s); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		summaries <- s
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	records := []Hitelezo{{Bankszerv: "10002003", Nev: "MÁK"}}
+	r := Refresher{
+		Directory: NewDirectory(nil),
+		Interval:  time.Hour,
+		Fetch:     func(context.Context, *Source) ([]Hitelezo, error) { return records, nil },
+		Options:   []Option{WithRetry(retry.Strategy{Delay: time.Millisecond, MaxCount: 3})},
+		Webhook:   &Webhook{URL: srv.URL, Secret: secret},
+	}
+	if err := r.TriggerRefresh(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got := <-summaries; got != (ChangeSummary{Added: 1, Total: 1}) {
+		t.Errorf("got %+v", got)
+	}
+	// no change, no notification
+	if err := r.TriggerRefresh(ctx); err != nil {
+		t.Fatal(err)
+	}
+	records = []Hitelezo{{Bankszerv: "10002003", Nev: "Magyar Államkincstár"}, {Bankszerv: "11773016", Nev: "OTP"}}
+	if err := r.TriggerRefresh(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got := <-summaries; got != (ChangeSummary{Added: 1, Modified: 1, Total: 2}) {
+		t.Errorf("got %+v", got)
+	}
+	if n.Load() != 3 {
+		t.Errorf("got %d requests, wanted 3", n.Load())
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"bufio"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// XMLVersion is the version of the XML schema written by WriteXML.
+const XMLVersion = "1"
+
+// The XML schema (see contrib/hitelezok.xsd) is
+//
+//	<?xml version="1.0" encoding="UTF-8"?>
+//	<Hitelezok version="1">
+//	  <Hitelezo>
+//	    <Bankszerv>10002003</Bankszerv>
+//	    <BIC>HUSTHUHB</BIC>
+//	    <Nev>Magyar Államkincstár</Nev>
+//	    <Irszam>1139</Irszam>
+//	    <Cim>Budapest, Váci út 71.</Cim>
+//	  </Hitelezo>
+//	</Hitelezok>
+//
+// with all the fields as text (keeping the leading zeros), in this order;
+// the empty BIC, and the NonStdIrszam and SearchKey elements are omitted.
+
+// xmlHitelezo is the Hitelezo element of the XML.
+type xmlHitelezo struct {
+	XMLName      xml.Name `xml:"Hitelezo"`
+	Bankszerv    string   `xml:"Bankszerv"`
+	BIC          string   `xml:"BIC,omitempty"`
+	Nev          string   `xml:"Nev"`
+	Irszam       string   `xml:"Irszam"`
+	Cim          string   `xml:"Cim"`
+	NonStdIrszam bool     `xml:"NonStdIrszam,omitempty"`
+	SearchKey    string   `xml:"SearchKey,omitempty"`
+}
+
+// WriteXML writes the records as XML, in the schema of XMLVersion,
+// with the display name, transliterated and truncated as the other exporters.
+func WriteXML(w io.Writer, hs []Hitelezo, opts ...Option) error {
+	o := newOptions(opts)
+	bw := bufio.NewWriter(w)
+	bw.WriteString(xml.Header)
+	enc := xml.NewEncoder(bw)
+	enc.Indent("", "  ")
+	root := xml.StartElement{
+		Name: xml.Name{Local: "Hitelezok"},
+		Attr: []xml.Attr{{Name: xml.Name{Local: "version"}, Value: XMLVersion}},
+	}
+	if err := enc.EncodeToken(root); err != nil {
+		return err
+	}
+	for _, h := range hs {
+		row := o.exportRow(h)
+		if err := enc.Encode(xmlHitelezo{
+			Bankszerv: row[0], BIC: row[1], Nev: row[2], Irszam: row[3], Cim: row[4],
+			NonStdIrszam: h.NonStdIrszam, SearchKey: h.SearchKey,
+		}); err != nil {
+			return err
+		}
+	}
+	if err := enc.EncodeToken(root.End()); err != nil {
+		return err
+	}
+	if err := enc.Close(); err != nil {
+		return err
+	}
+	bw.WriteByte('\n')
+	return bw.Flush()
+}
+
+// ParseXML reads the records written by WriteXML, element by element.
+//
+// The unknown elements and attributes are skipped, for the later versions of the schema.
+func ParseXML(r io.Reader) ([]Hitelezo, error) {
+	dec := xml.NewDecoder(r)
+	var hs []Hitelezo
+	var rootSeen bool
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				if !rootSeen {
+					return nil, fmt.Errorf("no Hitelezok element: %w", ErrNotFound)
+				}
+				return hs, nil
+			}
+			return hs, err
+		}
+		se, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch se.Name.Local {
+		case "Hitelezok":
+			rootSeen = true
+		case "Hitelezo":
+			var x xmlHitelezo
+			if err := dec.DecodeElement(&x, &se); err != nil {
+				line, _ := dec.InputPos()
+				return hs, fmt.Errorf("line %d: %w", line, err)
+			}
+			hs = append(hs, Hitelezo{
+				Bankszerv: x.Bankszerv, BIC: x.BIC, Nev: x.Nev, Irszam: x.Irszam, Cim: x.Cim,
+				NonStdIrszam: x.NonStdIrszam, SearchKey: x.SearchKey,
+			})
+		default:
+			if err := dec.Skip(); err != nil {
+				return hs, err
+			}
+		}
+	}
+}
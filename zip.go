@@ -0,0 +1,103 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// FormatZIP is a ZIP archive wrapping the published file (but not an XLSX or ODS).
+const FormatZIP = Format("zip")
+
+// maxZIPEntry is the maximum uncompressed size of the file parsed from a ZIP archive.
+const maxZIPEntry = 1 << 30
+
+// zipEntryExts are the extensions of the parseable files in a ZIP archive, in the order of preference.
+var zipEntryExts = []string{".xlsx", ".xls", ".pdf", ".html", ".htm", ".csv"}
+
+var rDefaultPattern = regexp.MustCompile(DefaultPattern)
+
+// detectZIP tells whether the ZIP file starting with b is an XLSX, an ODS or an archive wrapping them.
+//
+// Without the size of r, the central directory cannot be read, thus it is reported as FormatXLSX.
+func detectZIP(r io.ReaderAt, b []byte) Format {
+	if bytes.Contains(b, odsMimetype) {
+		return FormatODS
+	}
+	sizer, ok := r.(interface{ Size() int64 })
+	if !ok {
+		return FormatXLSX
+	}
+	zr, err := zip.NewReader(r, sizer.Size())
+	if err != nil {
+		// let excelize report the error
+		return FormatXLSX
+	}
+	for _, f := range zr.File {
+		if f.Name == "[Content_Types].xml" || strings.HasPrefix(f.Name, "xl/") {
+			return FormatXLSX
+		}
+	}
+	return FormatZIP
+}
+
+// zipEntry returns the file to parse from the ZIP archive:
+// the first one whose name matches DefaultPattern, or else the first one by zipEntryExts.
+func zipEntry(zr *zip.Reader) *zip.File {
+	var best *zip.File
+	bestRank := len(zipEntryExts)
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() || strings.HasPrefix(f.Name, "__MACOSX/") {
+			continue
+		}
+		base := path.Base(f.Name)
+		if rDefaultPattern.MatchString(base) {
+			return f
+		}
+		for i, ext := range zipEntryExts[:bestRank] {
+			if strings.EqualFold(path.Ext(base), ext) {
+				best, bestRank = f, i
+				break
+			}
+		}
+	}
+	return best
+}
+
+// openZIP returns the contents of the file to parse from the ZIP archive (see zipEntry),
+// recording its name in the Source.
+func (o *options) openZIP(sr *io.SectionReader) (*io.SectionReader, error) {
+	zr, err := zip.NewReader(sr, sr.Size())
+	if err != nil {
+		return nil, err
+	}
+	f := zipEntry(zr)
+	if f == nil {
+		return nil, fmt.Errorf("no %s in the ZIP archive: %w", strings.Join(zipEntryExts, ", "), ErrFormatUnknown)
+	}
+	if f.UncompressedSize64 > maxZIPEntry {
+		return nil, fmt.Errorf("%s: %d bytes is too big", f.Name, f.UncompressedSize64)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", f.Name, err)
+	}
+	defer rc.Close()
+	inner, err := o.sectionReader(io.LimitReader(rc, maxZIPEntry))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", f.Name, err)
+	}
+	if o.source != nil {
+		o.source.Entry = f.Name
+		o.source.setFilenameDate(path.Base(f.Name))
+	}
+	return inner, nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package giro
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+)
+
+func makeZIP(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestParseZIP(t *testing.T) {
+	const doc = `<html><body><table>
+<tr><th>Bankszerv</th><th>Név</th><th>Irsz.</th><th>Cím</th></tr>
+<tr><td>10002003</td><td>Magyar Államkincstár</td><td>1139</td><td>Budapest, Váci út 71.</td></tr>
+</table></body></html>`
+	b := makeZIP(t, map[string]string{
+		"olvass.txt":           "Egyéb fájl",
+		"__MACOSX/._lista.htm": "\x00\x05\x16\x07",
+		"2026/lista.htm":       doc,
+	})
+	if got := DetectFormat(bytes.NewReader(b)); got != FormatZIP {
+		t.Errorf("DetectFormat: got %q, wanted %q", got, FormatZIP)
+	}
+	var src Source
+	hs, err := Parse(context.Background(), bytes.NewReader(b), WithSource(&src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hs) != 1 || hs[0].Bankszerv != "10002003" {
+		t.Errorf("got %+v", hs)
+	}
+	if src.Format != FormatHTML || src.Entry != "2026/lista.htm" {
+		t.Errorf("got source %+v", src)
+	}
+
+	b = makeZIP(t, map[string]string{"olvass.txt": "Egyéb fájl"})
+	if _, err = Parse(context.Background(), bytes.NewReader(b)); !errors.Is(err, ErrFormatUnknown) {
+		t.Errorf("got %+v, wanted ErrFormatUnknown", err)
+	}
+}
+
+func TestZIPEntry(t *testing.T) {
+	b := makeZIP(t, map[string]string{
+		"a.pdf":            "%PDF-1.4",
+		"b.xlsx":           "PK",
+		"EHT_20260102.pdf": "%PDF-1.4",
+		"docs/readme.html": "<html>",
+	})
+	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f := zipEntry(zr); f == nil || f.Name != "EHT_20260102.pdf" {
+		t.Errorf("got %+v, wanted EHT_20260102.pdf", f)
+	}
+}